@@ -8,19 +8,115 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v1/requester"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
 )
 
 // Config represents the application configuration.
 type Config struct {
-	Server ServerConfig
+	Server    ServerConfig
+	Health    HealthConfig    `toml:"health"`
+	RateLimit RateLimitConfig `toml:"rate_limit"`
+	APIKeys   APIKeysConfig   `toml:"api_keys"`
+	Logging   logging.Config  `toml:"logging"`
+	// ProxyCache configures the cache of the node responses served by the v1 routes
+	ProxyCache db.ProxyCacheConfig `toml:"proxy_cache"`
+	Upstream   UpstreamConfig      `toml:"upstream"`
+	// Registry selects where the chain-token-registry and validator-directory files are read from
+	Registry requester.RegistryConfig `toml:"registry"`
 }
 
 // ServerConfig represents the server configuration.
-// TODO: add more server options
+// Every field can be overridden by the environment variable
+// documented next to it.
 type ServerConfig struct {
+	// RPC_SERVER_PORT
 	Port int
+	// RPC_SERVER_READ_TIMEOUT: maximum duration for reading the full request, including the body
+	ReadTimeout time.Duration `toml:"read_timeout"`
+	// RPC_SERVER_WRITE_TIMEOUT: maximum duration before timing out writes of the response
+	WriteTimeout time.Duration `toml:"write_timeout"`
+	// RPC_SERVER_IDLE_TIMEOUT: maximum time to wait for the next request when keep-alive is enabled
+	IdleTimeout time.Duration `toml:"idle_timeout"`
+	// RPC_SERVER_MAX_REQUEST_BODY_SIZE: maximum request body size in bytes
+	MaxRequestBodySize int `toml:"max_request_body_size"`
+	// RPC_SERVER_CONCURRENCY: maximum number of concurrent connections the server may serve
+	Concurrency int `toml:"concurrency"`
+	// RPC_SERVER_MAX_CONNS_PER_IP: maximum number of concurrent client connections allowed per IP
+	MaxConnsPerIP int `toml:"max_conns_per_ip"`
+	// RPC_SERVER_MAX_REQUESTS_PER_CONN: maximum number of requests served per keep-alive connection
+	MaxRequestsPerConn int `toml:"max_requests_per_conn"`
+	// RPC_SERVER_MAX_KEEPALIVE_DURATION: maximum lifetime of a keep-alive connection
+	MaxKeepaliveDuration time.Duration `toml:"max_keepalive_duration"`
+	// RPC_SERVER_DISABLE_KEEPALIVE: close every connection after sending the first response
+	DisableKeepalive bool `toml:"disable_keepalive"`
+	// RPC_SERVER_SHUTDOWN_TIMEOUT: maximum time to wait for in-flight requests on shutdown
+	ShutdownTimeout time.Duration `toml:"shutdown_timeout"`
+}
+
+// HealthConfig represents the readiness checks configuration.
+type HealthConfig struct {
+	// maximum duration of a single dependency check
+	Timeout time.Duration `toml:"timeout"`
+	// maximum age of the endpoint rankings published by the endpoint cron
+	EndpointsMaxAge time.Duration `toml:"endpoints_max_age"`
+	// maximum age of the prices published by the price cron
+	PricesMaxAge time.Duration `toml:"prices_max_age"`
+	// maximum age of the network config stored in redis
+	NetworkConfigMaxAge time.Duration `toml:"network_config_max_age"`
+}
+
+// RateLimitConfig represents the rate limiting configuration.
+type RateLimitConfig struct {
+	// RATE_LIMIT_ENABLED
+	Enabled bool `toml:"enabled"`
+	// use the X-Forwarded-For header set by the reverse proxies to identify clients
+	TrustProxy bool `toml:"trust_proxy"`
+	// number of reverse proxies appending to X-Forwarded-For in front of the server, 1 when zero
+	TrustedProxies int `toml:"trusted_proxies"`
+	// routes that are never rate limited
+	Exempt []string `toml:"exempt"`
+	// policy applied to the routes that do not belong to any other policy
+	Default RateLimitPolicy `toml:"default"`
+	// policies per route group, the first match is applied
+	Policies []RateLimitPolicy `toml:"policies"`
+	// factor applied to the policies for clients with an API key
+	AuthenticatedMultiplier float64 `toml:"authenticated_multiplier"`
+}
+
+// RateLimitPolicy represents a token-bucket limit applied to a route group.
+// Routes use the router syntax, e.g. /ERC20ModuleBalance/{evmos_address}/{eth_address}.
+type RateLimitPolicy struct {
+	Name   string   `toml:"name"`
+	Routes []string `toml:"routes"`
+	// tokens added to the bucket per second, 0 disables the limit
+	Rate float64 `toml:"rate"`
+	// maximum number of tokens in the bucket
+	Burst int `toml:"burst"`
+}
+
+// UpstreamConfig represents the requests sent to the nodes.
+type UpstreamConfig struct {
+	// UPSTREAM_REQUEST_TIMEOUT: maximum time spent on the node requests sent on behalf of a client request
+	RequestTimeout time.Duration `toml:"request_timeout"`
+	// UPSTREAM_HEDGE_DELAY: delay before a GET request is also sent to the next ranked node, 0 disables hedging
+	HedgeDelay time.Duration `toml:"hedge_delay"`
+}
+
+// APIKeysConfig represents the API keys configuration.
+type APIKeysConfig struct {
+	// API_KEYS_ENABLED
+	Enabled bool `toml:"enabled"`
+	// header carrying the API key
+	Header string `toml:"header"`
+	// ADMIN_API_TOKEN: bearer token required by the admin routes, they are disabled when empty
+	AdminToken string `toml:"admin_token"`
+	// daily quota of the keys created without an explicit one, 0 means unlimited
+	DefaultDailyQuota int64 `toml:"default_daily_quota"`
 }
 
 // LoadConfig loads the application configuration from environment variables
@@ -39,10 +135,115 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to decode default config file: %w", err)
 	}
 
+	if err := loadServerEnv(&cfg.Server); err != nil {
+		return nil, err
+	}
+
+	if err := overrideBool("RATE_LIMIT_ENABLED", &cfg.RateLimit.Enabled); err != nil {
+		return nil, err
+	}
+
+	if err := overrideBool("API_KEYS_ENABLED", &cfg.APIKeys.Enabled); err != nil {
+		return nil, err
+	}
+	if token := os.Getenv("ADMIN_API_TOKEN"); token != "" {
+		cfg.APIKeys.AdminToken = token
+	}
+
+	if err := overrideDuration("UPSTREAM_REQUEST_TIMEOUT", &cfg.Upstream.RequestTimeout); err != nil {
+		return nil, err
+	}
+	if err := overrideDuration("UPSTREAM_HEDGE_DELAY", &cfg.Upstream.HedgeDelay); err != nil {
+		return nil, err
+	}
+
+	if err := overrideBool("PROXY_CACHE_STALE_WHILE_REVALIDATE", &cfg.ProxyCache.StaleWhileRevalidate); err != nil {
+		return nil, err
+	}
+
+	cfg.Logging = cfg.Logging.LoadEnv()
+	cfg.Registry = cfg.Registry.LoadEnv()
+
+	return cfg, nil
+}
+
+// loadServerEnv overrides the server configuration with the values
+// of the environment variables that are set.
+func loadServerEnv(cfg *ServerConfig) error {
 	port, err := strconv.Atoi(os.Getenv("RPC_SERVER_PORT"))
 	if err == nil && port != 0 {
-		cfg.Server.Port = port
+		cfg.Port = port
 	}
 
-	return cfg, nil
+	durations := map[string]*time.Duration{
+		"RPC_SERVER_READ_TIMEOUT":           &cfg.ReadTimeout,
+		"RPC_SERVER_WRITE_TIMEOUT":          &cfg.WriteTimeout,
+		"RPC_SERVER_IDLE_TIMEOUT":           &cfg.IdleTimeout,
+		"RPC_SERVER_MAX_KEEPALIVE_DURATION": &cfg.MaxKeepaliveDuration,
+		"RPC_SERVER_SHUTDOWN_TIMEOUT":       &cfg.ShutdownTimeout,
+	}
+	for name, field := range durations {
+		if err := overrideDuration(name, field); err != nil {
+			return err
+		}
+	}
+
+	ints := map[string]*int{
+		"RPC_SERVER_MAX_REQUEST_BODY_SIZE": &cfg.MaxRequestBodySize,
+		"RPC_SERVER_CONCURRENCY":           &cfg.Concurrency,
+		"RPC_SERVER_MAX_CONNS_PER_IP":      &cfg.MaxConnsPerIP,
+		"RPC_SERVER_MAX_REQUESTS_PER_CONN": &cfg.MaxRequestsPerConn,
+	}
+	for name, field := range ints {
+		if err := overrideInt(name, field); err != nil {
+			return err
+		}
+	}
+
+	return overrideBool("RPC_SERVER_DISABLE_KEEPALIVE", &cfg.DisableKeepalive)
+}
+
+// overrideDuration sets field to the duration stored in the environment
+// variable name, e.g. "15s", if it is set.
+func overrideDuration(name string, field *time.Duration) error {
+	val, ok := os.LookupEnv(name)
+	if !ok || val == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		return fmt.Errorf("invalid duration for %s: %w", name, err)
+	}
+	*field = d
+	return nil
+}
+
+// overrideInt sets field to the integer stored in the environment
+// variable name if it is set.
+func overrideInt(name string, field *int) error {
+	val, ok := os.LookupEnv(name)
+	if !ok || val == "" {
+		return nil
+	}
+	i, err := strconv.Atoi(val)
+	if err != nil {
+		return fmt.Errorf("invalid integer for %s: %w", name, err)
+	}
+	*field = i
+	return nil
+}
+
+// overrideBool sets field to the boolean stored in the environment
+// variable name if it is set.
+func overrideBool(name string, field *bool) error {
+	val, ok := os.LookupEnv(name)
+	if !ok || val == "" {
+		return nil
+	}
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		return fmt.Errorf("invalid boolean for %s: %w", name, err)
+	}
+	*field = b
+	return nil
 }
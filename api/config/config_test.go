@@ -0,0 +1,52 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+func TestDecodeServerConfig(t *testing.T) {
+	cfg := &Config{}
+	if _, err := toml.DecodeFile("config.toml", cfg); err != nil {
+		t.Fatalf("Error decoding config file: %s", err)
+	}
+
+	if cfg.Server.Port != 8081 {
+		t.Fatalf("Invalid port %d", cfg.Server.Port)
+	}
+	if cfg.Server.ReadTimeout != 10*time.Second {
+		t.Fatalf("Invalid read timeout %v", cfg.Server.ReadTimeout)
+	}
+	if cfg.Server.ShutdownTimeout != 30*time.Second {
+		t.Fatalf("Invalid shutdown timeout %v", cfg.Server.ShutdownTimeout)
+	}
+	if cfg.ProxyCache.StaleWhileRevalidate {
+		t.Fatalf("Stale-while-revalidate must be off by default")
+	}
+}
+
+func TestLoadServerEnv(t *testing.T) {
+	t.Setenv("RPC_SERVER_PORT", "9000")
+	t.Setenv("RPC_SERVER_WRITE_TIMEOUT", "3s")
+	t.Setenv("RPC_SERVER_CONCURRENCY", "42")
+	t.Setenv("RPC_SERVER_DISABLE_KEEPALIVE", "true")
+
+	cfg := ServerConfig{Port: 8081, WriteTimeout: time.Second}
+	if err := loadServerEnv(&cfg); err != nil {
+		t.Fatalf("Error loading env: %s", err)
+	}
+
+	if cfg.Port != 9000 || cfg.WriteTimeout != 3*time.Second || cfg.Concurrency != 42 || !cfg.DisableKeepalive {
+		t.Fatalf("Env overrides were not applied: %+v", cfg)
+	}
+
+	t.Setenv("RPC_SERVER_IDLE_TIMEOUT", "forever")
+	if err := loadServerEnv(&cfg); err == nil {
+		t.Fatalf("Invalid duration must return an error")
+	}
+}
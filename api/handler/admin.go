@@ -0,0 +1,152 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package handler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v2/apikeys"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/valyala/fasthttp"
+)
+
+// CreateAPIKeyParams represents the parameters for the POST /admin/apikeys endpoint.
+type CreateAPIKeyParams struct {
+	// name of the integrator using the key
+	Name string `json:"name"`
+	// maximum number of requests per UTC day, the configured default is used when nil
+	DailyQuota *int64 `json:"daily_quota"`
+	// factor applied to the rate limits, the configured default is used when 0
+	RateMultiplier float64 `json:"rate_multiplier"`
+}
+
+// CreateAPIKeyResponse represents the response for the POST /admin/apikeys endpoint.
+type CreateAPIKeyResponse struct {
+	// secret to be sent in the API key header, it can not be retrieved again
+	Key    string      `json:"key"`
+	APIKey apikeys.Key `json:"api_key"`
+}
+
+// APIKeyResponse represents the response for the GET /admin/apikeys/{id} endpoint.
+type APIKeyResponse struct {
+	APIKey     apikeys.Key `json:"api_key"`
+	UsageToday int64       `json:"usage_today"`
+}
+
+type adminErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// requireAdmin rejects the requests that do not carry the admin token as a bearer token.
+// The admin routes are disabled when no admin token is configured.
+func (h *Handler) requireAdmin(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		token := h.cfg.APIKeys.AdminToken
+		if token == "" {
+			sendJSON(ctx, http.StatusNotFound, adminErrorResponse{Error: "Not found"})
+			return
+		}
+
+		auth := string(ctx.Request.Header.Peek("Authorization"))
+		bearer := strings.TrimPrefix(auth, "Bearer ")
+		if bearer == auth || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			sendJSON(ctx, http.StatusUnauthorized, adminErrorResponse{Error: "Unauthorized"})
+			return
+		}
+		next(ctx)
+	}
+}
+
+// CreateAPIKey handles POST /admin/apikeys.
+// It creates a new API key, the secret is only returned in this response.
+// Returns:
+//
+//	{
+//	  "key": "9f2c...",
+//	  "api_key": {"id": "4a1b2c3d4e5f6a7b", "name": "partner", "daily_quota": 100000, "rate_multiplier": 0, "created_at": "..."}
+//	}
+func (h *Handler) CreateAPIKey(ctx *fasthttp.RequestCtx) {
+	params := CreateAPIKeyParams{}
+	if err := json.Unmarshal(ctx.PostBody(), &params); err != nil {
+		sendJSON(ctx, http.StatusBadRequest, adminErrorResponse{Error: "Invalid request body"})
+		return
+	}
+	if params.Name == "" {
+		sendJSON(ctx, http.StatusBadRequest, adminErrorResponse{Error: "name cannot be empty"})
+		return
+	}
+
+	dailyQuota := h.cfg.APIKeys.DefaultDailyQuota
+	if params.DailyQuota != nil {
+		dailyQuota = *params.DailyQuota
+	}
+	if dailyQuota < 0 || params.RateMultiplier < 0 {
+		sendJSON(ctx, http.StatusBadRequest, adminErrorResponse{Error: "daily_quota and rate_multiplier cannot be negative"})
+		return
+	}
+
+	key, secret, err := h.apiKeys.Create(params.Name, dailyQuota, params.RateMultiplier)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error creating API key", "error", err)
+		sendJSON(ctx, http.StatusInternalServerError, adminErrorResponse{Error: "Something went wrong, please try again later"})
+		return
+	}
+	sendJSON(ctx, http.StatusCreated, CreateAPIKeyResponse{Key: secret, APIKey: key})
+}
+
+// ListAPIKeys handles GET /admin/apikeys.
+// It returns every API key, including the revoked ones.
+func (h *Handler) ListAPIKeys(ctx *fasthttp.RequestCtx) {
+	keys, err := h.apiKeys.List()
+	if err != nil {
+		logging.FromContext(ctx).Error("Error listing API keys", "error", err)
+		sendJSON(ctx, http.StatusInternalServerError, adminErrorResponse{Error: "Something went wrong, please try again later"})
+		return
+	}
+	sendJSON(ctx, http.StatusOK, keys)
+}
+
+// GetAPIKey handles GET /admin/apikeys/{id}.
+// It returns the API key and the number of requests made with it today.
+func (h *Handler) GetAPIKey(ctx *fasthttp.RequestCtx) {
+	id, _ := ctx.UserValue("id").(string)
+	key, err := h.apiKeys.Get(id)
+	if err != nil {
+		h.sendAPIKeyError(ctx, err)
+		return
+	}
+
+	usage, err := h.apiKeys.Usage(id, time.Now())
+	if err != nil {
+		h.sendAPIKeyError(ctx, err)
+		return
+	}
+	sendJSON(ctx, http.StatusOK, APIKeyResponse{APIKey: key, UsageToday: usage})
+}
+
+// RevokeAPIKey handles DELETE /admin/apikeys/{id}.
+// The key is kept for auditing but every request made with it is rejected.
+func (h *Handler) RevokeAPIKey(ctx *fasthttp.RequestCtx) {
+	id, _ := ctx.UserValue("id").(string)
+	key, err := h.apiKeys.Revoke(id)
+	if err != nil {
+		h.sendAPIKeyError(ctx, err)
+		return
+	}
+	sendJSON(ctx, http.StatusOK, key)
+}
+
+func (h *Handler) sendAPIKeyError(ctx *fasthttp.RequestCtx, err error) {
+	if errors.Is(err, apikeys.ErrNotFound) {
+		sendJSON(ctx, http.StatusNotFound, adminErrorResponse{Error: "API key not found"})
+		return
+	}
+	logging.FromContext(ctx).Error("Error reading API key", "error", err)
+	sendJSON(ctx, http.StatusInternalServerError, adminErrorResponse{Error: "Something went wrong, please try again later"})
+}
@@ -4,20 +4,58 @@
 package handler
 
 import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/tharsis/dashboard-backend/api/config"
+	v1 "github.com/tharsis/dashboard-backend/api/handler/v1"
 	"github.com/tharsis/dashboard-backend/api/handler/v2"
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v2/apikeys"
+	"github.com/tharsis/dashboard-backend/internal/v2/numia"
 )
 
 type Handler struct {
-	v2 *v2.Handler
+	cfg            *config.Config
+	store          db.Store // store used by the readiness checks
+	v1             *v1.Handler
+	v2             *v2.Handler
+	numiaRPCClient *numia.RPCClient // client used by the readiness checks
+	apiKeys        apikeys.Store    // store used by the admin routes
+	// chains whose endpoint rankings were last checked by the readiness probe
+	readinessChains atomic.Value
+	// OpenAPI document served at /openapi.json, built once on startup
+	openAPIDocument []byte
+	// registry reloads started by the GitHub webhook, run one at a time
+	registryReloads  sync.WaitGroup
+	registryReloadMu sync.Mutex
 }
 
-func New() (*Handler, error) {
-	v2Handler, err := v2.NewHandler()
+func New(cfg *config.Config, store db.Store) (*Handler, error) {
+	v2Handler, err := v2.NewHandler(store)
+	if err != nil {
+		return nil, err
+	}
+
+	numiaRPCClient, err := numia.NewRPCClient()
 	if err != nil {
 		return nil, err
 	}
 
-	return &Handler{
-		v2: v2Handler,
-	}, nil
+	h := &Handler{
+		cfg:            cfg,
+		store:          store,
+		v1:             v1.NewHandler(store),
+		v2:             v2Handler,
+		numiaRPCClient: numiaRPCClient,
+		apiKeys:        apikeys.NewStore(store),
+	}
+
+	h.openAPIDocument, err = json.Marshal(h.newOpenAPIDocument())
+	if err != nil {
+		return nil, fmt.Errorf("error encoding openapi document: %w", err)
+	}
+	return h, nil
 }
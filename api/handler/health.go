@@ -0,0 +1,177 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/constants"
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v1/resources"
+	"github.com/tharsis/dashboard-backend/internal/v2/health"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/valyala/fasthttp"
+)
+
+// Live handles GET /health/live.
+// It only reports that the process is up and serving requests.
+func (h *Handler) Live(ctx *fasthttp.RequestCtx) {
+	sendJSON(ctx, http.StatusOK, StatusResponse{Status: string(health.StatusOK)})
+}
+
+// Ready handles GET /health/ready.
+// It checks every dependency of the API and returns 503 when a critical one is down.
+// Returns:
+//
+//	{
+//	  "status": "degraded",
+//	  "checks": [
+//	    {"name": "redis", "status": "ok", "critical": true, "duration_ms": 0.4},
+//	    {"name": "prices", "status": "fail", "critical": false, "message": "...", "duration_ms": 0.3}
+//	  ]
+//	}
+func (h *Handler) Ready(ctx *fasthttp.RequestCtx) {
+	// The checks that time out keep running after the response is sent,
+	// they must not use the fasthttp request context which is reused by then
+	checkCtx := logging.NewContext(context.Background(), logging.RequestID(ctx), logging.FromContext(ctx))
+	report := health.Run(h.readinessChecks(checkCtx), h.cfg.Health.Timeout)
+
+	status := http.StatusOK
+	if report.Status == health.StatusUnavailable {
+		status = http.StatusServiceUnavailable
+	}
+	sendJSON(ctx, status, report)
+}
+
+// readinessChecks builds the list of checks run by the readiness probe.
+// The endpoint rankings are checked for every chain in the network config.
+func (h *Handler) readinessChecks(ctx context.Context) []health.Check {
+	cfg := h.cfg.Health
+
+	checks := []health.Check{
+		{
+			Name:     "redis",
+			Critical: true,
+			Run: func() (string, error) {
+				return "", db.RedisPing(h.store)
+			},
+		},
+		{
+			Name:     "numia",
+			Critical: true,
+			Run: func() (string, error) {
+				res, err := h.numiaRPCClient.QueryHeight(ctx)
+				if err != nil {
+					return "", err
+				}
+				if res.LatestBlockHeight == "" {
+					return "", fmt.Errorf("empty height in numia response")
+				}
+				return "height " + res.LatestBlockHeight, nil
+			},
+		},
+		{
+			Name:     "prices",
+			Critical: false,
+			Run: func() (string, error) {
+				updatedAt, err := db.RedisGetPricesUpdatedAt(h.store)
+				if err != nil {
+					return "", fmt.Errorf("prices have never been published: %w", err)
+				}
+				return health.Freshness(updatedAt, cfg.PricesMaxAge)
+			},
+		},
+	}
+
+	// Read the age before loading the configs, loading them may refresh the cache
+	networkConfigUpdatedAt, networkConfigErr := db.RedisGetNetworkConfigUpdatedAt(h.store)
+	checks = append(checks, health.Check{
+		Name:     "network_config",
+		Critical: true,
+		Run: func() (string, error) {
+			if networkConfigErr != nil {
+				return "", fmt.Errorf("network config has never been stored: %w", networkConfigErr)
+			}
+			return health.Freshness(networkConfigUpdatedAt, cfg.NetworkConfigMaxAge)
+		},
+	})
+
+	chains, err := h.loadReadinessChains(cfg.Timeout)
+	if err != nil {
+		return append(checks, health.Check{
+			Name:     "endpoints",
+			Critical: true,
+			Run: func() (string, error) {
+				return "", fmt.Errorf("unable to load network configs: %w", err)
+			},
+		})
+	}
+
+	for _, chain := range chains {
+		chain := chain
+		checks = append(checks, health.Check{
+			Name: "endpoints_" + strings.ToLower(chain),
+			// Most of the routes depend on the evmos nodes
+			Critical: chain == constants.EVMOS,
+			Run: func() (string, error) {
+				ranking, err := db.RedisGetEndpointRanking(h.store, chain, "rest")
+				if err != nil {
+					return "", fmt.Errorf("no rest endpoint published: %w", err)
+				}
+				// The rankings of the previous cron have no version, only an update time
+				if ranking.UpdatedAt.IsZero() {
+					return "", fmt.Errorf("endpoint rankings have never been published")
+				}
+				return health.Freshness(ranking.UpdatedAt, cfg.EndpointsMaxAge)
+			},
+		})
+	}
+
+	return checks
+}
+
+// loadReadinessChains returns the mainnet chains of the network config, loaded
+// within timeout like the checks. The chains of the last successful load are
+// returned when the network configs can not be loaded in time.
+func (h *Handler) loadReadinessChains(timeout time.Duration) ([]string, error) {
+	type outcome struct {
+		chains []string
+		err    error
+	}
+	// Buffered so the lookup can finish after the timeout
+	done := make(chan outcome, 1)
+	go func() {
+		networkConfigs, err := resources.GetNetworkConfigs(h.store)
+		if err != nil {
+			done <- outcome{err: err}
+			return
+		}
+		chains := make([]string, 0, len(networkConfigs))
+		for _, networkConfig := range networkConfigs {
+			if chain := strings.ToUpper(resources.GetMainnetConfig(networkConfig).Identifier); chain != "" {
+				chains = append(chains, chain)
+			}
+		}
+		done <- outcome{chains: chains}
+	}()
+
+	var res outcome
+	select {
+	case res = <-done:
+	case <-time.After(timeout):
+		res.err = fmt.Errorf("timed out after %s", timeout)
+	}
+	if res.err == nil {
+		h.readinessChains.Store(res.chains)
+		return res.chains, nil
+	}
+	if chains, ok := h.readinessChains.Load().([]string); ok {
+		return chains, nil
+	}
+	return nil, res.err
+}
@@ -0,0 +1,60 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/api/config"
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+)
+
+// blockingStore blocks the reads until release is closed.
+type blockingStore struct {
+	db.Store
+	release chan struct{}
+}
+
+func (s *blockingStore) Get(ctx context.Context, key string) (string, error) {
+	<-s.release
+	return s.Store.Get(ctx, key)
+}
+
+func TestReadinessChainsTimeout(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "")
+	store := db.NewMemoryStore(100)
+	_, err := db.RedisGetOrComputeNetworkConfig(store, func() (string, error) {
+		return `[{"prefix":"evmos","configurations":[{"chainId":"evmos_9001-2","identifier":"evmos","configurationType":"mainnet"}]}]`, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := &Handler{cfg: &config.Config{}, store: store}
+	chains, err := h.loadReadinessChains(time.Second)
+	if err != nil || len(chains) != 1 || chains[0] != "EVMOS" {
+		t.Fatalf("expected the evmos chain, got %v %v", chains, err)
+	}
+
+	// The lookup is bounded by the timeout, the last chains are used
+	slow := &blockingStore{Store: store, release: make(chan struct{})}
+	t.Cleanup(func() { close(slow.release) })
+	h.store = slow
+	start := time.Now()
+	chains, err = h.loadReadinessChains(50 * time.Millisecond)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the lookup to time out, took %v", elapsed)
+	}
+	if err != nil || len(chains) != 1 || chains[0] != "EVMOS" {
+		t.Fatalf("expected the last chains, got %v %v", chains, err)
+	}
+
+	// Without a previous lookup the timeout is reported
+	h = &Handler{cfg: &config.Config{}, store: slow}
+	if _, err := h.loadReadinessChains(50 * time.Millisecond); err == nil {
+		t.Fatalf("expected the timeout error")
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package handler
+
+import (
+	"net/http"
+
+	v1 "github.com/tharsis/dashboard-backend/api/handler/v1"
+	v2 "github.com/tharsis/dashboard-backend/api/handler/v2"
+	"github.com/tharsis/dashboard-backend/internal/v2/apikeys"
+	"github.com/tharsis/dashboard-backend/internal/v2/health"
+	"github.com/tharsis/dashboard-backend/internal/v2/node/rest"
+	"github.com/tharsis/dashboard-backend/internal/v2/numia"
+	"github.com/tharsis/dashboard-backend/internal/v2/openapi"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	tagServer = "server"
+	tagAdmin  = "admin"
+	tagV2     = "v2"
+
+	apiKeyScheme     = "apiKey"
+	adminTokenScheme = "adminToken"
+)
+
+// docsPage renders the OpenAPI document served at /openapi.json.
+const docsPage = `<!DOCTYPE html>
+<html>
+  <head>
+    <title>Evmos Dashboard Backend API</title>
+    <meta charset="utf-8"/>
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+  </head>
+  <body>
+    <redoc spec-url="/openapi.json"></redoc>
+    <script src="https://cdn.redoc.ly/redoc/v2.1.3/bundles/redoc.standalone.js"></script>
+  </body>
+</html>
+`
+
+// openAPIRoutes describes every route registered by RegisterRoutes.
+// TestOpenAPIDocumentCoversRoutes fails when a route is missing.
+func (h *Handler) openAPIRoutes() []openapi.Route {
+	admin := []openapi.SecurityRequirement{{adminTokenScheme: {}}}
+
+	routes := []openapi.Route{
+		{Method: http.MethodGet, Path: "/status", Tag: tagServer, Summary: "Server status", Deprecated: true, Response: StatusResponse{}},
+		{Method: http.MethodGet, Path: "/health/live", Tag: tagServer, Summary: "Liveness probe", Response: StatusResponse{}},
+		{Method: http.MethodGet, Path: "/health/ready", Tag: tagServer, Summary: "Readiness probe, returns 503 when a critical dependency is down", Response: health.Report{}},
+		{Method: http.MethodGet, Path: "/metrics", Tag: tagServer, Summary: "Prometheus metrics", ContentType: "text/plain", ResponseSchema: &openapi.Schema{Type: "string"}},
+		{Method: http.MethodGet, Path: "/openapi.json", Tag: tagServer, Summary: "This OpenAPI document", ResponseSchema: &openapi.Schema{Type: "object"}},
+		{Method: http.MethodGet, Path: "/docs", Tag: tagServer, Summary: "Browsable API documentation", ContentType: "text/html", ResponseSchema: &openapi.Schema{Type: "string"}},
+
+		// Admin endpoints
+		{Method: http.MethodPost, Path: "/admin/apikeys", Tag: tagAdmin, Summary: "Create an API key", Security: admin, Request: CreateAPIKeyParams{}, Response: CreateAPIKeyResponse{}},
+		{Method: http.MethodGet, Path: "/admin/apikeys", Tag: tagAdmin, Summary: "List the API keys", Security: admin, Response: []apikeys.Key{}},
+		{Method: http.MethodGet, Path: "/admin/apikeys/{id}", Tag: tagAdmin, Summary: "API key and its usage today", Security: admin, Response: APIKeyResponse{}},
+		{Method: http.MethodDelete, Path: "/admin/apikeys/{id}", Tag: tagAdmin, Summary: "Revoke an API key", Security: admin, Response: apikeys.Key{}},
+
+		// Webhooks
+		{Method: http.MethodPost, Path: "/webhooks/github", Tag: tagAdmin, Summary: "Reload the registry on a GitHub push",
+			Description:   "Authenticated with the X-Hub-Signature-256 HMAC of the body, signed with the webhook secret.",
+			RequestSchema: &openapi.Schema{Type: "object", Description: "GitHub push event"}, Response: GithubWebhookResponse{}},
+
+		// v2 endpoints
+		{Method: http.MethodGet, Path: "/v2/height", Tag: tagV2, Summary: "Latest block height", Response: v2.HeightResponse{}},
+		{Method: http.MethodGet, Path: "/v2/delegations/{address}", Tag: tagV2, Summary: "Delegations of an address", Response: []numia.DelegationResponse{}},
+		{Method: http.MethodGet, Path: "/v2/rewards/{address}", Tag: tagV2, Summary: "Monthly rewards of an address", Response: []numia.RewardsResponse{}},
+		{Method: http.MethodGet, Path: "/v2/vesting/{address}", Tag: tagV2, Summary: "Vesting account of an address", Response: rest.VestingByAddressResponse{}},
+		{Method: http.MethodGet, Path: "/v2/registry/status", Tag: tagV2, Summary: "Registry files loaded and skipped", Response: v2.RegistryStatusResponse{}},
+		{
+			Method: http.MethodGet, Path: "/v2/endpoints/{chain}", Tag: tagV2, Summary: "Endpoint rankings and health history of a chain",
+			Query:    []openapi.Parameter{{Name: "window", In: "query", Description: "History to return, e.g. 30m, 1h by default", Schema: &openapi.Schema{Type: "string"}}},
+			Response: v2.EndpointsResponse{},
+		},
+
+		// Tx endpoints
+		{Method: http.MethodPost, Path: "/v2/tx/broadcast", Tag: tagV2, Summary: "Broadcast a signed transaction", Request: v2.BroadcastTxParams{}, Response: v2.BroadcastTxResponse{}},
+		{Method: http.MethodPost, Path: "/v2/tx/amino/broadcast", Tag: tagV2, Summary: "Broadcast an amino signed transaction", RequestSchema: v2.BroadcastAminoTxSchema, Response: v2.BroadcastAminoTxResponse{}},
+	}
+
+	// v1 endpoints to be deprecated
+	return append(routes, v1.OpenAPIRoutes()...)
+}
+
+// newOpenAPIDocument builds the OpenAPI document of the API.
+func (h *Handler) newOpenAPIDocument() *openapi.Document {
+	doc := openapi.NewDocument(openapi.Info{
+		Title: "Evmos Dashboard Backend API",
+		Description: "API used by the Evmos dashboard. Requests can be authenticated with an API key to get higher rate limits. " +
+			"Mainnet is served by default, the testnet is selected with the /testnet path prefix or the X-Environment header.",
+		Version: "2",
+	}, h.openAPIRoutes())
+
+	doc.Components.SecuritySchemes = map[string]openapi.SecurityScheme{
+		apiKeyScheme: {
+			Type:        "apiKey",
+			Description: "API key given to integrators",
+			Name:        h.cfg.APIKeys.Header,
+			In:          "header",
+		},
+		adminTokenScheme: {
+			Type:   "http",
+			Scheme: "bearer",
+		},
+	}
+	// The API key is optional
+	doc.Security = []openapi.SecurityRequirement{{}, {apiKeyScheme: {}}}
+	return doc
+}
+
+// OpenAPI handles GET /openapi.json.
+// It returns the OpenAPI 3 document describing every route.
+func (h *Handler) OpenAPI(ctx *fasthttp.RequestCtx) {
+	ctx.Response.Header.SetContentType("application/json")
+	ctx.SetBody(h.openAPIDocument)
+}
+
+// Docs handles GET /docs.
+// It renders the OpenAPI document as a browsable page.
+func (h *Handler) Docs(ctx *fasthttp.RequestCtx) {
+	ctx.Response.Header.SetContentType("text/html; charset=utf-8")
+	ctx.SetBodyString(docsPage)
+}
@@ -0,0 +1,82 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package handler
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/fasthttp/router"
+	"github.com/tharsis/dashboard-backend/api/config"
+	v1 "github.com/tharsis/dashboard-backend/api/handler/v1"
+	v2 "github.com/tharsis/dashboard-backend/api/handler/v2"
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v2/openapi"
+)
+
+func testOpenAPIDocument() (*Handler, *openapi.Document) {
+	store := db.NewMemoryStore(100)
+	h := &Handler{cfg: &config.Config{}, store: store, v1: v1.NewHandler(store), v2: &v2.Handler{}}
+	h.cfg.APIKeys.Header = "X-API-Key"
+	return h, h.newOpenAPIDocument()
+}
+
+// TestOpenAPIDocumentCoversRoutes keeps the OpenAPI document in sync with the router.
+func TestOpenAPIDocumentCoversRoutes(t *testing.T) {
+	h, doc := testOpenAPIDocument()
+
+	r := router.New()
+	h.RegisterRoutes(r)
+
+	registered := map[string]bool{}
+	for method, paths := range r.List() {
+		for _, path := range paths {
+			key := strings.ToLower(method) + " " + openapi.PathFromRoute(path)
+			registered[key] = true
+			if _, ok := doc.Paths[openapi.PathFromRoute(path)][strings.ToLower(method)]; !ok {
+				t.Fatalf("Route %s %s is missing in the OpenAPI document, add it to openAPIRoutes", method, path)
+			}
+		}
+	}
+
+	for path, operations := range doc.Paths {
+		for method := range operations {
+			if !registered[method+" "+path] {
+				t.Fatalf("Operation %s %s is not registered in the router", method, path)
+			}
+		}
+	}
+}
+
+func TestOpenAPIDocumentIsValid(t *testing.T) {
+	_, doc := testOpenAPIDocument()
+
+	operationIDs := map[string]bool{}
+	for path, operations := range doc.Paths {
+		for method, op := range operations {
+			if operationIDs[op.OperationID] {
+				t.Fatalf("Duplicated operation id %s in %s %s", op.OperationID, method, path)
+			}
+			operationIDs[op.OperationID] = true
+		}
+	}
+
+	encoded, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Error encoding the document: %s", err.Error())
+	}
+
+	// Every reference must point to a component
+	refs := regexp.MustCompile(`"\$ref":"#/components/schemas/([^"]+)"`).FindAllStringSubmatch(string(encoded), -1)
+	if len(refs) == 0 {
+		t.Fatalf("Expected the document to reference components")
+	}
+	for _, ref := range refs {
+		if _, ok := doc.Components.Schemas[ref[1]]; !ok {
+			t.Fatalf("Reference to missing component %s", ref[1])
+		}
+	}
+}
@@ -5,16 +5,33 @@ package handler
 
 import (
 	"github.com/fasthttp/router"
-	v1 "github.com/tharsis/dashboard-backend/api/handler/v1"
+	"github.com/tharsis/dashboard-backend/internal/v2/telemetry"
 )
 
 func (h *Handler) RegisterRoutes(r *router.Router) {
 	r.GET("/status", h.Status)
+	r.GET("/health/live", h.Live)
+	r.GET("/health/ready", h.Ready)
+	r.GET("/metrics", telemetry.Handler())
+	r.GET("/openapi.json", h.OpenAPI)
+	r.GET("/docs", h.Docs)
+
+	// Admin endpoints
+	r.POST("/admin/apikeys", h.requireAdmin(h.CreateAPIKey))
+	r.GET("/admin/apikeys", h.requireAdmin(h.ListAPIKeys))
+	r.GET("/admin/apikeys/{id}", h.requireAdmin(h.GetAPIKey))
+	r.DELETE("/admin/apikeys/{id}", h.requireAdmin(h.RevokeAPIKey))
+
+	// Webhooks
+	r.POST("/webhooks/github", h.GithubWebhook)
+
 	// v2 endpoints
 	r.GET("/v2/height", h.v2.Height)
 	r.GET("/v2/delegations/{address}", h.v2.DelegationsByAddress)
 	r.GET("/v2/rewards/{address}", h.v2.RewardsByAddress)
 	r.GET("/v2/vesting/{address}", h.v2.VestingByAddress)
+	r.GET("/v2/registry/status", h.v2.RegistryStatus)
+	r.GET("/v2/endpoints/{chain}", h.v2.Endpoints)
 
 	// Tx endpoints
 	r.POST("/v2/tx/broadcast", h.v2.BroadcastTx)
@@ -22,5 +39,5 @@ func (h *Handler) RegisterRoutes(r *router.Router) {
 
 	// v1 endpoints to be deprecated
 	// NOTE: v1 endpoints do not have a /v1 prefix for backwards compatibility
-	v1.RegisterRoutes(r)
+	h.v1.RegisterRoutes(r)
 }
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
 	"github.com/valyala/fasthttp"
 )
 
@@ -16,17 +17,22 @@ type StatusResponse struct {
 
 // Status handles GET /status.
 // Dummy endpoint to check if the server is up and running.
+// Deprecated: use /health/live and /health/ready instead.
 func (h *Handler) Status(ctx *fasthttp.RequestCtx) {
-	ctx.SetStatusCode(http.StatusOK)
 	resp := StatusResponse{
 		Status: "OK",
 	}
-	jsonResponse, err := json.Marshal(resp)
+	sendJSON(ctx, http.StatusOK, resp)
+}
+
+func sendJSON(ctx *fasthttp.RequestCtx, statusCode int, response interface{}) {
+	jsonResponse, err := json.Marshal(response)
 	if err != nil {
-		ctx.Logger().Printf("Error encoding response: %s", err.Error())
+		logging.FromContext(ctx).Error("Error encoding response", "error", err)
 		ctx.SetStatusCode(http.StatusInternalServerError)
 		return
 	}
+	ctx.SetStatusCode(statusCode)
 	ctx.Response.Header.SetContentType("application/json")
 	ctx.SetBody(jsonResponse)
 }
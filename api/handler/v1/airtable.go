@@ -7,32 +7,37 @@ import (
 	"github.com/fasthttp/router"
 	"github.com/tharsis/dashboard-backend/internal/v1/db"
 	"github.com/tharsis/dashboard-backend/internal/v1/requester"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
 	"github.com/valyala/fasthttp"
 )
 
-func GetAnnouncements(ctx *fasthttp.RequestCtx) {
+func (h *Handler) GetAnnouncements(ctx *fasthttp.RequestCtx) {
 	path := "/Announcement?maxRecords=30&sort[0][field]=Start+Date+Time&sort[0][direction]=desc"
 
-	if resp, err := db.RedisGetAirtableRequest(path); err == nil {
+	if resp, err := db.RedisGetAirtableRequest(h.store, path); err == nil {
 		sendResponse(resp, nil, ctx)
 		return
 	}
 
 	resp, err := requester.MakeAirtableGetRequest(path)
 	if err != nil {
-		if val, err := db.RedisGetAirtableFallbackRequest(path); err == nil {
+		if val, err := db.RedisGetAirtableFallbackRequest(h.store, path); err == nil {
 			sendResponse(val, nil, ctx)
 			return
 		}
 		sendResponse("unable to get airtable request", err, ctx)
 	}
 
-	db.RedisSetAirtableRequest(resp, path)
-	db.RedisSetAirtableFallbackRequest(resp, path)
+	if err := db.RedisSetAirtableRequest(h.store, resp, path); err != nil {
+		logging.FromContext(ctx).Warn("Error caching airtable response", "error", err)
+	}
+	if err := db.RedisSetAirtableFallbackRequest(h.store, resp, path); err != nil {
+		logging.FromContext(ctx).Warn("Error caching airtable fallback response", "error", err)
+	}
 
 	sendResponse(resp, nil, ctx)
 }
 
-func AddAirtableRoutes(r *router.Router) {
-	r.GET("/Announcements", GetAnnouncements)
+func (h *Handler) AddAirtableRoutes(r *router.Router) {
+	r.GET("/Announcements", h.GetAnnouncements)
 }
@@ -21,11 +21,11 @@ type BalanceResponse struct {
 	Balance BalanceElement `json:"balance"`
 }
 
-func BalanceByNetworkAndDenom(ctx *fasthttp.RequestCtx) {
+func (h *Handler) BalanceByNetworkAndDenom(ctx *fasthttp.RequestCtx) {
 	token := paramToString("token", ctx)
 	denom := token
 
-	coinConfigs, err := resources.GetERC20Tokens()
+	coinConfigs, err := resources.GetERC20Tokens(h.store)
 	if err != nil {
 		sendResponse("", err, ctx)
 		return
@@ -41,21 +41,21 @@ func BalanceByNetworkAndDenom(ctx *fasthttp.RequestCtx) {
 	}
 
 	endpoint := BuildFourParamEndpoint("/cosmos/bank/v1beta1/balances/", paramToString("address", ctx), "/by_denom?denom=", denom)
-	val, err := getRequestRest(getChain(ctx), endpoint)
+	val, err := getRequestRest(ctx, h.store, getChain(ctx), endpoint)
 	sendResponse(val, err, ctx)
 }
 
-func EVMOSIBCBalance(ctx *fasthttp.RequestCtx) {
+func (h *Handler) EVMOSIBCBalance(ctx *fasthttp.RequestCtx) {
 	sourceChain := getChain(ctx)
 
-	evmosIbcDenom, err := GetDenom("EVMOS", sourceChain)
+	evmosIbcDenom, err := GetDenom(ctx, h.store, "EVMOS", sourceChain)
 	if err != nil {
 		sendResponse("Unable to get EVMOS denom in source chain provided", err, ctx)
 		return
 	}
 
 	endpoint := BuildFourParamEndpoint("/cosmos/bank/v1beta1/balances/", paramToString("address", ctx), "/by_denom?denom=", evmosIbcDenom)
-	val, err := getRequestRest(sourceChain, endpoint)
+	val, err := getRequestRest(ctx, h.store, sourceChain, endpoint)
 	if err != nil {
 		sendResponse("Unable to get EVMOS balance in chain provided", err, ctx)
 	}
@@ -4,13 +4,16 @@
 package v1
 
 import (
+	"context"
 	"encoding/json"
 	"math"
 	"sort"
+	"time"
 
 	sdkmath "cosmossdk.io/math"
 
 	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v2/requestctx"
 	"github.com/valyala/fasthttp"
 )
 
@@ -67,98 +70,121 @@ type RemainingEpochsResponse struct {
 	RemainingEpochs int `json:"remainingEpochs"`
 }
 
-func GetValidatorsWithRanks(chain string) (map[string]Validator, error) {
-	if val, err := db.RedisGetValidatorWithRanks(chain); err == nil {
-		var res map[string]Validator
-		err := json.Unmarshal([]byte(val), &res)
-		if err == nil {
-			return res, nil
-		}
-	}
+// computeTimeout bounds the computations shared by the concurrent requests.
+const computeTimeout = 30 * time.Second
 
-	// We need to make a request with just the bonded validators to get the ranks
-	bondedRaw, err := GetAllValidators(chain)
-	if err != nil {
-		return nil, err
-	}
-	var bonded ValidatorAPIResponse
-	err = json.Unmarshal([]byte(bondedRaw), &bonded)
-	if err != nil {
-		return nil, err
-	}
+// computeContext returns the context of a computation shared by the concurrent
+// requests, which must not be canceled when the request that started it is served.
+func computeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(requestctx.Detach(ctx), computeTimeout)
+}
 
-	sort.SliceStable(bonded.Validators, func(a int, b int) bool {
-		valA, okA := sdkmath.NewIntFromString(bonded.Validators[a].Tokens)
-		valB, okB := sdkmath.NewIntFromString(bonded.Validators[b].Tokens)
-		if !okA || !okB {
-			return false
+func GetValidatorsWithRanks(ctx context.Context, store db.Store, chain string) (map[string]Validator, error) {
+	cacheChain := db.EnvironmentChain(requestctx.Environment(ctx), chain)
+	val, err := db.RedisGetOrComputeValidatorWithRanks(store, cacheChain, func() (string, error) {
+		ctx, cancel := computeContext(ctx)
+		defer cancel()
+
+		// We need to make a request with just the bonded validators to get the ranks
+		bondedRaw, err := GetAllValidators(ctx, store, chain)
+		if err != nil {
+			return "", err
+		}
+		var bonded ValidatorAPIResponse
+		err = json.Unmarshal([]byte(bondedRaw), &bonded)
+		if err != nil {
+			return "", err
 		}
-		return valA.GT(valB)
-	})
 
-	valMap := make(map[string]Validator)
+		sort.SliceStable(bonded.Validators, func(a int, b int) bool {
+			valA, okA := sdkmath.NewIntFromString(bonded.Validators[a].Tokens)
+			valB, okB := sdkmath.NewIntFromString(bonded.Validators[b].Tokens)
+			if !okA || !okB {
+				return false
+			}
+			return valA.GT(valB)
+		})
+
+		valMap := make(map[string]Validator)
+
+		// Set the ranks
+		for k, v := range bonded.Validators {
+			item := v
+			item.Rank = k + 1
+			valMap[v.OperatorAddress] = item
+		}
 
-	// Set the ranks
-	for k, v := range bonded.Validators {
-		item := v
-		item.Rank = k + 1
-		valMap[v.OperatorAddress] = item
+		val, err := json.Marshal(valMap)
+		if err != nil {
+			return "", err
+		}
+		return string(val), nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if val, err := json.Marshal(valMap); err == nil {
-		db.RedisSetValidatorWithRanks(chain, string(val))
+	var res map[string]Validator
+	if err := json.Unmarshal([]byte(val), &res); err != nil {
+		return nil, err
 	}
-
-	return valMap, nil
+	return res, nil
 }
 
-func GetValidatorsWithNoFilter(chain string) (map[string]Validator, error) {
-	if val, err := db.RedisGetValidatorWithNoFilter(chain); err == nil {
-		var res map[string]Validator
-		err := json.Unmarshal([]byte(val), &res)
-		if err == nil {
-			return res, nil
+func GetValidatorsWithNoFilter(ctx context.Context, store db.Store, chain string) (map[string]Validator, error) {
+	cacheChain := db.EnvironmentChain(requestctx.Environment(ctx), chain)
+	val, err := db.RedisGetOrComputeValidatorWithNoFilter(store, cacheChain, func() (string, error) {
+		ctx, cancel := computeContext(ctx)
+		defer cancel()
+
+		endpoint := "/cosmos/staking/v1beta1/validators?pagination.limit=600"
+		validators, err := getRequestRest(ctx, store, chain, endpoint)
+		if err != nil {
+			return "", err
 		}
-	}
 
-	endpoint := "/cosmos/staking/v1beta1/validators?pagination.limit=600"
+		var m ValidatorAPIResponse
+		err = json.Unmarshal([]byte(validators), &m)
+		if err != nil {
+			return "", err
+		}
 
-	validators, _ := getRequestRest(chain, endpoint)
+		valWithRanks, err := GetValidatorsWithRanks(ctx, store, chain)
+		if err != nil {
+			return "", err
+		}
 
-	var m ValidatorAPIResponse
-	err := json.Unmarshal([]byte(validators), &m)
-	if err != nil {
-		return nil, err
-	}
+		valMap := make(map[string]Validator)
+		for _, v := range m.Validators {
+			if val, ok := valWithRanks[v.OperatorAddress]; ok {
+				v.Rank = val.Rank
+			} else {
+				v.Rank = -1
+			}
+			valMap[v.OperatorAddress] = v
+		}
 
-	valWithRanks, err := GetValidatorsWithRanks(chain)
+		val, err := json.Marshal(valMap)
+		if err != nil {
+			return "", err
+		}
+		return string(val), nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	valMap := make(map[string]Validator)
-	for _, v := range m.Validators {
-		if val, ok := valWithRanks[v.OperatorAddress]; ok {
-			v.Rank = val.Rank
-		} else {
-			v.Rank = -1
-		}
-		valMap[v.OperatorAddress] = v
-	}
-
-	val, err := json.Marshal(valMap)
-	if err != nil {
+	var res map[string]Validator
+	if err := json.Unmarshal([]byte(val), &res); err != nil {
 		return nil, err
 	}
-
-	db.RedisSetValidatorWithNoFilter(chain, string(val))
-	return valMap, nil
+	return res, nil
 }
 
-func RemainingEpochs(ctx *fasthttp.RequestCtx) {
+func (h *Handler) RemainingEpochs(ctx *fasthttp.RequestCtx) {
 	// query skipped epochs
 	skippedEndpoint := "/evmos/inflation/v1/skipped_epochs"
-	val, err := getRequestRest("EVMOS", skippedEndpoint)
+	val, err := getRequestRest(ctx, h.store, "EVMOS", skippedEndpoint)
 	if err != nil {
 		sendResponse("Failed to get remaining epochs from endpoint", err, ctx)
 		return
@@ -174,7 +200,7 @@ func RemainingEpochs(ctx *fasthttp.RequestCtx) {
 
 	// query current epochs
 	currentEndpoint := "/evmos/epochs/v1/current_epoch?identifier=day"
-	val, err = getRequestRest("EVMOS", currentEndpoint)
+	val, err = getRequestRest(ctx, h.store, "EVMOS", currentEndpoint)
 	if err != nil {
 		sendResponse("Failed to get remaining epochs from endpoint", err, ctx)
 		return
@@ -17,6 +17,8 @@ import (
 	"github.com/tharsis/dashboard-backend/internal/v1/requester"
 	"github.com/tharsis/dashboard-backend/internal/v1/resources"
 	"github.com/tharsis/dashboard-backend/internal/v1/utils"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/tharsis/dashboard-backend/internal/v2/requestctx"
 	"github.com/valyala/fasthttp"
 	"golang.org/x/exp/slices"
 )
@@ -81,19 +83,19 @@ func buildValuesResponse(values string) string {
 	return "{\"values\":" + values + "}"
 }
 
-func ERC20ModuleEmptyBalance(ctx *fasthttp.RequestCtx) {
+func (h *Handler) ERC20ModuleEmptyBalance(ctx *fasthttp.RequestCtx) {
 	container := ModuleBalanceContainer{
 		values:         map[string]ERC20Entry{},
 		cosmosBalances: []BalanceElement{},
 	}
 
-	erc20ModuleCoins, err := resources.GetERC20ModuleCoins()
+	erc20ModuleCoins, err := resources.GetERC20ModuleCoins(h.store)
 	if err != nil {
 		sendResponse("", err, ctx)
 		return
 	}
 
-	networkConfigs, err := resources.GetNetworkConfigs()
+	networkConfigs, err := resources.GetNetworkConfigs(h.store)
 	if err != nil {
 		sendResponse("", err, ctx)
 		return
@@ -103,9 +105,9 @@ func ERC20ModuleEmptyBalance(ctx *fasthttp.RequestCtx) {
 	for k, v := range erc20ModuleCoins {
 		configIdx := slices.IndexFunc(networkConfigs, func(c resources.NetworkConfig) bool { return c.Prefix == v.ChainPrefix })
 		networkConfig := networkConfigs[configIdx]
-		mainnetConfig := resources.GetMainnetConfig(networkConfig)
-		coingeckoPrice := GetCoingeckoPrice(v.CoingeckoID)
-		coin24hChnage := GetCoingecko24HChange(v.CoingeckoID)
+		chainConfig := resources.GetConfig(networkConfig, requestctx.Environment(ctx))
+		coingeckoPrice := GetCoingeckoPrice(h.store, v.CoingeckoID)
+		coin24hChnage := GetCoingecko24HChange(h.store, v.CoingeckoID)
 		container.values[k] = ERC20Entry{
 			Name:                v.Name,
 			Symbol:              v.Symbol,
@@ -115,8 +117,8 @@ func ERC20ModuleEmptyBalance(ctx *fasthttp.RequestCtx) {
 			TokenName:           v.TokenName,
 			TokenIdentifier:     v.TokenRepresentation,
 			Description:         v.Description,
-			ChainID:             mainnetConfig.ChainID,
-			ChainIdentifier:     mainnetConfig.Identifier,
+			ChainID:             chainConfig.ChainID,
+			ChainIdentifier:     chainConfig.Identifier,
 			HandledByExternalUI: v.HandledByExternalUI,
 			CoingeckoPrice:      coingeckoPrice,
 			ERC20Address:        v.Erc20,
@@ -149,11 +151,11 @@ func ERC20ModuleEmptyBalance(ctx *fasthttp.RequestCtx) {
 	sendResponse(string(jsonresponse), nil, ctx)
 }
 
-func ERC20ModuleBalance(ctx *fasthttp.RequestCtx) {
+func (h *Handler) ERC20ModuleBalance(ctx *fasthttp.RequestCtx) {
 	evmosAddress := paramToString("evmos_address", ctx)
 	ethAddress := paramToString("eth_address", ctx)
 	endpoint := BuildTwoParamEndpoint("/cosmos/bank/v1beta1/balances/", evmosAddress)
-	val, err := getRequestRest("EVMOS", endpoint)
+	val, err := getRequestRest(ctx, h.store, "EVMOS", endpoint)
 	if err != nil {
 		sendResponse("", err, ctx)
 		return
@@ -170,13 +172,13 @@ func ERC20ModuleBalance(ctx *fasthttp.RequestCtx) {
 		cosmosBalances: m.Balances,
 	}
 
-	erc20ModuleCoins, err := resources.GetERC20ModuleCoins()
+	erc20ModuleCoins, err := resources.GetERC20ModuleCoins(h.store)
 	if err != nil {
 		sendResponse("", err, ctx)
 		return
 	}
 
-	networkConfigs, err := resources.GetNetworkConfigs()
+	networkConfigs, err := resources.GetNetworkConfigs(h.store)
 	if err != nil {
 		sendResponse("", err, ctx)
 		return
@@ -185,7 +187,7 @@ func ERC20ModuleBalance(ctx *fasthttp.RequestCtx) {
 	index := 0
 	for k, v := range erc20ModuleCoins {
 		// TODO: consider moving this to a work or remove the container mutex
-		val, err := blockchain.GetERC20Balance(v.Erc20, ethAddress)
+		val, err := blockchain.GetERC20Balance(ctx, h.store, v.Erc20, ethAddress)
 		balance := "0"
 		if err == nil && val != "" {
 			balance = val
@@ -200,9 +202,9 @@ func ERC20ModuleBalance(ctx *fasthttp.RequestCtx) {
 
 		configIdx := slices.IndexFunc(networkConfigs, func(c resources.NetworkConfig) bool { return c.Prefix == v.ChainPrefix })
 		networkConfig := networkConfigs[configIdx]
-		mainnetConfig := resources.GetMainnetConfig(networkConfig)
-		coingeckoPrice := GetCoingeckoPrice(v.CoingeckoID)
-		coin24hChnage := GetCoingecko24HChange(v.CoingeckoID)
+		chainConfig := resources.GetConfig(networkConfig, requestctx.Environment(ctx))
+		coingeckoPrice := GetCoingeckoPrice(h.store, v.CoingeckoID)
+		coin24hChnage := GetCoingecko24HChange(h.store, v.CoingeckoID)
 
 		container.values[k] = ERC20Entry{
 			Name:                v.Name,
@@ -214,8 +216,8 @@ func ERC20ModuleBalance(ctx *fasthttp.RequestCtx) {
 			TokenIdentifier:     v.TokenRepresentation,
 			Description:         v.Description,
 			CoingeckoPrice:      coingeckoPrice,
-			ChainID:             mainnetConfig.ChainID,
-			ChainIdentifier:     mainnetConfig.Identifier,
+			ChainID:             chainConfig.ChainID,
+			ChainIdentifier:     chainConfig.Identifier,
 			HandledByExternalUI: v.HandledByExternalUI,
 			ERC20Address:        v.Erc20,
 			PngSrc:              v.PngSrc,
@@ -282,13 +284,13 @@ type TokensByName struct {
 	Values TokensByNameConfig `json:"values"`
 }
 
-func ERC20TokensByNameInternal(name string) (string, error) {
+func ERC20TokensByNameInternal(store db.Store, name string) (string, error) {
 	// the name it has to be equal to the one that is in the github repo.
-	if val, err := db.RedisGetERC20TokensByName(name); err == nil {
+	if val, err := db.RedisGetERC20TokensByName(store, name); err == nil {
 		return val, nil
 	}
 
-	val, err := requester.GetERC20TokensDirectory()
+	val, err := requester.GetERC20TokensDirectory(store)
 	if err != nil {
 		return "", err
 	}
@@ -300,10 +302,12 @@ func ERC20TokensByNameInternal(name string) (string, error) {
 		if err != nil {
 			continue
 		}
-		db.RedisSetERC20TokensByName(tokensByName.Values.CoinDenom, res)
+		if err := db.RedisSetERC20TokensByName(store, tokensByName.Values.CoinDenom, res); err != nil {
+			logging.Default().Warn("Error caching ERC20 token", "error", err)
+		}
 	}
 
-	if val, err := db.RedisGetERC20TokensByName(name); err == nil {
+	if val, err := db.RedisGetERC20TokensByName(store, name); err == nil {
 		return val, nil
 	}
 
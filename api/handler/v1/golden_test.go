@@ -0,0 +1,328 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package v1
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fasthttp/router"
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v1/requester"
+	"github.com/tharsis/dashboard-backend/internal/v2/mockchain"
+	"github.com/valyala/fasthttp"
+)
+
+// Run go test ./api/handler/v1 -run TestGolden -update to rewrite the snapshots
+// after an intended change of the responses, and review the diff.
+var update = flag.Bool("update", false, "rewrite the golden files of the v1 responses")
+
+const (
+	goldenDir   = "testdata/golden"
+	upstreamDir = "testdata/upstream"
+	registryDir = "testdata/registry"
+
+	// registryTreeURL is the chain-token-registry tree read outside production
+	registryTreeURL   = "https://api.github.com/repos/evmos/chain-token-registry/git/trees/main?recursive=1"
+	announcementsPath = "/Announcement?maxRecords=30&sort[0][field]=Start+Date+Time&sort[0][direction]=desc"
+
+	osmoAddress = "osmo1m9705gycgz6ymcharccuwzzhflap3l3n3z4l9c"
+	ethAddress  = "0xD97CFA209840B44DE2FD1E31C708574FFA18FE33"
+	validator2  = "evmosvaloper1ftfhf45eu2vug4kk4eragppcwv9k09wumrn6xz"
+	// pubKey is the secp256k1 public key of mockchain.Address, base64 encoded
+	pubKey = "Atl8+iCYQLRN4v0eMccIV0/6GP4zBeju8EjIoHwmADmr"
+)
+
+// upstreamFixture answers the requests of the nodes with a recorded response
+// of testdata/upstream, on top of the mockchain default fixtures.
+type upstreamFixture struct {
+	method  string
+	pattern string
+	file    string
+}
+
+var evmosFixtures = []upstreamFixture{
+	{http.MethodGet, "/evmos/inflation/v1/skipped_epochs", "skipped_epochs.json"},
+	{http.MethodGet, "/evmos/epochs/v1/current_epoch?identifier=day", "current_epoch.json"},
+	{http.MethodGet, "/evmos/epochs/v1/epochs", "epochs.json"},
+	{http.MethodGet, "/evmos/feemarket/v1/params", "feemarket_params.json"},
+	{http.MethodGet, "/cosmos/bank/v1beta1/balances/*", "balances.json"},
+	{http.MethodGet, "/cosmos/bank/v1beta1/balances/*/by_denom", "balance_by_denom.json"},
+	{http.MethodGet, "/cosmos/staking/v1beta1/delegators/*/unbonding_delegations", "unbonding_delegations.json"},
+	{http.MethodGet, "/cosmos/distribution/v1beta1/delegators/*/rewards", "rewards.json"},
+	{http.MethodGet, "/cosmos/gov/v1beta1/proposals/*/votes/*", "vote.json"},
+	{http.MethodGet, "/cosmos/gov/v1/proposals", "proposals.json"},
+	{http.MethodGet, "/cosmos/gov/v1/proposals/*/tally", "tally.json"},
+	{http.MethodGet, "/cosmos/gov/v1/params/tallying", "tally_params.json"},
+	{http.MethodGet, "/ibc/core/client/v1/client_status/*", "client_status.json"},
+	{http.MethodGet, "/tx?hash=*", "tx_ibc.json"},
+}
+
+var osmosisFixtures = []upstreamFixture{
+	{http.MethodGet, "/cosmos/bank/v1beta1/balances/*/by_denom", "osmosis_balance_by_denom.json"},
+	{http.MethodGet, "/ibc/core/channel/v1/channels/*/ports/transfer/packet_acks/*", "packet_ack.json"},
+}
+
+// goldenChains are the nodes of the chains queried by the handlers.
+type goldenChains struct {
+	evmos   *mockchain.Node
+	osmosis *mockchain.Node
+}
+
+type goldenCase struct {
+	// name of the golden file
+	name   string
+	method string
+	path   string
+	body   string
+	// setup overrides the fixtures of the nodes
+	setup func(t *testing.T, chains goldenChains)
+}
+
+func txBody(message string) string {
+	return `{"transaction":{"pubKey":"` + pubKey + `","sender":"` + mockchain.Address + `","gas":0},"message":` + message + `}`
+}
+
+var goldenCases = []goldenCase{
+	// epoch
+	{name: "remaining_epochs", method: http.MethodGet, path: "/RemainingEpochs"},
+	{name: "epochs", method: http.MethodGet, path: "/Epochs/EVMOS"},
+	{name: "epochs_not_evmos", method: http.MethodGet, path: "/Epochs/OSMOSIS"},
+	{name: "epochs_nodes_down", method: http.MethodGet, path: "/Epochs/EVMOS", setup: func(t *testing.T, chains goldenChains) {
+		chains.evmos.Handle(http.MethodGet, "/evmos/epochs/v1/epochs", http.StatusServiceUnavailable, "")
+	}},
+
+	// announcements
+	{name: "announcements", method: http.MethodGet, path: "/Announcements"},
+
+	// config
+	{name: "network_config", method: http.MethodGet, path: "/NetworkConfig"},
+	{name: "network_config_by_name", method: http.MethodGet, path: "/NetworkConfig/osmosis"},
+	{name: "network_config_by_name_unknown", method: http.MethodGet, path: "/NetworkConfig/unknown"},
+
+	// ibc
+	{name: "ibc_transfer_invalid_amount", method: http.MethodPost, path: "/ibcTransfer", body: `{"transaction":{"pubKey":"` + pubKey + `","sender":"` + mockchain.Address + `","gas":0},` +
+		`"message":{"srcChain":"EVMOS","dstChain":"OSMOSIS","sender":"` + mockchain.Address + `","receiver":"` + osmoAddress + `","amount":"one","token":"EVMOS"}}`},
+	{name: "ibc_transfer_not_evmos", method: http.MethodPost, path: "/ibcTransfer", body: `{"transaction":{"pubKey":"` + pubKey + `","sender":"` + osmoAddress + `","gas":0},` +
+		`"message":{"srcChain":"OSMOSIS","dstChain":"OSMOSIS","sender":"` + osmoAddress + `","receiver":"` + osmoAddress + `","amount":"1000000","token":"OSMO"}}`},
+	{name: "ibc_transfer_invalid_body", method: http.MethodPost, path: "/ibcTransfer", body: `{`},
+
+	// bank
+	{name: "balance_by_denom", method: http.MethodGet, path: "/BalanceByDenom/EVMOS/" + mockchain.Address + "/aevmos"},
+	{name: "balance_by_denom_not_found", method: http.MethodGet, path: "/BalanceByDenom/EVMOS/" + mockchain.Address + "/aevmos", setup: func(t *testing.T, chains goldenChains) {
+		chains.evmos.Handle(http.MethodGet, "/cosmos/bank/v1beta1/balances/*/by_denom", http.StatusNotFound, `{"code":5,"message":"account not found","details":[]}`)
+	}},
+	{name: "balance_by_network_and_denom", method: http.MethodGet, path: "/BalanceByNetworkAndDenom/OSMOSIS/OSMO/" + osmoAddress},
+	{name: "evmos_ibc_balance", method: http.MethodGet, path: "/EVMOSIBCBalance/OSMOSIS/" + osmoAddress},
+
+	// distribution
+	{name: "rewards", method: http.MethodPost, path: "/rewards", body: `{"transaction":{"pubKey":"` + pubKey + `","sender":"` + mockchain.Address + `","gas":0}}`},
+
+	// tx
+	{name: "is_ibc_executed", method: http.MethodGet, path: "/isIBCExecuted/" + mockchain.TxHash + "/EVMOS"},
+	{name: "is_ibc_executed_unconfirmed", method: http.MethodGet, path: "/isIBCExecuted/" + mockchain.TxHash + "/EVMOS", setup: func(t *testing.T, chains goldenChains) {
+		chains.evmos.Handle(http.MethodGet, "/tx?hash=*", http.StatusOK, `{"jsonrpc":"2.0","id":-1,"error":{"code":-32603,"message":"Internal error","data":"tx not found"}}`)
+	}},
+	{name: "broadcast_eip712", method: http.MethodPost, path: "/broadcastEip712", body: `{"chainId":9001,"feePayer":"` + mockchain.Address + `",` +
+		`"feePayerSig":"0x` + strings.Repeat("ab", 65) + `","body":"EgRtZW1v","authInfo":""}`},
+	{name: "broadcast_eip712_invalid_body", method: http.MethodPost, path: "/broadcastEip712", body: `{`},
+	{name: "simulate", method: http.MethodPost, path: "/simulate", body: `{"network":"EVMOS","txBytes":[10,2,8,1]}`},
+	{name: "simulate_failed", method: http.MethodPost, path: "/simulate", body: `{"network":"EVMOS","txBytes":[10,2,8,1]}`, setup: func(t *testing.T, chains goldenChains) {
+		chains.evmos.Handle(http.MethodPost, "/cosmos/tx/v1beta1/simulate", http.StatusBadRequest, `{"code":13,"message":"insufficient fees; got: 0aevmos required: 4000000000000000aevmos: insufficient fee","details":[]}`)
+	}},
+	{name: "tx_status", method: http.MethodGet, path: "/TxStatus/EVMOS/" + mockchain.TxHash},
+
+	// staking
+	{name: "total_staked_by_address", method: http.MethodGet, path: "/totalStakedByAddress/" + mockchain.Address},
+	{name: "all_validators", method: http.MethodGet, path: "/AllValidators"},
+	{name: "delegate", method: http.MethodPost, path: "/delegate", body: txBody(`{"amount":"1000000000000000000","validatorAddress":"` + mockchain.Validator + `"}`)},
+	{name: "undelegate", method: http.MethodPost, path: "/undelegate", body: txBody(`{"amount":"1000000000000000000","validatorAddress":"` + mockchain.Validator + `"}`)},
+	{name: "redelegate", method: http.MethodPost, path: "/redelegate", body: txBody(`{"amount":"1000000000000000000","validatorAddress":"` + mockchain.Validator + `","validatorDstAddress":"` + validator2 + `"}`)},
+	{name: "redelegate_invalid_amount", method: http.MethodPost, path: "/redelegate", body: txBody(`{"amount":"one","validatorAddress":"` + mockchain.Validator + `","validatorDstAddress":"` + validator2 + `"}`)},
+	{name: "staking_info", method: http.MethodGet, path: "/stakingInfo/" + mockchain.Address},
+	{name: "cancel_undelegation", method: http.MethodPost, path: "/cancelUndelegation", body: txBody(`{"validatorAddress":"` + mockchain.Validator + `","creationHeight":"13200000","amount":"500"}`)},
+
+	// gov
+	{name: "vote_record", method: http.MethodGet, path: "/VoteRecord/EVMOS/1/" + mockchain.Address},
+	{name: "v1_proposals", method: http.MethodGet, path: "/V1Proposals"},
+	{name: "vote", method: http.MethodPost, path: "/vote", body: txBody(`{"option":1,"proposalId":2}`)},
+
+	// erc20
+	{name: "erc20_module_empty_balance", method: http.MethodGet, path: "/ERC20ModuleBalance"},
+	{name: "erc20_module_balance", method: http.MethodGet, path: "/ERC20ModuleBalance/" + mockchain.Address + "/" + ethAddress, setup: func(t *testing.T, chains goldenChains) {
+		// 2 OSMO as ERC20 tokens
+		chains.evmos.HandleRPC("eth_call", `"0x00000000000000000000000000000000000000000000000000000000001e8480"`)
+	}},
+	{name: "convert_coin", method: http.MethodPost, path: "/convertCoin", body: txBody(`{"srcChain":"EVMOS","sender":"` + mockchain.Address + `","receiver":"` + ethAddress + `","amount":"1000000","token":"OSMO"}`)},
+	{name: "convert_erc20", method: http.MethodPost, path: "/convertERC20", body: txBody(`{"srcChain":"EVMOS","sender":"` + ethAddress + `","receiver":"` + mockchain.Address + `","amount":"1000000","token":"OSMO"}`)},
+	{name: "convert_erc20_unknown_token", method: http.MethodPost, path: "/convertERC20", body: txBody(`{"srcChain":"EVMOS","sender":"` + ethAddress + `","receiver":"` + mockchain.Address + `","amount":"1000000","token":"UNKNOWN"}`)},
+}
+
+// TestGolden compares the responses of the v1 routes with the snapshots of
+// testdata/golden, the nodes answering with the recorded responses.
+func TestGolden(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "")
+
+	for _, tc := range goldenCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got := serveGolden(t, tc)
+
+			golden := filepath.Join(goldenDir, tc.name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, got, 0o600); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("error reading the golden file, run the test with -update to create it: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("response differs from %s, run the test with -update if the change is intended\ngot:\n%s\nwant:\n%s", golden, got, want)
+			}
+		})
+	}
+}
+
+// TestGoldenCoverage checks that every v1 route has a golden case.
+func TestGoldenCoverage(t *testing.T) {
+	routes := router.New()
+	NewHandler(db.NewMemoryStore(10)).RegisterRoutes(routes)
+
+	// The same routes, recording the route matched by each case
+	r := router.New()
+	r.SaveMatchedRoutePath = true
+	for method, paths := range routes.List() {
+		for _, path := range paths {
+			r.Handle(method, path, func(*fasthttp.RequestCtx) {})
+		}
+	}
+
+	covered := map[string]bool{}
+	for _, tc := range goldenCases {
+		ctx := newGoldenRequest(tc)
+		r.Handler(ctx)
+		path, ok := ctx.UserValue(router.MatchedRoutePathParam).(string)
+		if !ok {
+			t.Fatalf("golden case %s does not match a route", tc.name)
+		}
+		covered[tc.method+" "+path] = true
+	}
+
+	for method, paths := range routes.List() {
+		for _, path := range paths {
+			if !covered[method+" "+path] {
+				t.Errorf("route %s %s has no golden case", method, path)
+			}
+		}
+	}
+}
+
+// serveGolden serves the request of the case and returns the response as
+// written in the golden files.
+func serveGolden(t *testing.T, tc goldenCase) []byte {
+	t.Helper()
+	store := db.NewMemoryStore(1000)
+	chains := goldenChains{evmos: mockchain.New(t), osmosis: mockchain.New(t)}
+	chains.evmos.Register(t, store, "EVMOS")
+	chains.osmosis.Register(t, store, "OSMOSIS")
+	loadFixtures(t, chains.evmos, evmosFixtures)
+	loadFixtures(t, chains.osmosis, osmosisFixtures)
+	if tc.setup != nil {
+		tc.setup(t, chains)
+	}
+	seedStore(t, store)
+
+	r := router.New()
+	NewHandler(store).RegisterRoutes(r)
+	ctx := newGoldenRequest(tc)
+	r.Handler(ctx)
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "%d %s\n", ctx.Response.StatusCode(), ctx.Response.Header.ContentType())
+	out.Write(ctx.Response.Body())
+	out.WriteString("\n")
+	return out.Bytes()
+}
+
+func newGoldenRequest(tc goldenCase) *fasthttp.RequestCtx {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetMethod(tc.method)
+	ctx.Request.SetRequestURI(tc.path)
+	ctx.Request.SetBodyString(tc.body)
+	return ctx
+}
+
+func loadFixtures(t *testing.T, node *mockchain.Node, fixtures []upstreamFixture) {
+	t.Helper()
+	for _, f := range fixtures {
+		body, err := os.ReadFile(filepath.Join(upstreamDir, f.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		node.Handle(f.method, f.pattern, http.StatusOK, string(bytes.TrimSpace(body)))
+	}
+}
+
+// seedStore caches the token registry, the announcements and the prices, the
+// handlers read them from the store instead of GitHub, Airtable and CoinGecko.
+func seedStore(t *testing.T, store db.Store) {
+	t.Helper()
+	tree := requester.TreeResponse{}
+	err := filepath.WalkDir(registryDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(registryDir, path)
+		if err != nil {
+			return err
+		}
+		url := "https://api.github.com/repos/evmos/chain-token-registry/contents/" + filepath.ToSlash(rel)
+		file, err := json.Marshal(requester.Content{Content: base64.StdEncoding.EncodeToString(content)})
+		if err != nil {
+			return err
+		}
+		tree.Tree = append(tree.Tree, requester.Tree{Path: filepath.ToSlash(rel), Mode: "100644", Type: "blob", URL: url})
+		return db.RedisSetGithubResponse(store, url, string(file))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	treeJSON, err := json.Marshal(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.RedisSetGithubResponse(store, registryTreeURL, string(treeJSON)); err != nil {
+		t.Fatal(err)
+	}
+
+	announcements, err := os.ReadFile("testdata/announcements.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.RedisSetAirtableRequest(store, string(bytes.TrimSpace(announcements)), announcementsPath); err != nil {
+		t.Fatal(err)
+	}
+
+	for asset, price := range map[string]string{"evmos": "0.05", "osmosis": "0.5"} {
+		if err := db.RedisSetPrice(store, asset, "usd", price); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
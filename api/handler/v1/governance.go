@@ -4,15 +4,18 @@
 package v1
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/tharsis/dashboard-backend/internal/v1/blockchain"
 	"github.com/tharsis/dashboard-backend/internal/v1/db"
 
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/tharsis/dashboard-backend/internal/v2/requestctx"
 	"github.com/valyala/fasthttp"
 )
 
-func ProcessProposals(proposalsRes string, v1 bool) ([]byte, error) {
+func ProcessProposals(ctx context.Context, store db.Store, proposalsRes string, v1 bool) ([]byte, error) {
 	var jsonProposalRes blockchain.V1GovernanceProposalsResponse
 	err := json.Unmarshal([]byte(proposalsRes), &jsonProposalRes)
 	if err != nil {
@@ -30,7 +33,7 @@ func ProcessProposals(proposalsRes string, v1 bool) ([]byte, error) {
 
 	if v1 {
 		// Get current tally for proposals in voting period and overwrite final tally
-		proposals, err := blockchain.GetV1ProposalsTally(filteredProposals)
+		proposals, err := blockchain.GetV1ProposalsTally(ctx, store, filteredProposals)
 		if err != nil {
 			return []byte{}, err
 		}
@@ -42,7 +45,7 @@ func ProcessProposals(proposalsRes string, v1 bool) ([]byte, error) {
 
 	} else {
 		// Get current tally for proposals in voting period and overwrite final tally
-		proposals, err := blockchain.GetProposalsTally(filteredProposals)
+		proposals, err := blockchain.GetProposalsTally(ctx, store, filteredProposals)
 		if err != nil {
 			return []byte{}, err
 		}
@@ -57,9 +60,9 @@ func ProcessProposals(proposalsRes string, v1 bool) ([]byte, error) {
 	return proposalRes, nil
 }
 
-func V1GovernanceProposals(ctx *fasthttp.RequestCtx) { //nolint: revive
+func (h *Handler) V1GovernanceProposals(ctx *fasthttp.RequestCtx) { //nolint: revive
 	var proposalRes []byte
-	if redisVal, err := db.RedisGetGovernanceV1Proposals(); err == nil && redisVal != "null" {
+	if redisVal, err := db.RedisGetGovernanceV1Proposals(h.store, requestctx.Environment(ctx)); err == nil && redisVal != "null" {
 		proposalRes = []byte(redisVal)
 		if err != nil {
 			sendResponse("Unable to fetch governance proposals", err, ctx)
@@ -67,20 +70,22 @@ func V1GovernanceProposals(ctx *fasthttp.RequestCtx) { //nolint: revive
 		}
 	} else {
 		endpoint := buildThreeParamEndpoint("/cosmos/gov/v1/proposals?pagination.limit=", "50", "&pagination.reverse=true")
-		val, err := getRequestRest("EVMOS", endpoint)
+		val, err := getRequestRest(ctx, h.store, "EVMOS", endpoint)
 		if err != nil {
 			sendResponse("Unable to fetch governance proposals", err, ctx)
 			return
 		}
 
 		// Process and convert v1 payload into v1beta1 payload version
-		proposalRes, err = ProcessProposals(val, true)
+		proposalRes, err = ProcessProposals(ctx, h.store, val, true)
 		if err != nil {
 			sendResponse("Unable to fetch governance proposals", err, ctx)
 			return
 		}
 
-		db.RedisSetGovernanceV1Proposals(string(proposalRes))
+		if err := db.RedisSetGovernanceV1Proposals(h.store, requestctx.Environment(ctx), string(proposalRes)); err != nil {
+			logging.FromContext(ctx).Warn("Error caching governance proposals", "error", err)
+		}
 	}
 	sendResponse(string(proposalRes), nil, ctx)
 }
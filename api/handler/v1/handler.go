@@ -0,0 +1,16 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package v1
+
+import "github.com/tharsis/dashboard-backend/internal/v1/db"
+
+type Handler struct {
+	store db.Store // store caching the node responses and the endpoint rankings
+}
+
+func NewHandler(store db.Store) *Handler {
+	return &Handler{
+		store: store,
+	}
+}
@@ -4,43 +4,110 @@
 package v1
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/tharsis/dashboard-backend/internal/v1/constants"
 	"github.com/tharsis/dashboard-backend/internal/v1/db"
 	"github.com/tharsis/dashboard-backend/internal/v1/metrics"
 	"github.com/tharsis/dashboard-backend/internal/v1/requester"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/tharsis/dashboard-backend/internal/v2/requestctx"
+	"github.com/tharsis/dashboard-backend/internal/v2/telemetry"
 	"github.com/valyala/fasthttp"
 )
 
-func getRequestRest(chain string, endpoint string) (string, error) {
-	return getRequest(chain, "rest", endpoint)
+func getRequestRest(ctx context.Context, store db.Store, chain string, endpoint string) (string, error) {
+	return getRequest(ctx, store, chain, "rest", endpoint)
 }
 
-func GetRequestJrpc(chain string, endpoint string) (string, error) {
-	return getRequest(chain, "jrpc", endpoint)
+func GetRequestJrpc(ctx context.Context, store db.Store, chain string, endpoint string) (string, error) {
+	return getRequest(ctx, store, chain, "jrpc", endpoint)
 }
 
-func getRequest(chain string, endpointType string, endpoint string) (string, error) {
-	val, err := db.RedisGetProxyResponse(chain, endpoint)
+// proxyCache is the cache label used to report the proxy cache lookups
+const proxyCache = "proxy"
+
+// CacheStatusHeader reports in the responses built from an expired node response
+// whether it is being refreshed (stale) or every node failed (fallback).
+const CacheStatusHeader = "X-Cache-Status"
+
+// refreshing holds the proxy responses being refreshed in the background
+var refreshing sync.Map
+
+func getRequest(ctx context.Context, store db.Store, chain string, endpointType string, endpoint string) (string, error) {
+	// The responses of the nodes of each environment are cached apart
+	cacheChain := db.EnvironmentChain(requestctx.Environment(ctx), chain)
+	if val, err := db.RedisGetProxyResponse(store, cacheChain, endpoint); err == nil {
+		telemetry.RecordCacheLookup(proxyCache, telemetry.CacheHit)
+		return val, nil
+	}
+
+	if db.ProxyStaleWhileRevalidate(endpoint) {
+		if val, err := db.RedisGetFallbackResponse(store, cacheChain, endpoint); err == nil {
+			telemetry.RecordCacheLookup(proxyCache, telemetry.CacheStale)
+			setCacheStatus(ctx, telemetry.CacheStale)
+			refreshProxyResponse(ctx, store, chain, endpointType, endpoint)
+			return val, nil
+		}
+	}
+
+	telemetry.RecordCacheLookup(proxyCache, telemetry.CacheMiss)
+	val, err := requester.MakeGetRequest(ctx, store, chain, endpointType, endpoint)
 	if err != nil {
-		val, err = requester.MakeGetRequest(chain, endpointType, endpoint)
-		if err != nil {
-			if val, err := db.RedisGetFallbackResponse(chain, endpoint); err == nil {
-				return val, nil
-			}
-			return "", err
+		if val, err := db.RedisGetFallbackResponse(store, cacheChain, endpoint); err == nil {
+			telemetry.RecordCacheLookup(proxyCache, telemetry.CacheFallback)
+			setCacheStatus(ctx, telemetry.CacheFallback)
+			return val, nil
 		}
-		db.RedisSetProxyResponse(chain, endpoint, val)
-		db.RedisSetFallbacResponse(chain, endpoint, val)
-		return val, nil
+		return "", err
 	}
+	cacheProxyResponse(ctx, store, chain, endpoint, val)
 	return val, nil
 }
 
+// refreshProxyResponse queries the nodes in the background and caches the response,
+// unless the response is already being refreshed.
+func refreshProxyResponse(ctx context.Context, store db.Store, chain string, endpointType string, endpoint string) {
+	key := db.EnvironmentChain(requestctx.Environment(ctx), chain) + "|" + endpointType + "|" + endpoint
+	if _, loaded := refreshing.LoadOrStore(key, struct{}{}); loaded {
+		return
+	}
+
+	// The request context can not be used once the response is sent
+	bgCtx := requestctx.Detach(ctx)
+	go func() {
+		defer refreshing.Delete(key)
+		val, err := requester.MakeGetRequest(bgCtx, store, chain, endpointType, endpoint)
+		if err != nil {
+			logging.FromContext(bgCtx).Warn("Error refreshing proxy response", "chain", chain, "endpoint", endpoint, "error", err)
+			return
+		}
+		cacheProxyResponse(bgCtx, store, chain, endpoint, val)
+	}()
+}
+
+func cacheProxyResponse(ctx context.Context, store db.Store, chain string, endpoint string, val string) {
+	chain = db.EnvironmentChain(requestctx.Environment(ctx), chain)
+	if err := db.RedisSetProxyResponse(store, chain, endpoint, val); err != nil {
+		logging.FromContext(ctx).Warn("Error caching proxy response", "error", err)
+	}
+	if err := db.RedisSetFallbacResponse(store, chain, endpoint, val); err != nil {
+		logging.FromContext(ctx).Warn("Error caching fallback response", "error", err)
+	}
+}
+
+// setCacheStatus sets the cache status header when ctx is the request context.
+func setCacheStatus(ctx context.Context, status string) {
+	if reqCtx, ok := ctx.(*fasthttp.RequestCtx); ok {
+		reqCtx.Response.Header.Set(CacheStatusHeader, status)
+	}
+}
+
 func BuildTwoParamEndpoint(a, b string) string {
 	var sb strings.Builder
 	sb.WriteString(a)
@@ -65,18 +132,18 @@ func BuildFourParamEndpoint(a, b, c, d string) string {
 	return sb.String()
 }
 
-func GetCoingeckoPrice(coingeckoID string) string {
+func GetCoingeckoPrice(store db.Store, coingeckoID string) string {
 	price := "0"
-	val, err := db.RedisGetPrice(coingeckoID, "usd")
+	val, err := db.RedisGetPrice(store, coingeckoID, "usd")
 	if err == nil {
 		price = val
 	}
 	return price
 }
 
-func GetCoingecko24HChange(coingeckoID string) string {
+func GetCoingecko24HChange(store db.Store, coingeckoID string) string {
 	change := "0"
-	val, err := db.RedisGet24HChange(coingeckoID)
+	val, err := db.RedisGet24HChange(store, coingeckoID)
 	if err == nil {
 		change = val
 	}
@@ -125,7 +192,7 @@ func sendResponse(val string, err error, ctx *fasthttp.RequestCtx) {
 func sendJSONResponse(ctx *fasthttp.RequestCtx, response interface{}) {
 	jsonResponse, err := json.Marshal(response)
 	if err != nil {
-		ctx.Logger().Printf("Error encoding response: %s", err.Error())
+		logging.FromContext(ctx).Error("Error encoding response", "error", err)
 		ctx.SetStatusCode(http.StatusInternalServerError)
 		return
 	}
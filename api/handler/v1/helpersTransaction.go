@@ -4,12 +4,15 @@
 package v1
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/tharsis/dashboard-backend/internal/v1/constants"
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v2/requestctx"
 )
 
 type PubKeyAccount struct {
@@ -55,9 +58,9 @@ type AccountResponse struct {
 	Account AccountDetails `json:"account"`
 }
 
-func GetAccountInfo(sender string, srcChain string) (uint64, uint64, error) {
+func GetAccountInfo(ctx context.Context, store db.Store, sender string, srcChain string) (uint64, uint64, error) {
 	// EVMOS and OSMO have different struct for AccountInternal
-	val, err := AccountInternal(sender, srcChain)
+	val, err := AccountInternal(ctx, store, sender, srcChain)
 	if err != nil {
 		return 0, 0, fmt.Errorf("error while getting account details, please try again")
 	}
@@ -110,8 +113,8 @@ func GetAccountInfo(sender string, srcChain string) (uint64, uint64, error) {
 	return number, sequence, nil
 }
 
-func GetHeightInfo(m MessageSendIBCStruct) (uint64, uint64, error) {
-	h, r, err := ChainHeightInternal(m.Message.DstChain)
+func GetHeightInfo(ctx context.Context, store db.Store, m MessageSendIBCStruct) (uint64, uint64, error) {
+	h, r, err := ChainHeightInternal(ctx, store, m.Message.DstChain)
 	if err != nil {
 		// y que no pueda enviarse numero negativo
 		return 0, 0, fmt.Errorf("error while getting height chain info, please try again")
@@ -129,12 +132,12 @@ func GetHeightInfo(m MessageSendIBCStruct) (uint64, uint64, error) {
 	return height, revision, nil
 }
 
-func GetDenom(token string, srcChain string) (string, error) {
+func GetDenom(ctx context.Context, store db.Store, token string, srcChain string) (string, error) {
 	if token == "EVMOS" { //nolint:all
 		if srcChain == "EVMOS" {
 			return "aevmos", nil
 		}
-		network, err := NetworkConfigByNameInternal(srcChain)
+		network, err := NetworkConfigByNameInternal(store, srcChain)
 		if err != nil {
 			return "", fmt.Errorf("invalid source chain, please try again")
 		}
@@ -145,14 +148,15 @@ func GetDenom(token string, srcChain string) (string, error) {
 			return "", fmt.Errorf("invalid params for network configuration, please try again")
 		}
 
+		environment := requestctx.Environment(ctx)
 		for _, v := range config.Values.Configurations {
-			if v.ConfigurationType == constants.Mainnet {
+			if v.ConfigurationType == environment {
 				return v.Source.SourceIBCDenomToEvmos, nil
 			}
 		}
 
 	} else if srcChain == "EVMOS" {
-		token, err := ERC20TokensByNameInternal(token)
+		token, err := ERC20TokensByNameInternal(store, token)
 		if err != nil {
 			return "", err
 		}
@@ -168,7 +172,7 @@ func GetDenom(token string, srcChain string) (string, error) {
 		// That means that the token is already in the format ibc/{denom}, so it was converted by the requester
 		return token, nil
 	} else {
-		token, err := ERC20TokensByNameInternal(token)
+		token, err := ERC20TokensByNameInternal(store, token)
 		if err != nil {
 			return "", err
 		}
@@ -182,14 +186,17 @@ func GetDenom(token string, srcChain string) (string, error) {
 	return "", fmt.Errorf("invalid denom, please try again")
 }
 
-func GetConfigInfo(m MessageSendIBCStruct) (string, string, string, string, string, error) {
+// GetConfigInfo returns the channel, client ID, chain ID, prefix and explorer URL
+// of the transfer in the environment of the request.
+func GetConfigInfo(ctx context.Context, store db.Store, m MessageSendIBCStruct) (string, string, string, string, string, error) {
+	environment := requestctx.Environment(ctx)
 	channel := ""
 	clientID := ""
 	chainID := ""
 	prefix := ""
 	explorerTxURL := ""
 
-	networkSrcChain, err := NetworkConfigByNameInternal(m.Message.SrcChain)
+	networkSrcChain, err := NetworkConfigByNameInternal(store, m.Message.SrcChain)
 	if err != nil {
 		return "", "", "", "", "", err
 	}
@@ -203,7 +210,7 @@ func GetConfigInfo(m MessageSendIBCStruct) (string, string, string, string, stri
 	prefix = configSrcChain.Values.Prefix
 
 	for _, v := range configSrcChain.Values.Configurations {
-		if v.ConfigurationType == constants.Mainnet {
+		if v.ConfigurationType == environment {
 			chainID = v.ChainID
 			channel = v.Source.SourceChannel
 			clientID = v.ClientID
@@ -219,7 +226,7 @@ func GetConfigInfo(m MessageSendIBCStruct) (string, string, string, string, stri
 		if m.Message.DstChain == "STARS" {
 			m.Message.DstChain = "STARGAZE"
 		}
-		network, err := NetworkConfigByNameInternal(m.Message.DstChain)
+		network, err := NetworkConfigByNameInternal(store, m.Message.DstChain)
 		if err != nil {
 			return "", "", "", "", "", err
 		}
@@ -231,7 +238,7 @@ func GetConfigInfo(m MessageSendIBCStruct) (string, string, string, string, stri
 		}
 
 		for _, v := range config.Values.Configurations {
-			if v.ConfigurationType == constants.Mainnet {
+			if v.ConfigurationType == environment {
 				channel = v.Source.DestinationChannel
 				clientID = v.ClientID
 			}
@@ -261,8 +268,8 @@ type ClientStatus struct {
 	Status string `json:"status"`
 }
 
-func IsIBCChannelActive(chain string, clientID string) error {
-	val, err := IBCClientStatusInternal(chain, clientID)
+func IsIBCChannelActive(ctx context.Context, store db.Store, chain string, clientID string) error {
+	val, err := IBCClientStatusInternal(ctx, store, chain, clientID)
 	if err != nil {
 		return err
 	}
@@ -276,8 +283,8 @@ func IsIBCChannelActive(chain string, clientID string) error {
 	return nil
 }
 
-func GetERC20Address(token string) (string, error) {
-	token, err := ERC20TokensByNameInternal(token)
+func GetERC20Address(store db.Store, token string) (string, error) {
+	token, err := ERC20TokensByNameInternal(store, token)
 	if err != nil {
 		return "", err
 	}
@@ -289,11 +296,14 @@ func GetERC20Address(token string) (string, error) {
 	return tokensByName.Values.ERC20Address, nil
 }
 
-func GetSourceInfo(srcChain string) (string, string, string, error) {
+// GetSourceInfo returns the prefix, chain ID and explorer URL of the source
+// chain in the environment of the request.
+func GetSourceInfo(ctx context.Context, store db.Store, srcChain string) (string, string, string, error) {
+	environment := requestctx.Environment(ctx)
 	prefix := ""
 	chainID := ""
 	explorerTxURL := ""
-	networkSrcChain, err := NetworkConfigByNameInternal(srcChain)
+	networkSrcChain, err := NetworkConfigByNameInternal(store, srcChain)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -307,7 +317,7 @@ func GetSourceInfo(srcChain string) (string, string, string, error) {
 	prefix = configSrcChain.Values.Prefix
 
 	for _, v := range configSrcChain.Values.Configurations {
-		if v.ConfigurationType == constants.Mainnet {
+		if v.ConfigurationType == environment {
 			chainID = v.ChainID
 			explorerTxURL = v.ExplorerTxURL
 		}
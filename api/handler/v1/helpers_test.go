@@ -0,0 +1,167 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/blockchain"
+	"github.com/tharsis/dashboard-backend/internal/v1/constants"
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v2/mockchain"
+	"github.com/tharsis/dashboard-backend/internal/v2/requestctx"
+	"github.com/valyala/fasthttp"
+)
+
+func TestGetRequestServesCachedResponse(t *testing.T) {
+	store := db.NewMemoryStore(100)
+	if err := db.RedisSetProxyResponse(store, "EVMOS", "/cosmos/base/tendermint/v1beta1/blocks/latest", `{"block":{}}`); err != nil {
+		t.Fatal(err)
+	}
+
+	// No endpoint is stored, the nodes are never queried
+	val, err := getRequestRest(context.Background(), store, "EVMOS", "/cosmos/base/tendermint/v1beta1/blocks/latest")
+	if err != nil || val != `{"block":{}}` {
+		t.Fatalf("expected the cached response, got %q %v", val, err)
+	}
+
+	if _, err := getRequestRest(context.Background(), store, "EVMOS", "/cosmos/bank/v1beta1/balances/evmos1"); err == nil {
+		t.Fatalf("expected an error without endpoints")
+	}
+}
+
+func TestGetRequestStaleWhileRevalidate(t *testing.T) {
+	db.SetProxyCacheConfig(db.ProxyCacheConfig{StaleWhileRevalidate: true})
+	t.Cleanup(func() { db.SetProxyCacheConfig(db.ProxyCacheConfig{}) })
+
+	store := db.NewMemoryStore(100)
+	endpoint := "/cosmos/staking/v1beta1/pool"
+	if err := db.RedisSetFallbacResponse(store, "EVMOS", endpoint, `{"pool":{}}`); err != nil {
+		t.Fatal(err)
+	}
+
+	// The fresh response expired, the fallback one is served while it is refreshed
+	ctx := &fasthttp.RequestCtx{}
+	val, err := getRequestRest(ctx, store, "EVMOS", endpoint)
+	if err != nil || val != `{"pool":{}}` {
+		t.Fatalf("expected the fallback response, got %q %v", val, err)
+	}
+	if status := string(ctx.Response.Header.Peek(CacheStatusHeader)); status != "stale" {
+		t.Fatalf("expected a stale cache status, got %q", status)
+	}
+
+	// Fresh responses have no cache status
+	if err := db.RedisSetProxyResponse(store, "EVMOS", endpoint, `{"pool":{"bonded_tokens":"1"}}`); err != nil {
+		t.Fatal(err)
+	}
+	ctx = &fasthttp.RequestCtx{}
+	if val, err := getRequestRest(ctx, store, "EVMOS", endpoint); err != nil || val != `{"pool":{"bonded_tokens":"1"}}` {
+		t.Fatalf("expected the fresh response, got %q %v", val, err)
+	}
+	if status := ctx.Response.Header.Peek(CacheStatusHeader); len(status) != 0 {
+		t.Fatalf("expected no cache status, got %q", status)
+	}
+}
+
+func TestGetRequestNoStale(t *testing.T) {
+	db.SetProxyCacheConfig(db.ProxyCacheConfig{
+		StaleWhileRevalidate: true,
+		Rules:                []db.ProxyCacheRule{{Pattern: "/cosmos/auth/v1beta1/accounts/*", NoStale: true}},
+	})
+	t.Cleanup(func() { db.SetProxyCacheConfig(db.ProxyCacheConfig{}) })
+
+	store := db.NewMemoryStore(100)
+	node := mockchain.New(t)
+	node.Register(t, store, "EVMOS")
+	endpoint := "/cosmos/auth/v1beta1/accounts/" + mockchain.Address
+	if err := db.RedisSetFallbacResponse(store, "EVMOS", endpoint, `{"stale":true}`); err != nil {
+		t.Fatal(err)
+	}
+
+	// The account sequence is never served stale, the nodes are queried
+	ctx := &fasthttp.RequestCtx{}
+	val, err := getRequestRest(ctx, store, "EVMOS", endpoint)
+	if err != nil || val == `{"stale":true}` {
+		t.Fatalf("expected the node response, got %q %v", val, err)
+	}
+	if status := ctx.Response.Header.Peek(CacheStatusHeader); len(status) != 0 {
+		t.Fatalf("expected no cache status, got %q", status)
+	}
+}
+
+func TestEnvironmentCachesAreApart(t *testing.T) {
+	store := db.NewMemoryStore(100)
+	mainnet := mockchain.New(t)
+	mainnet.Register(t, store, "EVMOS")
+	testnet := mockchain.New(t)
+	testnet.Handle(http.MethodGet, "/cosmos/staking/v1beta1/validators*", http.StatusOK, `{"validators":[{`+
+		`"operator_address":"evmosvaloper1testnet","tokens":"1","description":{"moniker":"testnet"}}],"pagination":{"next_key":null,"total":"1"}}`)
+	testnet.HandleRPC("eth_call", `"0x0000000000000000000000000000000000000000000000000000000000000007"`)
+	now := time.Now()
+	for _, endpointType := range []string{"rest", "web3"} {
+		if err := db.RedisSetEndpointRanking(store, "TESTNET:EVMOS", endpointType, []db.RankedEndpoint{{URL: testnet.URL(), LastChecked: now}}, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	testnetCtx := requestctx.WithEnvironment(context.Background(), constants.Testnet)
+
+	// The testnet requests are served first and must not fill the mainnet keys
+	h := NewHandler(store)
+	allValidators := func(environment string) string {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.SetUserValue(requestctx.EnvironmentValue, environment)
+		h.AllValidators(ctx)
+		return string(ctx.Response.Body())
+	}
+	if body := allValidators(constants.Testnet); !strings.Contains(body, "evmosvaloper1testnet") {
+		t.Fatalf("expected the testnet validators, got %s", body)
+	}
+	if body := allValidators(constants.Mainnet); !strings.Contains(body, mockchain.Validator) || strings.Contains(body, "evmosvaloper1testnet") {
+		t.Fatalf("expected the mainnet validators, got %s", body)
+	}
+
+	validators, err := GetValidatorsWithNoFilter(testnetCtx, store, "EVMOS")
+	if _, ok := validators["evmosvaloper1testnet"]; err != nil || !ok {
+		t.Fatalf("expected the testnet validators, got %v %v", validators, err)
+	}
+	validators, err = GetValidatorsWithNoFilter(context.Background(), store, "EVMOS")
+	if _, ok := validators[mockchain.Validator]; err != nil || !ok || len(validators) != 1 {
+		t.Fatalf("expected the mainnet validators, got %v %v", validators, err)
+	}
+
+	contract, wallet := "0xD4949664cD82660AaE99bEdc034a0deA8A0bd517", "0x0000000000000000000000000000000000000001"
+	if balance, err := blockchain.GetERC20Balance(testnetCtx, store, contract, wallet); err != nil || balance != "7" {
+		t.Fatalf("expected the testnet balance, got %q %v", balance, err)
+	}
+	if balance, err := blockchain.GetERC20Balance(context.Background(), store, contract, wallet); err != nil || balance != "0" {
+		t.Fatalf("expected the mainnet balance, got %q %v", balance, err)
+	}
+}
+
+func TestSharedComputeOutlivesRequest(t *testing.T) {
+	store := db.NewMemoryStore(100)
+	mockchain.New(t).Register(t, store, "EVMOS")
+
+	// The request that starts the computation is already gone, the
+	// computation shared with the other requests must still complete
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	validators, err := GetValidatorsWithNoFilter(ctx, store, "EVMOS")
+	if _, ok := validators[mockchain.Validator]; err != nil || !ok {
+		t.Fatalf("expected the validators, got %v %v", validators, err)
+	}
+
+	// The node errors are returned instead of decoding an empty response
+	failing := db.NewMemoryStore(100)
+	node := mockchain.New(t)
+	node.Handle(http.MethodGet, "/cosmos/staking/v1beta1/validators*", http.StatusInternalServerError, `{}`)
+	node.Register(t, failing, "EVMOS")
+	if _, err := GetValidatorsWithNoFilter(context.Background(), failing, "EVMOS"); err == nil || strings.Contains(err.Error(), "JSON") {
+		t.Fatalf("expected the node error, got %v", err)
+	}
+}
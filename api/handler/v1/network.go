@@ -11,6 +11,7 @@ import (
 	"github.com/tharsis/dashboard-backend/internal/v1/db"
 	"github.com/tharsis/dashboard-backend/internal/v1/requester"
 	"github.com/tharsis/dashboard-backend/internal/v1/resources"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
 	"github.com/valyala/fasthttp"
 )
 
@@ -18,8 +19,8 @@ type ValuesResponse struct {
 	Values interface{} `json:"values"`
 }
 
-func NetworkConfig(ctx *fasthttp.RequestCtx) {
-	networkConfigs, err := resources.GetNetworkConfigs()
+func (h *Handler) NetworkConfig(ctx *fasthttp.RequestCtx) {
+	networkConfigs, err := resources.GetNetworkConfigs(h.store)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return
@@ -60,14 +61,14 @@ type NetworkByName struct {
 	Values ConfigurationParams `json:"values"`
 }
 
-func NetworkConfigByNameInternal(name string) (string, error) {
+func NetworkConfigByNameInternal(store db.Store, name string) (string, error) {
 	name = strings.ToLower(name)
 
-	if val, err := db.RedisGetNetworkConfigByName(name); err == nil {
+	if val, err := db.RedisGetNetworkConfigByName(store, name); err == nil {
 		return val, nil
 	}
 
-	val, err := requester.GetNetworkConfig()
+	val, err := requester.GetNetworkConfig(store)
 	if err != nil {
 		return "", err
 	}
@@ -75,17 +76,19 @@ func NetworkConfigByNameInternal(name string) (string, error) {
 	for _, v := range val {
 		if strings.Contains(v.URL, name) {
 			res := buildValuesResponse(v.Content)
-			db.RedisSetNetworkConfigByName(name, res)
+			if err := db.RedisSetNetworkConfigByName(store, name, res); err != nil {
+				logging.Default().Warn("Error caching network config", "error", err)
+			}
 			return res, nil
 		}
 	}
 	return "", fmt.Errorf("invalid network")
 }
 
-func NetworkConfigByName(ctx *fasthttp.RequestCtx) {
+func (h *Handler) NetworkConfigByName(ctx *fasthttp.RequestCtx) {
 	name := paramToString("name", ctx)
 
-	val, err := NetworkConfigByNameInternal(name)
+	val, err := NetworkConfigByNameInternal(h.store, name)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return
@@ -0,0 +1,112 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package v1
+
+import (
+	"net/http"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/blockchain"
+	"github.com/tharsis/dashboard-backend/internal/v1/resources"
+	"github.com/tharsis/dashboard-backend/internal/v2/openapi"
+)
+
+const (
+	tagEpochs        = "epochs"
+	tagAnnouncements = "announcements"
+	tagConfig        = "config"
+	tagBank          = "bank"
+	tagTx            = "tx"
+	tagStaking       = "staking"
+	tagGov           = "gov"
+	tagERC20         = "erc20"
+
+	// proxied responses are forwarded as returned by the node
+	proxiedResponse = "The response of the node is forwarded as is."
+)
+
+// IBCExecutedResponse represents the response of the GET /isIBCExecuted/{tx_hash}/{chain} endpoint.
+type IBCExecutedResponse struct {
+	Executed bool   `json:"executed"`
+	Msg      string `json:"msg"`
+}
+
+// SimulateResponse represents the response of the POST /simulate endpoint.
+type SimulateResponse struct {
+	Status  bool   `json:"status"`
+	Message string `json:"message"`
+}
+
+// ERC20BalancesResponse represents the response of the GET /ERC20ModuleBalance endpoints.
+type ERC20BalancesResponse struct {
+	Balance []ERC20Entry `json:"balance"`
+}
+
+// NetworkConfigsResponse represents the response of the GET /NetworkConfig endpoint.
+type NetworkConfigsResponse struct {
+	Values []resources.NetworkConfig `json:"values"`
+}
+
+// ValidatorsResponse represents the response of the GET /AllValidators endpoint.
+type ValidatorsResponse struct {
+	Values []Validator `json:"values"`
+}
+
+// OpenAPIRoutes describes the routes registered by RegisterRoutes
+// for the OpenAPI document.
+func OpenAPIRoutes() []openapi.Route {
+	transaction := "Builds an unsigned transaction to be signed by the wallet."
+
+	return []openapi.Route{
+		// epoch
+		{Method: http.MethodGet, Path: "/RemainingEpochs", Tag: tagEpochs, Summary: "Remaining epochs of the current inflation period", Response: RemainingEpochsResponse{}},
+		{Method: http.MethodGet, Path: "/Epochs/{chain}", Tag: tagEpochs, Summary: "Epochs of the chain, EVMOS only", Description: proxiedResponse},
+
+		// announcements
+		{Method: http.MethodGet, Path: "/Announcements", Tag: tagAnnouncements, Summary: "Latest announcements", Description: "The Airtable records are forwarded as is."},
+
+		// config
+		{Method: http.MethodGet, Path: "/NetworkConfig", Tag: tagConfig, Summary: "Configuration of every network", Response: NetworkConfigsResponse{}},
+		{Method: http.MethodGet, Path: "/NetworkConfig/{name}", Tag: tagConfig, Summary: "Configuration of a network", Response: NetworkByName{}},
+
+		// ibc
+		{Method: http.MethodPost, Path: "/ibcTransfer", Tag: tagTx, Summary: "IBC transfer", Description: transaction, Request: MessageSendIBCStruct{}, Response: TransactionString{}},
+
+		// bank
+		{Method: http.MethodGet, Path: "/BalanceByDenom/{chain}/{address}/{denom:*}", Tag: tagBank, Summary: "Balance of a denom", Description: proxiedResponse, Response: BalanceResponse{}},
+		{Method: http.MethodGet, Path: "/BalanceByNetworkAndDenom/{chain}/{token}/{address}", Tag: tagBank, Summary: "Balance of a token on a network", Description: proxiedResponse, Response: BalanceResponse{}},
+		{Method: http.MethodGet, Path: "/EVMOSIBCBalance/{chain}/{address}", Tag: tagBank, Summary: "EVMOS balance on an IBC chain", Description: proxiedResponse, Response: BalanceResponse{}},
+
+		// distribution
+		{Method: http.MethodPost, Path: "/rewards", Tag: tagStaking, Summary: "Claim the staking rewards", Description: transaction, Request: TxRewardsStruct{}, Response: TransactionString{}},
+
+		// tx
+		{Method: http.MethodGet, Path: "/isIBCExecuted/{tx_hash}/{chain}", Tag: tagTx, Summary: "Whether an IBC transfer has been acknowledged", Response: IBCExecutedResponse{}},
+		{Method: http.MethodPost, Path: "/broadcastEip712", Tag: tagTx, Summary: "Broadcast an EIP-712 signed transaction", Description: proxiedResponse, Request: BroadcastMetamaskParams{}},
+		{Method: http.MethodPost, Path: "/simulate", Tag: tagTx, Summary: "Simulate a transaction", Request: simulateParams{}, Response: SimulateResponse{}},
+		{Method: http.MethodGet, Path: "/TxStatus/{chain}/{tx_hash}", Tag: tagTx, Summary: "Status of a transaction", Description: proxiedResponse},
+
+		// staking
+		{Method: http.MethodGet, Path: "/totalStakedByAddress/{address}", Tag: tagStaking, Summary: "Total amount staked by an address", Response: ValueResponse{}},
+		{Method: http.MethodGet, Path: "/AllValidators", Tag: tagStaking, Summary: "Every validator, sorted by voting power", Response: ValidatorsResponse{}},
+		{Method: http.MethodPost, Path: "/delegate", Tag: tagStaking, Summary: "Delegate", Description: transaction, Request: TxDelegateLikeStruct{}, Response: TransactionString{}},
+		{Method: http.MethodPost, Path: "/undelegate", Tag: tagStaking, Summary: "Undelegate", Description: transaction, Request: TxDelegateLikeStruct{}, Response: TransactionString{}},
+		{Method: http.MethodPost, Path: "/redelegate", Tag: tagStaking, Summary: "Redelegate", Description: transaction, Request: TxRedelegateStruct{}, Response: TransactionString{}},
+		{Method: http.MethodGet, Path: "/stakingInfo/{address}", Tag: tagStaking, Summary: "Delegations, undelegations and rewards of an address", Response: StakingInfoResponse{}},
+		{Method: http.MethodPost, Path: "/cancelUndelegation", Tag: tagStaking, Summary: "Cancel an undelegation", Description: transaction, Request: TxCancelUndelegationStruct{}, Response: TransactionString{}},
+
+		// gov
+		{
+			Method: http.MethodGet, Path: "/VoteRecord/{chain}/{proposal_id}/{address}", Tag: tagGov, Summary: "Vote of an address on a proposal", Description: proxiedResponse,
+			Query: []openapi.Parameter{{Name: "v1", In: "query", Description: "Use the gov v1 module", Schema: &openapi.Schema{Type: "string"}}},
+		},
+		{Method: http.MethodGet, Path: "/V1Proposals", Tag: tagGov, Summary: "Governance proposals with their current tally", Response: blockchain.V1ProposalsResponse{}},
+		{Method: http.MethodPost, Path: "/vote", Tag: tagGov, Summary: "Vote on a proposal", Description: transaction, Request: TxVoteStruct{}, Response: TransactionString{}},
+
+		// erc20
+		{Method: http.MethodGet, Path: "/ERC20ModuleBalance", Tag: tagERC20, Summary: "Tokens of the ERC20 module with empty balances", Response: ERC20BalancesResponse{}},
+		{Method: http.MethodGet, Path: "/ERC20ModuleBalance/{evmos_address}/{eth_address}", Tag: tagERC20, Summary: "Cosmos and ERC20 balances of every token", Response: ERC20BalancesResponse{}},
+		{Method: http.MethodPost, Path: "/convertCoin", Tag: tagERC20, Summary: "Convert a coin to ERC20", Description: transaction, Request: TxConvertStruct{}, Response: TransactionString{}},
+		{Method: http.MethodPost, Path: "/convertERC20", Tag: tagERC20, Summary: "Convert an ERC20 to a coin", Description: transaction, Request: TxConvertStruct{}, Response: TransactionString{}},
+	}
+}
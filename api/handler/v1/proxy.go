@@ -5,89 +5,91 @@ package v1
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/tharsis/dashboard-backend/internal/v1/blockchain"
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
 	"github.com/tharsis/dashboard-backend/internal/v1/requester"
 	"github.com/valyala/fasthttp"
 )
 
 // Endpoints
 
-func VoteRecord(ctx *fasthttp.RequestCtx) {
+func (h *Handler) VoteRecord(ctx *fasthttp.RequestCtx) {
 	url := blockchain.GetGovURL(ctx.QueryArgs().Peek("v1"))
 	endpoint := BuildFourParamEndpoint(url+"/proposals/", paramToString("proposal_id", ctx), "/votes/", paramToString("address", ctx))
-	val, err := getRequestRest(getChain(ctx), endpoint)
+	val, err := getRequestRest(ctx, h.store, getChain(ctx), endpoint)
 	sendResponse(val, err, ctx)
 }
 
-func Epochs(ctx *fasthttp.RequestCtx) {
+func (h *Handler) Epochs(ctx *fasthttp.RequestCtx) {
 	if err := enforceEvmos(ctx); err == nil {
 		endpoint := "/evmos/epochs/v1/epochs"
-		val, err := getRequestRest(getChain(ctx), endpoint)
+		val, err := getRequestRest(ctx, h.store, getChain(ctx), endpoint)
 		sendResponse(val, err, ctx)
 	}
 }
 
-func EthGasPriceInternal() (string, error) {
+func EthGasPriceInternal(ctx context.Context, store db.Store) (string, error) {
 	payload := bytes.NewBuffer([]byte(`{"jsonrpc":"2.0","method":"eth_gasPrice","params":[],"id":1}`))
-	val, err := requester.MakePostRequest("EVMOS", "web3", "/", payload.Bytes())
+	val, err := requester.MakePostRequest(ctx, store, "EVMOS", "web3", "/", payload.Bytes())
 	if err != nil {
 		return "", err
 	}
 	return val, nil
 }
 
-func FeeMarketParamsInternal(chain string) (string, error) {
+func FeeMarketParamsInternal(ctx context.Context, store db.Store, chain string) (string, error) {
 	if chain == "EVMOS" {
 		endpoint := "/evmos/feemarket/v1/params"
-		val, err := getRequestRest(chain, endpoint)
+		val, err := getRequestRest(ctx, store, chain, endpoint)
 		return val, err
 	}
 
 	return "", fmt.Errorf("network is not Evmos")
 }
 
-func BalanceByDenom(ctx *fasthttp.RequestCtx) {
+func (h *Handler) BalanceByDenom(ctx *fasthttp.RequestCtx) {
 	endpoint := BuildFourParamEndpoint("/cosmos/bank/v1beta1/balances/", paramToString("address", ctx), "/by_denom?denom=", paramToString("denom", ctx))
-	val, err := getRequestRest(getChain(ctx), endpoint)
+	val, err := getRequestRest(ctx, h.store, getChain(ctx), endpoint)
 	sendResponse(val, err, ctx)
 }
 
-func GetValidators(status string, chain string) (string, error) {
+func GetValidators(ctx context.Context, store db.Store, status string, chain string) (string, error) {
 	endpoint := buildThreeParamEndpoint("/cosmos/staking/v1beta1/validators?status=", status, "&pagination.limit=200")
-	return getRequestRest(chain, endpoint)
+	return getRequestRest(ctx, store, chain, endpoint)
 }
 
-func GetAllValidators(chain string) (string, error) {
+func GetAllValidators(ctx context.Context, store db.Store, chain string) (string, error) {
 	endpoint := "/cosmos/staking/v1beta1/validators?pagination.limit=500"
-	return getRequestRest(chain, endpoint)
+	return getRequestRest(ctx, store, chain, endpoint)
 }
 
-func AccountInternal(address string, chain string) (string, error) {
+func AccountInternal(ctx context.Context, store db.Store, address string, chain string) (string, error) {
 	endpoint := BuildTwoParamEndpoint("/cosmos/auth/v1beta1/accounts/", address)
-	val, err := getRequestRest(chain, endpoint)
+	val, err := getRequestRest(ctx, store, chain, endpoint)
 	if err != nil {
 		return "", err
 	}
 	return val, nil
 }
 
-func IBCClientStatusInternal(chain string, clientID string) (string, error) {
+func IBCClientStatusInternal(ctx context.Context, store db.Store, chain string, clientID string) (string, error) {
 	endpoint := BuildTwoParamEndpoint("/ibc/core/client/v1/client_status/", clientID)
-	val, err := getRequestRest(chain, endpoint)
+	val, err := getRequestRest(ctx, store, chain, endpoint)
 	if err != nil {
 		return "", err
 	}
 	return val, nil
 }
 
-func TxStatus(ctx *fasthttp.RequestCtx) {
+func (h *Handler) TxStatus(ctx *fasthttp.RequestCtx) {
 	endpoint := BuildTwoParamEndpoint("/tx?hash=0x", paramToString("tx_hash", ctx))
-	val, err := GetRequestJrpc(getChain(ctx), endpoint)
+	val, err := GetRequestJrpc(ctx, h.store, getChain(ctx), endpoint)
 	sendResponse(val, err, ctx)
 }
 
@@ -128,14 +130,14 @@ func ConvertTxBytesToString(txBytes []uint8) string {
 	return localTxBytes
 }
 
-func SimulateInternal(network string, txBytes string) (bool, string) {
+func SimulateInternal(ctx context.Context, store db.Store, network string, txBytes string) (bool, string) {
 	var sb strings.Builder
 	sb.WriteString(`{"tx_bytes":[`)
 	sb.WriteString(txBytes)
 	sb.WriteString(`]}`)
 	jsonBody := []byte(sb.String())
 
-	val, err := requester.MakePostRequest(network, "rest", "/cosmos/tx/v1beta1/simulate", jsonBody)
+	val, err := requester.MakePostRequest(ctx, store, network, "rest", "/cosmos/tx/v1beta1/simulate", jsonBody)
 	if err != nil {
 		return false, fmt.Sprint(err)
 	}
@@ -152,18 +154,18 @@ func SimulateInternal(network string, txBytes string) (bool, string) {
 	return true, "Transaction was simulated correctly"
 }
 
-func Simulate(ctx *fasthttp.RequestCtx) {
+func (h *Handler) Simulate(ctx *fasthttp.RequestCtx) {
 	m := simulateParams{}
 	if err := json.Unmarshal(ctx.PostBody(), &m); err != nil {
 		sendResponse("", err, ctx)
 		return
 	}
 	txBytes := ConvertTxBytesToString(m.TxBytes)
-	success, err := SimulateInternal(m.Network, txBytes)
+	success, err := SimulateInternal(ctx, h.store, m.Network, txBytes)
 	sendResponse("{\"status\": "+strconv.FormatBool(success)+", \"message\": \""+err+"\"}", nil, ctx)
 }
 
-func broadcastInternal(bytes []byte, network string) (string, error) {
+func broadcastInternal(ctx context.Context, store db.Store, bytes []byte, network string) (string, error) {
 	txBytes := ConvertTxBytesToString(bytes)
 
 	var sb strings.Builder
@@ -176,7 +178,7 @@ func broadcastInternal(bytes []byte, network string) (string, error) {
 	if network != "EMONEY" {
 		// emoney uses a cosmos sdk version that does not match with the simulate
 		// that we are using
-		if success, msg := SimulateInternal(network, txBytes); !success {
+		if success, msg := SimulateInternal(ctx, store, network, txBytes); !success {
 			errorString := parseErrorString(msg)
 			var sb strings.Builder
 			sb.WriteString(`{"error":"`)
@@ -185,7 +187,7 @@ func broadcastInternal(bytes []byte, network string) (string, error) {
 			return sb.String(), nil
 		}
 	}
-	val, err := requester.MakeLongPostRequest(network, "rest", "/cosmos/tx/v1beta1/txs", jsonBody)
+	val, err := requester.MakeLongPostRequest(ctx, store, network, "rest", "/cosmos/tx/v1beta1/txs", jsonBody)
 	if err != nil {
 		return "", err
 	}
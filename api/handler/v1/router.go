@@ -7,52 +7,52 @@ import (
 	"github.com/fasthttp/router"
 )
 
-func RegisterRoutes(r *router.Router) {
+func (h *Handler) RegisterRoutes(r *router.Router) {
 	// epoch
-	r.GET("/RemainingEpochs", RemainingEpochs)
-	r.GET("/Epochs/{chain}", Epochs)
+	r.GET("/RemainingEpochs", h.RemainingEpochs)
+	r.GET("/Epochs/{chain}", h.Epochs)
 
 	// announcements
-	r.GET("/Announcements", GetAnnouncements)
+	r.GET("/Announcements", h.GetAnnouncements)
 
 	// config
-	r.GET("/NetworkConfig", NetworkConfig)
-	r.GET("/NetworkConfig/{name}", NetworkConfigByName)
+	r.GET("/NetworkConfig", h.NetworkConfig)
+	r.GET("/NetworkConfig/{name}", h.NetworkConfigByName)
 
 	// ibc
-	r.POST("/ibcTransfer", IBCTransfer)
+	r.POST("/ibcTransfer", h.IBCTransfer)
 
 	// bank
-	r.GET("/BalanceByDenom/{chain}/{address}/{denom:*}", BalanceByDenom)
-	r.GET("/BalanceByNetworkAndDenom/{chain}/{token}/{address}", BalanceByNetworkAndDenom)
-	r.GET("/EVMOSIBCBalance/{chain}/{address}", EVMOSIBCBalance)
+	r.GET("/BalanceByDenom/{chain}/{address}/{denom:*}", h.BalanceByDenom)
+	r.GET("/BalanceByNetworkAndDenom/{chain}/{token}/{address}", h.BalanceByNetworkAndDenom)
+	r.GET("/EVMOSIBCBalance/{chain}/{address}", h.EVMOSIBCBalance)
 
 	// distribution
-	r.POST("/rewards", Rewards)
+	r.POST("/rewards", h.Rewards)
 
 	// tx
-	r.GET("/isIBCExecuted/{tx_hash}/{chain}", isIBCExecuted)
-	r.POST("/broadcastEip712", BroadcastMetamask)
-	r.POST("/simulate", Simulate)
-	r.GET("/TxStatus/{chain}/{tx_hash}", TxStatus)
+	r.GET("/isIBCExecuted/{tx_hash}/{chain}", h.isIBCExecuted)
+	r.POST("/broadcastEip712", h.BroadcastMetamask)
+	r.POST("/simulate", h.Simulate)
+	r.GET("/TxStatus/{chain}/{tx_hash}", h.TxStatus)
 
 	// staking
-	r.GET("/totalStakedByAddress/{address}", TotalStakingByAddress)
-	r.GET("/AllValidators", AllValidators)
-	r.POST("/delegate", Delegate)
-	r.POST("/undelegate", Undelegate)
-	r.POST("/redelegate", Redelegate)
-	r.GET("/stakingInfo/{address}", StakingInfo)
-	r.POST("/cancelUndelegation", CancelUndelegation)
+	r.GET("/totalStakedByAddress/{address}", h.TotalStakingByAddress)
+	r.GET("/AllValidators", h.AllValidators)
+	r.POST("/delegate", h.Delegate)
+	r.POST("/undelegate", h.Undelegate)
+	r.POST("/redelegate", h.Redelegate)
+	r.GET("/stakingInfo/{address}", h.StakingInfo)
+	r.POST("/cancelUndelegation", h.CancelUndelegation)
 
 	// gov
-	r.GET("/VoteRecord/{chain}/{proposal_id}/{address}", VoteRecord)
-	r.GET("/V1Proposals", V1GovernanceProposals)
-	r.POST("/vote", Vote)
+	r.GET("/VoteRecord/{chain}/{proposal_id}/{address}", h.VoteRecord)
+	r.GET("/V1Proposals", h.V1GovernanceProposals)
+	r.POST("/vote", h.Vote)
 
 	// erc20
-	r.GET("/ERC20ModuleBalance", ERC20ModuleEmptyBalance)
-	r.GET("/ERC20ModuleBalance/{evmos_address}/{eth_address}", ERC20ModuleBalance)
-	r.POST("/convertCoin", ConvertCoin)
-	r.POST("/convertERC20", ConvertERC20)
+	r.GET("/ERC20ModuleBalance", h.ERC20ModuleEmptyBalance)
+	r.GET("/ERC20ModuleBalance/{evmos_address}/{eth_address}", h.ERC20ModuleBalance)
+	r.POST("/convertCoin", h.ConvertCoin)
+	r.POST("/convertERC20", h.ConvertERC20)
 }
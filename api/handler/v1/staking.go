@@ -38,7 +38,7 @@ type ValueResponse struct {
 	Value interface{} `json:"value"`
 }
 
-func TotalStakingByAddress(ctx *fasthttp.RequestCtx) {
+func (h *Handler) TotalStakingByAddress(ctx *fasthttp.RequestCtx) {
 	address := paramToString("address", ctx)
 
 	addressSplitted := strings.Split(address, "evmos")
@@ -48,7 +48,7 @@ func TotalStakingByAddress(ctx *fasthttp.RequestCtx) {
 	}
 
 	endpoint := buildThreeParamEndpoint("/cosmos/staking/v1beta1/delegations/", address, "?pagination.limit=200")
-	val, err := getRequestRest("EVMOS", endpoint)
+	val, err := getRequestRest(ctx, h.store, "EVMOS", endpoint)
 	if err != nil {
 		sendResponse("", err, ctx)
 		return
@@ -73,11 +73,11 @@ func TotalStakingByAddress(ctx *fasthttp.RequestCtx) {
 	sendResponse(res, err, ctx)
 }
 
-func StakingInfo(ctx *fasthttp.RequestCtx) {
+func (h *Handler) StakingInfo(ctx *fasthttp.RequestCtx) {
 	address := paramToString("address", ctx)
 
 	delegationsURL := buildThreeParamEndpoint("/cosmos/staking/v1beta1/delegations/", address, "?pagination.limit=150")
-	delegationsRes, err := getRequestRest("EVMOS", delegationsURL)
+	delegationsRes, err := getRequestRest(ctx, h.store, "EVMOS", delegationsURL)
 	if err != nil {
 		sendResponse("", err, ctx)
 		return
@@ -91,7 +91,7 @@ func StakingInfo(ctx *fasthttp.RequestCtx) {
 	}
 
 	undelegationsURL := buildThreeParamEndpoint("/cosmos/staking/v1beta1/delegators/", address, "/unbonding_delegations")
-	undelegationsRes, err := getRequestRest("EVMOS", undelegationsURL)
+	undelegationsRes, err := getRequestRest(ctx, h.store, "EVMOS", undelegationsURL)
 	if err != nil {
 		sendResponse("unable to get delegations", err, ctx)
 		return
@@ -104,7 +104,7 @@ func StakingInfo(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	valMap, err := GetValidatorsWithNoFilter("EVMOS")
+	valMap, err := GetValidatorsWithNoFilter(ctx, h.store, "EVMOS")
 	if err != nil {
 		sendResponse("unable to get validators data", err, ctx)
 		return
@@ -132,7 +132,7 @@ func StakingInfo(ctx *fasthttp.RequestCtx) {
 
 	endpoint := buildThreeParamEndpoint("/cosmos/distribution/v1beta1/delegators/", address, "/rewards")
 
-	rewardsRes, err := getRequestRest("EVMOS", endpoint)
+	rewardsRes, err := getRequestRest(ctx, h.store, "EVMOS", endpoint)
 	if err != nil {
 		sendResponse("unable to get rewards data", err, ctx)
 		return
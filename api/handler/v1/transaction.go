@@ -4,6 +4,7 @@
 package v1
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -21,6 +22,7 @@ import (
 
 	"github.com/tharsis/dashboard-backend/internal/v1/blockchain"
 	"github.com/tharsis/dashboard-backend/internal/v1/constants"
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
 	"github.com/valyala/fasthttp"
 )
 
@@ -53,7 +55,7 @@ type BroadcastMetamaskParams struct {
 	AuthInfo    string `json:"authInfo"`
 }
 
-func BroadcastMetamask(ctx *fasthttp.RequestCtx) {
+func (h *Handler) BroadcastMetamask(ctx *fasthttp.RequestCtx) {
 	m := BroadcastMetamaskParams{}
 	if err := json.Unmarshal(ctx.PostBody(), &m); err != nil {
 		sendResponse(buildErrorBroadcast("Error while parsing broadcast, please try again"), nil, ctx)
@@ -98,7 +100,7 @@ func BroadcastMetamask(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	val, err := broadcastInternal(bytesTxRaw, "EVMOS")
+	val, err := broadcastInternal(ctx, h.store, bytesTxRaw, "EVMOS")
 	if err != nil {
 		sendResponse("", err, ctx)
 		return
@@ -212,9 +214,9 @@ const (
 	MarginMultiplicatorCoefficient float64 = 1.15
 )
 
-func GenerateFeeGasPrice(gas float64) (int64, error) {
+func GenerateFeeGasPrice(ctx context.Context, store db.Store, gas float64) (int64, error) {
 	// this function uses the eth_gasPrice to solve the fee issues that we are facing
-	val, err := EthGasPriceInternal()
+	val, err := EthGasPriceInternal(ctx, store)
 	if err != nil {
 		return 0, err
 	}
@@ -231,8 +233,8 @@ func GenerateFeeGasPrice(gas float64) (int64, error) {
 	return int64(floatValue * gas * MarginMultiplicatorCoefficient), nil
 }
 
-func GenerateFeeUnSignMarket(chain string, gas float64) (int64, error) {
-	val, err := FeeMarketParamsInternal(chain)
+func GenerateFeeUnSignMarket(ctx context.Context, store db.Store, chain string, gas float64) (int64, error) {
+	val, err := FeeMarketParamsInternal(ctx, store, chain)
 	if err != nil {
 		return 0, err
 	}
@@ -247,14 +249,14 @@ func GenerateFeeUnSignMarket(chain string, gas float64) (int64, error) {
 	return int64(minGasPrice * gas * MarginMultiplicatorCoefficient), nil
 }
 
-func IBCTransfer(ctx *fasthttp.RequestCtx) {
+func (h *Handler) IBCTransfer(ctx *fasthttp.RequestCtx) {
 	m := MessageSendIBCStruct{}
 	if err := json.Unmarshal(ctx.PostBody(), &m); err != nil {
 		sendResponse(buildErrorResponse("Error parsing IBC Transfer, please try again"), nil, ctx)
 		return
 	}
 
-	accountNumber, sequence, err := GetAccountInfo(m.Message.Sender, m.Message.SrcChain)
+	accountNumber, sequence, err := GetAccountInfo(ctx, h.store, m.Message.Sender, m.Message.SrcChain)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return
@@ -264,7 +266,7 @@ func IBCTransfer(ctx *fasthttp.RequestCtx) {
 		gas = m.Transaction.Gas
 	}
 
-	fee, err := GenerateFeeGasPrice(gas)
+	fee, err := GenerateFeeGasPrice(ctx, h.store, gas)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 	}
@@ -274,7 +276,7 @@ func IBCTransfer(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	height, revision, err := GetHeightInfo(m)
+	height, revision, err := GetHeightInfo(ctx, h.store, m)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return
@@ -282,13 +284,13 @@ func IBCTransfer(ctx *fasthttp.RequestCtx) {
 	// We timeout the ibc after 500 blocks
 	height += 500
 
-	channel, clientID, chainID, prefix, explorerTxURL, err := GetConfigInfo(m)
+	channel, clientID, chainID, prefix, explorerTxURL, err := GetConfigInfo(ctx, h.store, m)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return
 	}
 
-	if err := IsIBCChannelActive("EVMOS", clientID); err != nil {
+	if err := IsIBCChannelActive(ctx, h.store, "EVMOS", clientID); err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return
 	}
@@ -296,7 +298,7 @@ func IBCTransfer(ctx *fasthttp.RequestCtx) {
 	denom := ""
 
 	if m.Message.UseERC20Denom {
-		denom, err = GetERC20Address(m.Message.Token)
+		denom, err = GetERC20Address(h.store, m.Message.Token)
 		if err != nil {
 			sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 			return
@@ -304,7 +306,7 @@ func IBCTransfer(ctx *fasthttp.RequestCtx) {
 		denom = "erc20/" + denom
 
 	} else {
-		denom, err = GetDenom(m.Message.Token, m.Message.SrcChain)
+		denom, err = GetDenom(ctx, h.store, m.Message.Token, m.Message.SrcChain)
 		if err != nil {
 			sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 			return
@@ -397,7 +399,7 @@ type TxDelegateLikeStruct struct {
 	Message     DelegateLikeParam               `json:"message"`
 }
 
-func ConvertCoin(ctx *fasthttp.RequestCtx) {
+func (h *Handler) ConvertCoin(ctx *fasthttp.RequestCtx) {
 	// convert to erc20
 	m := TxConvertStruct{}
 	if err := json.Unmarshal(ctx.PostBody(), &m); err != nil {
@@ -405,19 +407,19 @@ func ConvertCoin(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	accountNumber, sequence, err := GetAccountInfo(m.Transaction.Sender, m.Message.SrcChain)
+	accountNumber, sequence, err := GetAccountInfo(ctx, h.store, m.Transaction.Sender, m.Message.SrcChain)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return
 	}
 
-	prefix, chainID, explorerTxURL, err := GetSourceInfo(m.Message.SrcChain)
+	prefix, chainID, explorerTxURL, err := GetSourceInfo(ctx, h.store, m.Message.SrcChain)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return
 	}
 
-	denom, err := GetDenom(m.Message.Token, m.Message.SrcChain)
+	denom, err := GetDenom(ctx, h.store, m.Message.Token, m.Message.SrcChain)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return
@@ -440,7 +442,7 @@ func ConvertCoin(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	fee, err := GenerateFeeGasPrice(EvmosTxFeeConvertAssetGas)
+	fee, err := GenerateFeeGasPrice(ctx, h.store, EvmosTxFeeConvertAssetGas)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return
@@ -474,7 +476,7 @@ func ConvertCoin(ctx *fasthttp.RequestCtx) {
 	sendResponse(string(resultBytes), err, ctx)
 }
 
-func ConvertERC20(ctx *fasthttp.RequestCtx) {
+func (h *Handler) ConvertERC20(ctx *fasthttp.RequestCtx) {
 	// convert to ibc
 	m := TxConvertStruct{}
 	if err := json.Unmarshal(ctx.PostBody(), &m); err != nil {
@@ -482,19 +484,19 @@ func ConvertERC20(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	accountNumber, sequence, err := GetAccountInfo(m.Transaction.Sender, m.Message.SrcChain)
+	accountNumber, sequence, err := GetAccountInfo(ctx, h.store, m.Transaction.Sender, m.Message.SrcChain)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return
 	}
 
-	prefix, chainID, explorerTxURL, err := GetSourceInfo(m.Message.SrcChain)
+	prefix, chainID, explorerTxURL, err := GetSourceInfo(ctx, h.store, m.Message.SrcChain)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return
 	}
 
-	contract, err := GetERC20Address(m.Message.Token)
+	contract, err := GetERC20Address(h.store, m.Message.Token)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return
@@ -517,7 +519,7 @@ func ConvertERC20(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	fee, err := GenerateFeeGasPrice(EvmosTxFeeConvertAssetGas)
+	fee, err := GenerateFeeGasPrice(ctx, h.store, EvmosTxFeeConvertAssetGas)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return
@@ -552,7 +554,7 @@ func ConvertERC20(ctx *fasthttp.RequestCtx) {
 	sendResponse(string(resultBytes), err, ctx)
 }
 
-func delegateLikeParams(ctx *fasthttp.RequestCtx) (TxDelegateLikeStruct, sdkmath.Int, string, error) {
+func (h *Handler) delegateLikeParams(ctx *fasthttp.RequestCtx) (TxDelegateLikeStruct, sdkmath.Int, string, error) {
 	m := TxDelegateLikeStruct{}
 	if err := json.Unmarshal(ctx.PostBody(), &m); err != nil {
 		sendResponse("", err, ctx)
@@ -565,7 +567,7 @@ func delegateLikeParams(ctx *fasthttp.RequestCtx) (TxDelegateLikeStruct, sdkmath
 		return m, sdkmath.Int{}, "", fmt.Errorf("invalid amount")
 	}
 
-	denom, err := GetDenom(constants.EVMOS, constants.EVMOS)
+	denom, err := GetDenom(ctx, h.store, constants.EVMOS, constants.EVMOS)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return m, sdkmath.Int{}, "", fmt.Errorf("invalid denom")
@@ -573,20 +575,20 @@ func delegateLikeParams(ctx *fasthttp.RequestCtx) (TxDelegateLikeStruct, sdkmath
 	return m, amountInt, denom, nil
 }
 
-func createDelegateLikeTransaction(ctx *fasthttp.RequestCtx, txParams blockchain.TransactionIBCParams, msgs []sdk.Msg, gas float64) {
-	accountNumber, sequence, err := GetAccountInfo(txParams.Sender, constants.EVMOS)
+func (h *Handler) createDelegateLikeTransaction(ctx *fasthttp.RequestCtx, txParams blockchain.TransactionIBCParams, msgs []sdk.Msg, gas float64) {
+	accountNumber, sequence, err := GetAccountInfo(ctx, h.store, txParams.Sender, constants.EVMOS)
 	if err != nil {
 		sendResponse("", err, ctx)
 		return
 	}
 
-	fee, err := GenerateFeeGasPrice(gas)
+	fee, err := GenerateFeeGasPrice(ctx, h.store, gas)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return
 	}
 
-	prefix, chainID, explorerTxURL, err := GetSourceInfo(constants.EVMOS)
+	prefix, chainID, explorerTxURL, err := GetSourceInfo(ctx, h.store, constants.EVMOS)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return
@@ -620,8 +622,8 @@ func createDelegateLikeTransaction(ctx *fasthttp.RequestCtx, txParams blockchain
 	sendResponse(string(resultBytes), err, ctx)
 }
 
-func Undelegate(ctx *fasthttp.RequestCtx) {
-	m, amountInt, denom, err := delegateLikeParams(ctx)
+func (h *Handler) Undelegate(ctx *fasthttp.RequestCtx) {
+	m, amountInt, denom, err := h.delegateLikeParams(ctx)
 	if err != nil {
 		// The previous function sends the response to the client
 		return
@@ -633,11 +635,11 @@ func Undelegate(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	createDelegateLikeTransaction(ctx, m.Transaction, []sdk.Msg{undelegateSDKMsg}, DefaultGas)
+	h.createDelegateLikeTransaction(ctx, m.Transaction, []sdk.Msg{undelegateSDKMsg}, DefaultGas)
 }
 
-func Delegate(ctx *fasthttp.RequestCtx) {
-	m, amountInt, denom, err := delegateLikeParams(ctx)
+func (h *Handler) Delegate(ctx *fasthttp.RequestCtx) {
+	m, amountInt, denom, err := h.delegateLikeParams(ctx)
 	if err != nil {
 		// The previous function sends the response to the client
 		return
@@ -649,7 +651,7 @@ func Delegate(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	createDelegateLikeTransaction(ctx, m.Transaction, []sdk.Msg{delegateSDKMsg}, DefaultGas)
+	h.createDelegateLikeTransaction(ctx, m.Transaction, []sdk.Msg{delegateSDKMsg}, DefaultGas)
 }
 
 type RedelegateParams struct {
@@ -663,7 +665,7 @@ type TxRedelegateStruct struct {
 	Message     RedelegateParams                `json:"message"`
 }
 
-func Redelegate(ctx *fasthttp.RequestCtx) {
+func (h *Handler) Redelegate(ctx *fasthttp.RequestCtx) {
 	m := TxRedelegateStruct{}
 	if err := json.Unmarshal(ctx.PostBody(), &m); err != nil {
 		sendResponse("", err, ctx)
@@ -676,7 +678,7 @@ func Redelegate(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	denom, err := GetDenom(constants.EVMOS, constants.EVMOS)
+	denom, err := GetDenom(ctx, h.store, constants.EVMOS, constants.EVMOS)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return
@@ -688,7 +690,7 @@ func Redelegate(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	createDelegateLikeTransaction(ctx, m.Transaction, []sdk.Msg{redelegateSDKMsg}, DefaultGas)
+	h.createDelegateLikeTransaction(ctx, m.Transaction, []sdk.Msg{redelegateSDKMsg}, DefaultGas)
 }
 
 type RewardsParams struct {
@@ -731,7 +733,7 @@ func sortRewards(array []StakingReward) []StakingReward {
 	return array
 }
 
-func Rewards(ctx *fasthttp.RequestCtx) {
+func (h *Handler) Rewards(ctx *fasthttp.RequestCtx) {
 	m := TxRewardsStruct{}
 	if err := json.Unmarshal(ctx.PostBody(), &m); err != nil {
 		sendResponse("", err, ctx)
@@ -739,7 +741,7 @@ func Rewards(ctx *fasthttp.RequestCtx) {
 	}
 
 	endpoint := buildThreeParamEndpoint("/cosmos/distribution/v1beta1/delegators/", m.Transaction.Sender, "/rewards")
-	rewardsRes, err := getRequestRest("EVMOS", endpoint)
+	rewardsRes, err := getRequestRest(ctx, h.store, "EVMOS", endpoint)
 	if err != nil {
 		sendResponse("unable to get rewards data", err, ctx)
 		return
@@ -777,7 +779,7 @@ func Rewards(ctx *fasthttp.RequestCtx) {
 		msgs[k] = msg
 	}
 
-	createDelegateLikeTransaction(ctx, m.Transaction, msgs, DefaultGas*2)
+	h.createDelegateLikeTransaction(ctx, m.Transaction, msgs, DefaultGas*2)
 }
 
 type VoteParams struct {
@@ -801,7 +803,7 @@ type TxCancelUndelegationStruct struct {
 	Message     CancelUndelegationParams        `json:"message"`
 }
 
-func Vote(ctx *fasthttp.RequestCtx) {
+func (h *Handler) Vote(ctx *fasthttp.RequestCtx) {
 	m := TxVoteStruct{}
 	if err := json.Unmarshal(ctx.PostBody(), &m); err != nil {
 		sendResponse(buildErrorResponse("Error parsing Vote, please try again"), nil, ctx)
@@ -819,10 +821,10 @@ func Vote(ctx *fasthttp.RequestCtx) {
 		gas = m.Transaction.Gas
 	}
 
-	createDelegateLikeTransaction(ctx, m.Transaction, []sdk.Msg{msg}, gas)
+	h.createDelegateLikeTransaction(ctx, m.Transaction, []sdk.Msg{msg}, gas)
 }
 
-func CancelUndelegation(ctx *fasthttp.RequestCtx) {
+func (h *Handler) CancelUndelegation(ctx *fasthttp.RequestCtx) {
 	m := TxCancelUndelegationStruct{}
 	if err := json.Unmarshal(ctx.PostBody(), &m); err != nil {
 		sendResponse("", err, ctx)
@@ -835,7 +837,7 @@ func CancelUndelegation(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	denom, err := GetDenom(constants.EVMOS, constants.EVMOS)
+	denom, err := GetDenom(ctx, h.store, constants.EVMOS, constants.EVMOS)
 	if err != nil {
 		sendResponse(buildErrorResponse(err.Error()), nil, ctx)
 		return
@@ -853,5 +855,5 @@ func CancelUndelegation(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	createDelegateLikeTransaction(ctx, m.Transaction, []sdk.Msg{cancelUndelegationSDKMsg}, DefaultGas)
+	h.createDelegateLikeTransaction(ctx, m.Transaction, []sdk.Msg{cancelUndelegationSDKMsg}, DefaultGas)
 }
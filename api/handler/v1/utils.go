@@ -4,6 +4,7 @@
 package v1
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/tharsis/dashboard-backend/internal/v1/db"
 	"github.com/tharsis/dashboard-backend/internal/v1/resources"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/tharsis/dashboard-backend/internal/v2/requestctx"
 	"github.com/valyala/fasthttp"
 )
 
@@ -19,8 +22,9 @@ type ChainHeightParams struct {
 	Revision uint64 `jon:"revision"`
 }
 
-func ChainHeightInternal(chain string) (string, string, error) {
-	cache, err := db.RedisGetChainHeight(chain)
+func ChainHeightInternal(ctx context.Context, store db.Store, chain string) (string, string, error) {
+	cacheChain := db.EnvironmentChain(requestctx.Environment(ctx), chain)
+	cache, err := db.RedisGetChainHeight(store, cacheChain)
 	if err == nil {
 		m := ChainHeightParams{}
 		if err := json.Unmarshal([]byte(cache), &m); err != nil {
@@ -29,7 +33,7 @@ func ChainHeightInternal(chain string) (string, string, error) {
 		return strconv.FormatUint(m.Height, 10), strconv.FormatUint(m.Revision, 10), err
 	}
 
-	val, err := GetRequestJrpc(chain, "/status")
+	val, err := GetRequestJrpc(ctx, store, chain, "/status")
 	if err != nil {
 		return val, val, err
 	}
@@ -66,7 +70,9 @@ func ChainHeightInternal(chain string) (string, string, error) {
 			}
 			// Store the cache
 			val = `{"height":` + height + `,"revision":` + revision + `}`
-			db.RedisSetChainHeight(chain, val)
+			if err := db.RedisSetChainHeight(store, cacheChain, val); err != nil {
+				logging.FromContext(ctx).Warn("Error caching chain height", "error", err)
+			}
 			return height, revision, nil
 		}
 	}
@@ -82,9 +88,9 @@ const (
 	TSError       TxStatusType = "error"
 )
 
-func getTransaction(txHash string, chain string) (TxStatusType, map[string]interface{}) {
+func getTransaction(ctx context.Context, store db.Store, txHash string, chain string) (TxStatusType, map[string]interface{}) {
 	endpoint := BuildTwoParamEndpoint("/tx?hash=0x", txHash)
-	val, err := GetRequestJrpc(chain, endpoint)
+	val, err := GetRequestJrpc(ctx, store, chain, endpoint)
 	if err != nil {
 		return TSError, nil
 	}
@@ -227,9 +233,9 @@ func isIBCExecutedResponse(executed bool, msg string) string {
 	return sb.String()
 }
 
-func isIBCExecuted(ctx *fasthttp.RequestCtx) {
+func (h *Handler) isIBCExecuted(ctx *fasthttp.RequestCtx) {
 	chain := getChain(ctx)
-	status, txJSON := getTransaction(paramToString("tx_hash", ctx), chain)
+	status, txJSON := getTransaction(ctx, h.store, paramToString("tx_hash", ctx), chain)
 	if status != TSConfirmed {
 		// Transaction not confirmed
 		sendResponse(isIBCExecutedResponse(false, "Transaction not confirmed"), nil, ctx)
@@ -242,7 +248,7 @@ func isIBCExecuted(ctx *fasthttp.RequestCtx) {
 		sendResponse(isIBCExecutedResponse(false, "Error getting IBC info"), nil, ctx)
 		return
 	}
-	ibcChannels, err := resources.GetIBCChannels()
+	ibcChannels, err := resources.GetIBCChannels(h.store)
 	if err != nil {
 		return
 	}
@@ -259,7 +265,7 @@ func isIBCExecuted(ctx *fasthttp.RequestCtx) {
 	}
 
 	endpoint := BuildFourParamEndpoint("/ibc/core/channel/v1/channels/", dstChannel, "/ports/transfer/packet_acks/", sequence)
-	dstChainRequest, err := getRequestRest(dstChain, endpoint)
+	dstChainRequest, err := getRequestRest(ctx, h.store, dstChain, endpoint)
 	if err != nil {
 		sendResponse(isIBCExecutedResponse(false, "ACK not found"), nil, ctx)
 		return
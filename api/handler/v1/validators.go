@@ -9,6 +9,8 @@ import (
 
 	sdkmath "cosmossdk.io/math"
 	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/tharsis/dashboard-backend/internal/v2/requestctx"
 	"github.com/valyala/fasthttp"
 )
 
@@ -63,15 +65,16 @@ type ValidatorAPIResponse struct {
 	Pagination Pagination  `json:"pagination"`
 }
 
-func AllValidators(ctx *fasthttp.RequestCtx) {
-	if validators, err := db.RedisGetAllValidators("EVMOS"); err == nil {
+func (h *Handler) AllValidators(ctx *fasthttp.RequestCtx) {
+	cacheChain := db.EnvironmentChain(requestctx.Environment(ctx), "EVMOS")
+	if validators, err := db.RedisGetAllValidators(h.store, cacheChain); err == nil {
 		res := buildValuesResponse(validators)
 		sendResponse(res, err, ctx)
 		return
 	}
 
 	endpoint := BuildTwoParamEndpoint("/cosmos/staking/v1beta1/validators?", "pagination.limit=500")
-	res, err := getRequestRest("EVMOS", endpoint)
+	res, err := getRequestRest(ctx, h.store, "EVMOS", endpoint)
 	if err != nil {
 		sendResponse(err.Error(), err, ctx)
 	}
@@ -109,7 +112,9 @@ func AllValidators(ctx *fasthttp.RequestCtx) {
 
 	validatorsJSON := string(validatorsByte)
 
-	db.RedisSetAllValidators("EVMOS", validatorsJSON)
+	if err := db.RedisSetAllValidators(h.store, cacheChain, validatorsJSON); err != nil {
+		logging.FromContext(ctx).Warn("Error caching validators", "error", err)
+	}
 	validatorsRes := buildValuesResponse(validatorsJSON)
 	sendResponse(validatorsRes, err, ctx)
 }
@@ -3,6 +3,7 @@
 package v2
 
 import (
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
 	"github.com/valyala/fasthttp"
 )
 
@@ -33,9 +34,9 @@ func (h *Handler) DelegationsByAddress(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	delegations, err := h.numiaRPCClient.QueryDelegations(address)
+	delegations, err := h.numiaRPCClient.QueryDelegations(ctx, address)
 	if err != nil {
-		ctx.Logger().Printf("Error querying delegations from Numia: %s", err.Error())
+		logging.FromContext(ctx).Error("Error querying delegations from Numia", "error", err)
 		sendInternalErrorResponse(ctx)
 		return
 	}
@@ -0,0 +1,132 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package v2
+
+import (
+	"strings"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v1/resources"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/tharsis/dashboard-backend/internal/v2/node/upstream"
+	"github.com/tharsis/dashboard-backend/internal/v2/requestctx"
+	"github.com/valyala/fasthttp"
+)
+
+// defaultHistoryWindow is the history returned when no window is given.
+const defaultHistoryWindow = time.Hour
+
+type EndpointsResponse struct {
+	Chain       string `json:"chain"`
+	Environment string `json:"environment"`
+	// Types are the endpoint types with a ranking or a history
+	Types []EndpointTypeStatus `json:"types"`
+}
+
+type EndpointTypeStatus struct {
+	// Type is rest, jrpc, web3 or grpc
+	Type string `json:"type"`
+	// Ranking is the ranking in use, null if none was published
+	Ranking *db.EndpointRanking `json:"ranking"`
+	// History holds the checks of every endpoint within the window
+	History []db.EndpointHistory `json:"history"`
+}
+
+// Endpoints handles GET /v2/endpoints/{chain}.
+// It returns the endpoint rankings of the chain in use by the API, and the
+// checks of the endpoint cron within the window query parameter, a duration,
+// 1h by default. The rank of a check is the position of the endpoint in the
+// ranking published with it, 0 when it was not published.
+// Returns:
+//
+//	{
+//		"chain": "EVMOS",
+//		"environment": "mainnet",
+//		"types": [
+//		  {
+//			"type": "rest",
+//			"ranking": {
+//			  "version": 12,
+//			  "updated_at": "2023-06-01T14:05:00Z",
+//			  "endpoints": [{ "url": "https://rest.evmos.example", "height": 13281459, "latency": 0.12, "last_checked": "2023-06-01T14:05:00Z", "score": 0.89 }]
+//			},
+//			"history": [
+//			  {
+//				"url": "https://rest.evmos.example",
+//				"checks": [{ "checked_at": "2023-06-01T14:05:00Z", "height": 13281459, "latency": 0.12, "lag": 0, "rank": 1 }]
+//			  }
+//			]
+//		  }
+//		]
+//	}
+func (h *Handler) Endpoints(ctx *fasthttp.RequestCtx) {
+	chain := strings.ToUpper(ctx.UserValue("chain").(string))
+	if chain == "" {
+		sendBadRequestResponse(ctx, "Missing chain in request")
+		return
+	}
+	networkConfigs, err := resources.GetNetworkConfigs(h.store)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error getting network configs", "error", err)
+		sendInternalErrorResponse(ctx)
+		return
+	}
+	if !isKnownNetwork(networkConfigs, chain) {
+		sendBadRequestResponse(ctx, "Invalid chain, expected the identifier of a network of the registry")
+		return
+	}
+	window := defaultHistoryWindow
+	if param := string(ctx.QueryArgs().Peek("window")); param != "" {
+		if window, err = time.ParseDuration(param); err != nil || window <= 0 {
+			sendBadRequestResponse(ctx, "Invalid window, expected a positive duration, e.g. 30m")
+			return
+		}
+	}
+
+	environment := requestctx.Environment(ctx)
+	key := db.EnvironmentChain(environment, chain)
+	since := time.Now().Add(-window)
+	response := &EndpointsResponse{
+		Chain:       chain,
+		Environment: environment,
+		Types:       []EndpointTypeStatus{},
+	}
+	for _, endpointType := range []string{upstream.REST, upstream.JRPC, upstream.Web3, upstream.GRPC} {
+		status := EndpointTypeStatus{Type: endpointType}
+		ranking, err := db.RedisGetEndpointRanking(h.store, key, endpointType)
+		switch {
+		case err == nil:
+			status.Ranking = &ranking
+		case err != db.ErrNotFound:
+			logging.FromContext(ctx).Error("Error getting endpoint ranking", "endpoint_type", endpointType, "error", err)
+			sendInternalErrorResponse(ctx)
+			return
+		}
+
+		status.History, err = db.RedisGetEndpointHistory(h.store, key, endpointType, since)
+		if err != nil {
+			logging.FromContext(ctx).Error("Error getting endpoint history", "endpoint_type", endpointType, "error", err)
+			sendInternalErrorResponse(ctx)
+			return
+		}
+
+		if status.Ranking != nil || len(status.History) > 0 {
+			response.Types = append(response.Types, status)
+		}
+	}
+
+	sendSuccessfulJSONResponse(ctx, response)
+}
+
+// isKnownNetwork reports whether chain is the identifier of one of the networks,
+// the one the endpoint cron ranks its endpoints with.
+func isKnownNetwork(networkConfigs []resources.NetworkConfig, chain string) bool {
+	for _, nc := range networkConfigs {
+		if strings.ToUpper(resources.GetNetworkIdentifier(nc)) == chain {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v2/requestctx"
+	"github.com/valyala/fasthttp"
+)
+
+func TestEndpoints(t *testing.T) {
+	store := db.NewMemoryStore(100)
+	now := time.Now()
+	if err := db.RedisSetEndpointRanking(store, "EVMOS", "rest", []db.RankedEndpoint{{URL: "https://a", LastChecked: now}}, now); err != nil {
+		t.Fatal(err)
+	}
+	for _, check := range []db.EndpointCheck{{CheckedAt: now.Add(-2 * time.Hour), Rank: 2}, {CheckedAt: now, Rank: 1}} {
+		if err := db.RedisAddEndpointChecks(store, "EVMOS", "rest", map[string]db.EndpointCheck{"https://a": check}, 6*time.Hour); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.RedisAddEndpointChecks(store, "TESTNET:EVMOS", "jrpc", map[string]db.EndpointCheck{"https://t": {CheckedAt: now}}, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	_, err := db.RedisGetOrComputeNetworkConfig(store, func() (string, error) {
+		return `[{"prefix":"evmos","configurations":[{"chainId":"evmos_9001-2","identifier":"Evmos","configurationType":"mainnet"}]}]`, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &Handler{store: store}
+
+	get := func(uri string, environment string) (int, EndpointsResponse) {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.SetRequestURI(uri)
+		ctx.SetUserValue("chain", strings.TrimPrefix(string(ctx.Path()), "/v2/endpoints/"))
+		if environment != "" {
+			ctx.SetUserValue(requestctx.EnvironmentValue, environment)
+		}
+		h.Endpoints(ctx)
+		var res EndpointsResponse
+		_ = json.Unmarshal(ctx.Response.Body(), &res)
+		return ctx.Response.StatusCode(), res
+	}
+
+	status, res := get("/v2/endpoints/evmos", "")
+	if status != http.StatusOK || res.Chain != "EVMOS" || res.Environment != "mainnet" || len(res.Types) != 1 {
+		t.Fatalf("expected the rest endpoints, got %d %+v", status, res)
+	}
+	rest := res.Types[0]
+	if rest.Type != "rest" || rest.Ranking == nil || len(rest.History) != 1 || len(rest.History[0].Checks) != 1 || rest.History[0].Checks[0].Rank != 1 {
+		t.Fatalf("expected the ranking and the last hour of history, got %+v", rest)
+	}
+
+	if _, res = get("/v2/endpoints/evmos?window=3h", ""); len(res.Types[0].History[0].Checks) != 2 {
+		t.Fatalf("expected the checks of the window, got %+v", res.Types[0].History)
+	}
+	if status, _ = get("/v2/endpoints/evmos?window=-1h", ""); status != http.StatusBadRequest {
+		t.Fatalf("expected a bad request for an invalid window, got %d", status)
+	}
+
+	for _, uri := range []string{"/v2/endpoints/osmosis", "/v2/endpoints/*"} {
+		if status, _ = get(uri, ""); status != http.StatusBadRequest {
+			t.Fatalf("expected a bad request for %s, got %d", uri, status)
+		}
+	}
+
+	status, res = get("/v2/endpoints/evmos", "testnet")
+	if status != http.StatusOK || res.Environment != "testnet" || len(res.Types) != 1 || res.Types[0].Type != "jrpc" || res.Types[0].Ranking != nil {
+		t.Fatalf("expected the testnet history, got %d %+v", status, res)
+	}
+}
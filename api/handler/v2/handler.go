@@ -3,13 +3,17 @@
 
 package v2
 
-import "github.com/tharsis/dashboard-backend/internal/v2/numia"
+import (
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v2/numia"
+)
 
 type Handler struct {
 	numiaRPCClient *numia.RPCClient // client to make RPC queries to Numia
+	store          db.Store         // store holding the node endpoints
 }
 
-func NewHandler() (*Handler, error) {
+func NewHandler(store db.Store) (*Handler, error) {
 	numiaRPCClient, err := numia.NewRPCClient()
 	if err != nil {
 		return nil, err
@@ -17,5 +21,6 @@ func NewHandler() (*Handler, error) {
 
 	return &Handler{
 		numiaRPCClient: numiaRPCClient,
+		store:          store,
 	}, nil
 }
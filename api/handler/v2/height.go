@@ -3,6 +3,7 @@
 package v2
 
 import (
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
 	"github.com/valyala/fasthttp"
 )
 
@@ -18,9 +19,9 @@ type HeightResponse struct {
 //	 "height": "13281459"
 //	}
 func (h *Handler) Height(ctx *fasthttp.RequestCtx) {
-	data, err := h.numiaRPCClient.QueryHeight()
+	data, err := h.numiaRPCClient.QueryHeight(ctx)
 	if err != nil {
-		ctx.Logger().Printf("Error querying height: %s", err.Error())
+		logging.FromContext(ctx).Error("Error querying height", "error", err)
 		sendInternalErrorResponse(ctx)
 		return
 	}
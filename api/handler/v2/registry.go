@@ -0,0 +1,65 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package v2
+
+import (
+	"github.com/tharsis/dashboard-backend/internal/v1/resources"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/valyala/fasthttp"
+)
+
+type RegistryStatusResponse struct {
+	NetworkConfigs resources.RegistryLoad `json:"networkConfigs"`
+	Tokens         resources.RegistryLoad `json:"tokens"`
+	// Commits are the commits loaded by the GitHub webhook, by repository
+	Commits map[string]string `json:"commits"`
+}
+
+// RegistryStatus handles GET /v2/registry/status.
+// It returns the registry files of the last loads of the network configs and
+// the ERC20 tokens, with the reason the invalid files were skipped, and the
+// commits of the repositories loaded by the GitHub webhook.
+// Returns:
+//
+//	{
+//		"networkConfigs": {
+//		  "folder": "chainConfig",
+//		  "loadedAt": "2023-06-01T00:00:00Z",
+//		  "loaded": 1,
+//		  "skipped": 1,
+//		  "files": [
+//			{ "path": "chainConfig/evmos.json", "loaded": true },
+//			{ "path": "chainConfig/broken.json", "loaded": false, "error": "configurations cannot be empty" }
+//		  ]
+//		},
+//		"tokens": { ... },
+//		"commits": { "chain-token-registry": "6dcb09b5b57875f334f61aebed695e2e4193db5e" }
+//	}
+func (h *Handler) RegistryStatus(ctx *fasthttp.RequestCtx) {
+	networkConfigs, err := resources.GetRegistryLoad(h.store, resources.NetworkConfigsFolder)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error getting network configs status", "error", err)
+		sendInternalErrorResponse(ctx)
+		return
+	}
+	tokens, err := resources.GetRegistryLoad(h.store, resources.ERC20TokensFolder)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error getting tokens status", "error", err)
+		sendInternalErrorResponse(ctx)
+		return
+	}
+
+	commits, err := resources.GetRegistryCommits(h.store)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error getting registry commits", "error", err)
+		sendInternalErrorResponse(ctx)
+		return
+	}
+
+	sendSuccessfulJSONResponse(ctx, &RegistryStatusResponse{
+		NetworkConfigs: networkConfigs,
+		Tokens:         tokens,
+		Commits:        commits,
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
 	"github.com/valyala/fasthttp"
 )
 
@@ -61,7 +62,7 @@ func sendErrorJSONResponse(ctx *fasthttp.RequestCtx, message string) {
 func sendJSONResponse(ctx *fasthttp.RequestCtx, response interface{}) {
 	jsonResponse, err := json.Marshal(response)
 	if err != nil {
-		ctx.Logger().Printf("Error encoding response: %s", err.Error())
+		logging.FromContext(ctx).Error("Error encoding response", "error", err)
 		sendInternalErrorResponse(ctx)
 		return
 	}
@@ -3,7 +3,10 @@
 
 package v2
 
-import "github.com/valyala/fasthttp"
+import (
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/valyala/fasthttp"
+)
 
 // RewardsByAddress handles GET /v2/rewards/{address}.
 // It returns the rewards of the requested address.
@@ -27,9 +30,9 @@ func (h *Handler) RewardsByAddress(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	rewards, err := h.numiaRPCClient.QueryRewards(address)
+	rewards, err := h.numiaRPCClient.QueryRewards(ctx, address)
 	if err != nil {
-		ctx.Logger().Printf("Error querying rewards from Numia: %s", err.Error())
+		logging.FromContext(ctx).Error("Error querying rewards from Numia", "error", err)
 		sendInternalErrorResponse(ctx)
 		return
 	}
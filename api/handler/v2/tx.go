@@ -5,7 +5,9 @@ import (
 	"fmt"
 
 	"github.com/tharsis/dashboard-backend/internal/v2/encoding"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
 	"github.com/tharsis/dashboard-backend/internal/v2/node/rest"
+	"github.com/tharsis/dashboard-backend/internal/v2/openapi"
 
 	"github.com/cosmos/cosmos-sdk/simapp/params"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -17,11 +19,15 @@ import (
 // BroadcastTxParams represents the parameters for the POST /v2/tx/broadcast endpoint.
 type BroadcastTxParams struct {
 	// which network should the transaction be broadcasted to
-	Network string `json:"network"`
+	Network string `json:"network" openapi:"required"`
 	// the signed transaction to be broadcasted
-	TxBytes []byte `json:"tx_bytes"`
+	TxBytes []byte `json:"tx_bytes" openapi:"required"`
 }
 
+// broadcastTxValidator validates the body of POST /v2/tx/broadcast against
+// the schema published in the OpenAPI document.
+var broadcastTxValidator = openapi.NewValidator(BroadcastTxParams{})
+
 // BroadcastTxResponse represents the response for the POST /v2/tx/broadcast endpoint.
 type BroadcastTxResponse struct {
 	Code   uint32 `json:"code"`
@@ -37,6 +43,26 @@ type BroadcastAminoTxParams struct {
 	Signature legacytx.StdSignature `json:"signature"` //nolint:staticcheck
 }
 
+// nonEmpty is the minimum length of the required strings.
+var nonEmpty = 1
+
+// BroadcastAminoTxSchema is the schema of the body of POST /v2/tx/amino/broadcast
+// published in the OpenAPI document. It is written by hand because the amino
+// JSON encoding differs from encoding/json, e.g. integers are strings.
+var BroadcastAminoTxSchema = &openapi.Schema{
+	Type: "object",
+	Properties: map[string]*openapi.Schema{
+		"network":   {Type: "string", MinLength: &nonEmpty},
+		"signed":    {Type: "object", Description: "Amino JSON encoded StdSignDoc"},
+		"signature": {Type: "object", Description: "Amino JSON encoded StdSignature"},
+	},
+	Required: []string{"network", "signed", "signature"},
+}
+
+// broadcastAminoTxValidator validates the body of POST /v2/tx/amino/broadcast
+// against BroadcastAminoTxSchema.
+var broadcastAminoTxValidator = openapi.NewSchemaValidator(BroadcastAminoTxSchema)
+
 // BroadcastTxResponse represents the response for the POST /v2/tx/amion/broadcast endpoint.
 type BroadcastAminoTxResponse struct {
 	Code   uint32 `json:"code"`
@@ -54,9 +80,14 @@ type BroadcastAminoTxResponse struct {
 //	  "raw_log": "[]",
 //	}
 func (h *Handler) BroadcastTx(ctx *fasthttp.RequestCtx) {
+	if err := broadcastTxValidator.Validate(ctx.PostBody()); err != nil {
+		sendBadRequestResponse(ctx, err.Error())
+		return
+	}
+
 	reqParams := BroadcastTxParams{}
 	if err := json.Unmarshal(ctx.PostBody(), &reqParams); err != nil {
-		ctx.Logger().Printf("Error decoding request body: %s", err.Error())
+		logging.FromContext(ctx).Warn("Error decoding request body", "error", err)
 		sendBadRequestResponse(ctx, "Invalid request body")
 		return
 	}
@@ -68,7 +99,7 @@ func (h *Handler) BroadcastTx(ctx *fasthttp.RequestCtx) {
 
 	jsonTxRequest, err := json.Marshal(txRequest)
 	if err != nil {
-		ctx.Logger().Printf("Error marshaling txRequest: %s", err.Error())
+		logging.FromContext(ctx).Error("Error marshaling txRequest", "error", err)
 		sendInternalErrorResponse(ctx)
 		return
 	}
@@ -79,16 +110,10 @@ func (h *Handler) BroadcastTx(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	restClient, err := rest.NewClient(reqParams.Network)
-	if err != nil {
-		ctx.Logger().Printf("Error creating rest client: %s", err.Error())
-		sendInternalErrorResponse(ctx)
-		return
-	}
-
-	txResponse, err := restClient.BroadcastTx(jsonTxRequest)
+	restClient := rest.NewClient(h.store, reqParams.Network)
+	txResponse, err := restClient.BroadcastTx(ctx, jsonTxRequest)
 	if err != nil {
-		ctx.Logger().Printf("Error broadcasting tx: %s", err.Error())
+		logging.FromContext(ctx).Error("Error broadcasting tx", "error", err)
 		sendInternalErrorResponse(ctx)
 		return
 	}
@@ -125,19 +150,24 @@ func ValidateBroadcastTxParams(params *BroadcastTxParams) error {
 //	  "raw_log": "[]",
 //	}
 func (h *Handler) BroadcastAminoTx(ctx *fasthttp.RequestCtx) {
+	if err := broadcastAminoTxValidator.Validate(ctx.PostBody()); err != nil {
+		sendBadRequestResponse(ctx, err.Error())
+		return
+	}
+
 	protoCfg := encoding.MakeEncodingConfig()
 	aminoCodec := protoCfg.Amino
 
 	reqParams := BroadcastAminoTxParams{}
 	if err := aminoCodec.Amino.UnmarshalJSON(ctx.PostBody(), &reqParams); err != nil {
-		ctx.Logger().Printf("Error decoding request body: %s", err.Error())
+		logging.FromContext(ctx).Warn("Error decoding request body", "error", err)
 		sendBadRequestResponse(ctx, "Invalid request body")
 		return
 	}
 
 	txBytes, err := EncodeLegacyTransaction(&protoCfg, reqParams.Signed, reqParams.Signature)
 	if err != nil {
-		ctx.Logger().Printf("Error generating tx bytes: %s", err.Error())
+		logging.FromContext(ctx).Error("Error generating tx bytes", "error", err)
 		sendInternalErrorResponse(ctx)
 		return
 	}
@@ -149,21 +179,15 @@ func (h *Handler) BroadcastAminoTx(ctx *fasthttp.RequestCtx) {
 
 	jsonTxRequest, err := json.Marshal(txRequest)
 	if err != nil {
-		ctx.Logger().Printf("Error marshaling txRequest: %s", err.Error())
-		sendInternalErrorResponse(ctx)
-		return
-	}
-
-	restClient, err := rest.NewClient(reqParams.Network)
-	if err != nil {
-		ctx.Logger().Printf("Error creating rest client: %s", err.Error())
+		logging.FromContext(ctx).Error("Error marshaling txRequest", "error", err)
 		sendInternalErrorResponse(ctx)
 		return
 	}
 
-	txResponse, err := restClient.BroadcastTx(jsonTxRequest)
+	restClient := rest.NewClient(h.store, reqParams.Network)
+	txResponse, err := restClient.BroadcastTx(ctx, jsonTxRequest)
 	if err != nil {
-		ctx.Logger().Printf("Error broadcasting tx: %s", err.Error())
+		logging.FromContext(ctx).Error("Error broadcasting tx", "error", err)
 		sendInternalErrorResponse(ctx)
 		return
 	}
@@ -0,0 +1,43 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package v2
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/valyala/fasthttp"
+)
+
+func TestBroadcastRejectsInvalidBodies(t *testing.T) {
+	h := &Handler{store: db.NewMemoryStore(100)}
+
+	testCases := []struct {
+		name    string
+		handler fasthttp.RequestHandler
+		body    string
+		message string
+	}{
+		{"tx without network", h.BroadcastTx, `{"tx_bytes":"AQID"}`, "network is required"},
+		{"tx with invalid bytes", h.BroadcastTx, `{"network":"evmos","tx_bytes":"not base64"}`, "tx_bytes must be base64 encoded"},
+		{"amino tx without signature", h.BroadcastAminoTx, `{"network":"evmos","signed":{}}`, "signature is required"},
+		{"amino tx with empty network", h.BroadcastAminoTx, `{"network":"","signed":{},"signature":{}}`, "network cannot be empty"},
+		{"amino tx with invalid signed", h.BroadcastAminoTx, `{"network":"evmos","signed":"tx","signature":{}}`, "signed must be of type object, got string"},
+		{"amino tx with invalid JSON", h.BroadcastAminoTx, `{"network":`, "invalid JSON"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := &fasthttp.RequestCtx{}
+			ctx.Request.Header.SetMethod(http.MethodPost)
+			ctx.Request.SetBodyString(tc.body)
+			tc.handler(ctx)
+			if status := ctx.Response.StatusCode(); status != http.StatusBadRequest || !strings.Contains(string(ctx.Response.Body()), tc.message) {
+				t.Fatalf("expected a bad request with %q, got %d %s", tc.message, status, ctx.Response.Body())
+			}
+		})
+	}
+}
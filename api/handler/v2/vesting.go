@@ -4,6 +4,7 @@
 package v2
 
 import (
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
 	"github.com/tharsis/dashboard-backend/internal/v2/node/rest"
 	"github.com/valyala/fasthttp"
 )
@@ -81,16 +82,10 @@ func (h *Handler) VestingByAddress(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	restClient, err := rest.NewClient("evmos")
+	restClient := rest.NewClient(h.store, "evmos")
+	res, err := restClient.GetVestingAccount(ctx, address)
 	if err != nil {
-		ctx.Logger().Printf("Error creating rest client: %s", err.Error())
-		sendInternalErrorResponse(ctx)
-		return
-	}
-
-	res, err := restClient.GetVestingAccount(address)
-	if err != nil {
-		ctx.Logger().Printf("Error getting vesting account: %s", err.Error())
+		logging.FromContext(ctx).Error("Error getting vesting account", "error", err)
 		sendInternalErrorResponse(ctx)
 		return
 	}
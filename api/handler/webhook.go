@@ -0,0 +1,134 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/requester"
+	"github.com/tharsis/dashboard-backend/internal/v1/resources"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/valyala/fasthttp"
+)
+
+// Statuses of the GitHub webhook responses.
+const (
+	webhookPong      = "pong"
+	webhookIgnored   = "ignored"
+	webhookReloading = "reloading"
+)
+
+// GithubWebhookResponse represents the response for the POST /webhooks/github endpoint.
+type GithubWebhookResponse struct {
+	// pong, ignored or reloading
+	Status     string `json:"status"`
+	Repository string `json:"repository,omitempty"`
+	Commit     string `json:"commit,omitempty"`
+}
+
+// githubPushEvent is the payload of the GitHub push events.
+type githubPushEvent struct {
+	Ref     string `json:"ref"`
+	After   string `json:"after"`
+	Deleted bool   `json:"deleted"`
+	// Repository is the repository the commits were pushed to
+	Repository struct {
+		Name     string `json:"name"`
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// GithubWebhook handles POST /webhooks/github.
+// It verifies the X-Hub-Signature-256 HMAC of the body with the webhook secret.
+// On a push to the served branch of the chain-token-registry or the
+// validator-directory, it purges the cache of the repository and reloads it in
+// the background, the loaded commit is then listed by /v2/registry/status.
+// The pushes are ignored when the registry is read from local copies.
+// The route is disabled when no webhook secret is configured.
+func (h *Handler) GithubWebhook(ctx *fasthttp.RequestCtx) {
+	secret := h.cfg.Registry.WebhookSecret
+	if secret == "" {
+		sendJSON(ctx, http.StatusNotFound, adminErrorResponse{Error: "Not found"})
+		return
+	}
+	if !validGithubSignature(secret, ctx.PostBody(), string(ctx.Request.Header.Peek("X-Hub-Signature-256"))) {
+		sendJSON(ctx, http.StatusUnauthorized, adminErrorResponse{Error: "Invalid signature"})
+		return
+	}
+
+	switch string(ctx.Request.Header.Peek("X-GitHub-Event")) {
+	case "ping":
+		sendJSON(ctx, http.StatusOK, GithubWebhookResponse{Status: webhookPong})
+		return
+	case "push":
+	default:
+		sendJSON(ctx, http.StatusOK, GithubWebhookResponse{Status: webhookIgnored})
+		return
+	}
+
+	var event githubPushEvent
+	if err := json.Unmarshal(ctx.PostBody(), &event); err != nil {
+		sendJSON(ctx, http.StatusBadRequest, adminErrorResponse{Error: "Invalid request body"})
+		return
+	}
+	repo := requester.Repository(event.Repository.Name)
+	if repo != requester.ChainTokenRegistry && repo != requester.ValidatorDirectory ||
+		event.Repository.FullName != "evmos/"+event.Repository.Name ||
+		event.Ref != "refs/heads/"+requester.Branch(repo) || event.Deleted {
+		sendJSON(ctx, http.StatusOK, GithubWebhookResponse{Status: webhookIgnored, Repository: event.Repository.FullName})
+		return
+	}
+	if !requester.RegistryFromGithub() {
+		// The local copies are not at the pushed commit
+		sendJSON(ctx, http.StatusOK, GithubWebhookResponse{Status: webhookIgnored, Repository: event.Repository.FullName})
+		return
+	}
+
+	// GitHub gives up on the deliveries after 10 seconds, loading every file takes longer
+	h.registryReloads.Add(1)
+	go func() {
+		defer h.registryReloads.Done()
+		h.reloadRegistry(repo, event.After)
+	}()
+	sendJSON(ctx, http.StatusAccepted, GithubWebhookResponse{Status: webhookReloading, Repository: string(repo), Commit: event.After})
+}
+
+// reloadRegistry reloads the repository, one reload at a time. Every reload
+// loads the head of the branch, but the concurrent pushes are not ordered, so
+// the recorded commit is the one of the last reload to run, not necessarily
+// the one of the last push.
+func (h *Handler) reloadRegistry(repo requester.Repository, commit string) {
+	h.registryReloadMu.Lock()
+	defer h.registryReloadMu.Unlock()
+
+	logger := logging.Default().With("repository", string(repo), "commit", commit)
+	if err := resources.ReloadRegistry(h.store, repo, commit); err != nil {
+		logger.Error("Error reloading the registry", "error", err)
+		return
+	}
+	logger.Info("Registry reloaded")
+}
+
+// WaitRegistryReloads blocks until the registry reloads started by the
+// webhook are done, the server waits for them on shutdown.
+func (h *Handler) WaitRegistryReloads() {
+	h.registryReloads.Wait()
+}
+
+// validGithubSignature reports whether signature, the X-Hub-Signature-256
+// header, is the HMAC-SHA256 of body with secret.
+func validGithubSignature(secret string, body []byte, signature string) bool {
+	sig, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil || !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(sig, mac.Sum(nil))
+}
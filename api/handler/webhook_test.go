@@ -0,0 +1,157 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tharsis/dashboard-backend/api/config"
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v1/requester"
+	"github.com/tharsis/dashboard-backend/internal/v1/resources"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	testWebhookSecret = "webhook-secret"
+	testCommit        = "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+)
+
+func newWebhookRequest(event string, body string, secret string) *fasthttp.RequestCtx {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetMethod(http.MethodPost)
+	ctx.Request.Header.Set("X-GitHub-Event", event)
+	ctx.Request.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	ctx.Request.SetBody([]byte(body))
+	return ctx
+}
+
+func pushEvent(repo string, ref string) string {
+	return `{"ref":"` + ref + `","after":"` + testCommit + `","deleted":false,` +
+		`"repository":{"name":"` + repo + `","full_name":"evmos/` + repo + `"}}`
+}
+
+func TestReloadRegistry(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "")
+	files := map[string]string{
+		"chainConfig/evmos.json": `{"prefix":"evmos","configurations":[{"chainId":"evmos_9001-2","identifier":"evmos","configurationType":"mainnet"}]}`,
+		"tokens/evmos.json": `{"coinDenom":"EVMOS","exponent":"18","cosmosDenom":"aevmos","erc20Address":"0xD4949664cD82660AaE99bEdc034a0deA8A0bd517",` +
+			`"coingeckoId":"evmos","coinSourcePrefix":"evmos"}`,
+	}
+	root := t.TempDir()
+	for path, content := range files {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	requester.SetRegistrySource(requester.LocalSource{Dirs: map[requester.Repository]string{requester.ChainTokenRegistry: root}})
+	t.Cleanup(func() { requester.SetRegistrySource(requester.GithubSource{}) })
+
+	// Stale registry cached before the push
+	store := db.NewMemoryStore(100)
+	staleURL := requester.GithubAPIURL(requester.ChainTokenRegistry) + "git/trees/main?recursive=1"
+	if err := db.RedisSetGithubResponse(store, staleURL, `{"tree":[]}`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.RedisGetOrComputeNetworkConfig(store, func() (string, error) { return `[{"prefix":"stale"}]`, nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	h := &Handler{cfg: &config.Config{}, store: store}
+	h.cfg.Registry.WebhookSecret = testWebhookSecret
+
+	// The pushes do not apply to the local copies
+	ctx := newWebhookRequest("push", pushEvent("chain-token-registry", "refs/heads/main"), testWebhookSecret)
+	h.GithubWebhook(ctx)
+	var resp GithubWebhookResponse
+	if err := json.Unmarshal(ctx.Response.Body(), &resp); err != nil || ctx.Response.StatusCode() != http.StatusOK || resp.Status != webhookIgnored {
+		t.Fatalf("expected the push to be ignored with the local source, got %d: %s", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+	h.WaitRegistryReloads()
+	if _, err := db.RedisGetGithubResponse(store, staleURL); err != nil {
+		t.Fatalf("expected the github cache to be kept, got %v", err)
+	}
+
+	h.reloadRegistry(requester.ChainTokenRegistry, testCommit)
+
+	if _, err := db.RedisGetGithubResponse(store, staleURL); err == nil {
+		t.Fatalf("expected the github cache of the repository to be purged")
+	}
+	configs, err := resources.GetNetworkConfigs(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 1 || configs[0].Prefix != "evmos" {
+		t.Fatalf("expected the network configs to be reloaded, got %+v", configs)
+	}
+	commits, err := resources.GetRegistryCommits(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commits["chain-token-registry"] != testCommit {
+		t.Fatalf("expected the commit to be recorded, got %v", commits)
+	}
+}
+
+func TestGithubWebhookRejectsRequests(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "")
+	h := &Handler{cfg: &config.Config{}, store: db.NewMemoryStore(100)}
+
+	push := pushEvent("chain-token-registry", "refs/heads/main")
+	ctx := newWebhookRequest("push", push, testWebhookSecret)
+	h.GithubWebhook(ctx)
+	if ctx.Response.StatusCode() != http.StatusNotFound {
+		t.Fatalf("expected the webhook to be disabled without secret, got %d", ctx.Response.StatusCode())
+	}
+
+	h.cfg.Registry.WebhookSecret = testWebhookSecret
+	tests := []struct {
+		name   string
+		ctx    *fasthttp.RequestCtx
+		status int
+		want   string
+	}{
+		{"wrong secret", newWebhookRequest("push", push, "other"), http.StatusUnauthorized, ""},
+		{"ping", newWebhookRequest("ping", `{"zen":"Keep it logically awesome."}`, testWebhookSecret), http.StatusOK, webhookPong},
+		{"other event", newWebhookRequest("issues", `{}`, testWebhookSecret), http.StatusOK, webhookIgnored},
+		{"other branch", newWebhookRequest("push", pushEvent("chain-token-registry", "refs/heads/feature"), testWebhookSecret), http.StatusOK, webhookIgnored},
+		{"other repository", newWebhookRequest("push", pushEvent("evmos", "refs/heads/main"), testWebhookSecret), http.StatusOK, webhookIgnored},
+		{"invalid body", newWebhookRequest("push", `{`, testWebhookSecret), http.StatusBadRequest, ""},
+	}
+	for _, tc := range tests {
+		h.GithubWebhook(tc.ctx)
+		if tc.ctx.Response.StatusCode() != tc.status {
+			t.Fatalf("%s: expected status %d, got %d", tc.name, tc.status, tc.ctx.Response.StatusCode())
+		}
+		if tc.want == "" {
+			continue
+		}
+		var resp GithubWebhookResponse
+		if err := json.Unmarshal(tc.ctx.Response.Body(), &resp); err != nil || resp.Status != tc.want {
+			t.Fatalf("%s: expected status %q, got %s", tc.name, tc.want, tc.ctx.Response.Body())
+		}
+	}
+
+	// Tampered body with the signature of the original one
+	ctx = newWebhookRequest("push", push, testWebhookSecret)
+	ctx.Request.SetBody([]byte(pushEvent("validator-directory", "refs/heads/main")))
+	h.GithubWebhook(ctx)
+	if ctx.Response.StatusCode() != http.StatusUnauthorized {
+		t.Fatalf("expected a tampered body to be rejected, got %d", ctx.Response.StatusCode())
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package middleware
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v2/apikeys"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/valyala/fasthttp"
+)
+
+// KeyStore validates the API keys and counts their usage.
+type KeyStore interface {
+	Lookup(secret string) (apikeys.Key, error)
+	IncrementUsage(id string, now time.Time) (int64, error)
+}
+
+// APIKeyAuth identifies the clients sending an API key and enforces their daily quota.
+// Requests without an API key are served anonymously.
+type APIKeyAuth struct {
+	header string
+	store  KeyStore
+}
+
+// NewAPIKeyAuth creates the API key middleware reading the key from the given header.
+func NewAPIKeyAuth(header string, store KeyStore) *APIKeyAuth {
+	return &APIKeyAuth{
+		header: header,
+		store:  store,
+	}
+}
+
+// Middleware returns the middleware that rejects invalid or revoked API keys with a 401
+// status code and the requests over the daily quota with a 429 status code.
+// Valid keys are stored in the request under APIKeyUserValue.
+func (a *APIKeyAuth) Middleware(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		secret := string(ctx.Request.Header.Peek(a.header))
+		if secret == "" {
+			next(ctx)
+			return
+		}
+
+		key, err := a.store.Lookup(secret)
+		if errors.Is(err, apikeys.ErrNotFound) || errors.Is(err, apikeys.ErrRevoked) {
+			sendError(ctx, http.StatusUnauthorized, "Invalid API key")
+			return
+		}
+		if err != nil {
+			// Keep serving the request as anonymous if the keys can not be read
+			logging.FromContext(ctx).Error("Error looking up API key", "error", err)
+			next(ctx)
+			return
+		}
+
+		now := time.Now()
+		usage, err := a.store.IncrementUsage(key.ID, now)
+		if err != nil {
+			logging.FromContext(ctx).Error("Error counting API key usage", "error", err)
+		} else if key.DailyQuota > 0 && usage > key.DailyQuota {
+			seconds := int(math.Ceil(apikeys.UntilQuotaReset(now).Seconds()))
+			ctx.Response.Header.Set("Retry-After", strconv.Itoa(seconds))
+			sendError(ctx, http.StatusTooManyRequests, "Daily quota exceeded")
+			return
+		}
+
+		ctx.SetUserValue(APIKeyUserValue, &key)
+		next(ctx)
+	}
+}
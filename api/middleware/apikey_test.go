@@ -0,0 +1,130 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package middleware
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v2/apikeys"
+	"github.com/valyala/fasthttp"
+)
+
+// memoryKeyStore keeps the keys by secret and counts their usage in memory.
+type memoryKeyStore struct {
+	keys  map[string]apikeys.Key
+	usage map[string]int64
+}
+
+func (s *memoryKeyStore) Lookup(secret string) (apikeys.Key, error) {
+	key, ok := s.keys[secret]
+	if !ok {
+		return apikeys.Key{}, apikeys.ErrNotFound
+	}
+	if key.Revoked() {
+		return apikeys.Key{}, apikeys.ErrRevoked
+	}
+	return key, nil
+}
+
+func (s *memoryKeyStore) IncrementUsage(id string, _ time.Time) (int64, error) {
+	s.usage[id]++
+	return s.usage[id], nil
+}
+
+func doKeyRequest(h fasthttp.RequestHandler, apiKey string) *fasthttp.RequestCtx {
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	req.SetRequestURI("/AllValidators")
+	req.Header.Set("X-Forwarded-For", "1.1.1.1")
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Init(req, nil, nil)
+	h(ctx)
+	return ctx
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	revokedAt := time.Now()
+	store := &memoryKeyStore{
+		keys: map[string]apikeys.Key{
+			"valid":   {ID: "1", DailyQuota: 2},
+			"revoked": {ID: "2", RevokedAt: &revokedAt},
+		},
+		usage: map[string]int64{},
+	}
+
+	var keyID string
+	next := func(ctx *fasthttp.RequestCtx) {
+		keyID = ""
+		if key, ok := ctx.UserValue(APIKeyUserValue).(*apikeys.Key); ok {
+			keyID = key.ID
+		}
+		ctx.SetStatusCode(http.StatusOK)
+	}
+	h := NewAPIKeyAuth("X-API-Key", store).Middleware(next)
+
+	if ctx := doKeyRequest(h, ""); ctx.Response.StatusCode() != http.StatusOK || keyID != "" {
+		t.Fatalf("Requests without API key must be served anonymously")
+	}
+	if ctx := doKeyRequest(h, "unknown"); ctx.Response.StatusCode() != http.StatusUnauthorized {
+		t.Fatalf("Unknown API keys must be rejected, got %d", ctx.Response.StatusCode())
+	}
+	if ctx := doKeyRequest(h, "revoked"); ctx.Response.StatusCode() != http.StatusUnauthorized {
+		t.Fatalf("Revoked API keys must be rejected, got %d", ctx.Response.StatusCode())
+	}
+
+	for i := 0; i < 2; i++ {
+		if ctx := doKeyRequest(h, "valid"); ctx.Response.StatusCode() != http.StatusOK || keyID != "1" {
+			t.Fatalf("Request %d within the quota must be allowed", i+1)
+		}
+	}
+	ctx := doKeyRequest(h, "valid")
+	if ctx.Response.StatusCode() != http.StatusTooManyRequests {
+		t.Fatalf("Requests over the daily quota must be rejected, got %d", ctx.Response.StatusCode())
+	}
+	if len(ctx.Response.Header.Peek("Retry-After")) == 0 {
+		t.Fatalf("Missing Retry-After header")
+	}
+}
+
+func TestRateLimiterPerAPIKey(t *testing.T) {
+	var burst int
+	limiter := limiterFunc(func(_ string, client string, _ float64, b int) (bool, time.Duration, error) {
+		if client != "key:1" {
+			t.Fatalf("Clients with an API key must be limited per key, got %s", client)
+		}
+		burst = b
+		return true, 0, nil
+	})
+	cfg := testRateLimitConfig()
+	cfg.AuthenticatedMultiplier = 5
+
+	next := func(ctx *fasthttp.RequestCtx) { ctx.SetStatusCode(http.StatusOK) }
+	withKey := func(key *apikeys.Key) fasthttp.RequestHandler {
+		return func(ctx *fasthttp.RequestCtx) {
+			ctx.SetUserValue(APIKeyUserValue, key)
+			NewRateLimiter(cfg, limiter).Middleware(next)(ctx)
+		}
+	}
+
+	doRequest(withKey(&apikeys.Key{ID: "1"}), "/AllValidators", "1.1.1.1")
+	if burst != 50 {
+		t.Fatalf("Expected the authenticated multiplier to be applied, got burst %d", burst)
+	}
+	doRequest(withKey(&apikeys.Key{ID: "1", RateMultiplier: 2}), "/AllValidators", "1.1.1.1")
+	if burst != 20 {
+		t.Fatalf("Expected the key multiplier to be applied, got burst %d", burst)
+	}
+}
+
+type limiterFunc func(policy string, client string, rate float64, burst int) (bool, time.Duration, error)
+
+func (f limiterFunc) Take(policy string, client string, rate float64, burst int) (bool, time.Duration, error) {
+	return f(policy, client, rate, burst)
+}
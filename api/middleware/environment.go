@@ -0,0 +1,60 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/constants"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/tharsis/dashboard-backend/internal/v2/requestctx"
+	"github.com/valyala/fasthttp"
+)
+
+// EnvironmentHeader is the header selecting the environment of a request.
+const EnvironmentHeader = "X-Environment"
+
+// Environment selects the environment the request is served from, e.g. the
+// testnet nodes, chain IDs and explorer URLs, see requestctx.Environment.
+// The environment is given with a path prefix, e.g. /testnet/v2/height, which
+// is removed before routing, or with the X-Environment header. Mainnet is
+// served by default and unknown environments are rejected with a 400 status code.
+// It has to run before RequestContext so the request context carries it.
+func Environment(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		environment := strings.ToLower(string(ctx.Request.Header.Peek(EnvironmentHeader)))
+		path := string(ctx.Path())
+		for _, e := range constants.Environments {
+			if path == "/"+e || strings.HasPrefix(path, "/"+e+"/") {
+				environment = e
+				ctx.URI().SetPath(strings.TrimPrefix(path, "/"+e))
+				break
+			}
+		}
+		if environment == "" {
+			environment = constants.Mainnet
+		}
+		if !isEnvironment(environment) {
+			sendError(ctx, http.StatusBadRequest, "Invalid environment, expected one of "+strings.Join(constants.Environments, ", "))
+			return
+		}
+
+		ctx.SetUserValue(requestctx.EnvironmentValue, environment)
+		ctx.Response.Header.Set(EnvironmentHeader, environment)
+		if environment != constants.Mainnet {
+			ctx.SetUserValue(logging.LoggerUserValue, logging.FromContext(ctx).With("environment", environment))
+		}
+		next(ctx)
+	}
+}
+
+func isEnvironment(environment string) bool {
+	for _, e := range constants.Environments {
+		if e == environment {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v2/requestctx"
+	"github.com/valyala/fasthttp"
+)
+
+func TestEnvironment(t *testing.T) {
+	var path, environment string
+	h := Chain(func(ctx *fasthttp.RequestCtx) {
+		path = string(ctx.Path())
+		// The environment reaches the upstream requests through the request context
+		environment = requestctx.Environment(requestctx.From(ctx))
+	}, Environment, RequestContext(time.Second))
+
+	testCases := []struct {
+		name        string
+		uri         string
+		header      string
+		status      int
+		path        string
+		environment string
+	}{
+		{"mainnet by default", "/v2/height", "", http.StatusOK, "/v2/height", "mainnet"},
+		{"header", "/v2/height", "Testnet", http.StatusOK, "/v2/height", "testnet"},
+		{"path prefix", "/testnet/v2/height?chain=evmos", "", http.StatusOK, "/v2/height", "testnet"},
+		{"path prefix over header", "/mainnet/v2/height", "testnet", http.StatusOK, "/v2/height", "mainnet"},
+		{"prefix of a segment", "/testnetv2/height", "", http.StatusOK, "/testnetv2/height", "mainnet"},
+		{"unknown environment", "/v2/height", "devnet", http.StatusBadRequest, "", ""},
+	}
+	for _, tc := range testCases {
+		path, environment = "", ""
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.SetRequestURI(tc.uri)
+		if tc.header != "" {
+			ctx.Request.Header.Set(EnvironmentHeader, tc.header)
+		}
+		h(ctx)
+
+		if ctx.Response.StatusCode() != tc.status || path != tc.path || environment != tc.environment {
+			t.Fatalf("%s: expected %d %q %q, got %d %q %q", tc.name, tc.status, tc.path, tc.environment,
+				ctx.Response.StatusCode(), path, environment)
+		}
+		if tc.environment != "" && string(ctx.Response.Header.Peek(EnvironmentHeader)) != tc.environment {
+			t.Fatalf("%s: expected the environment in the response", tc.name)
+		}
+	}
+
+	if env := requestctx.Environment(context.Background()); env != "mainnet" {
+		t.Fatalf("expected mainnet without environment, got %q", env)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+// Package middleware contains the request handlers wrapping the API router.
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/valyala/fasthttp"
+)
+
+// Middleware wraps a request handler with extra behavior.
+type Middleware func(next fasthttp.RequestHandler) fasthttp.RequestHandler
+
+// Chain wraps the handler with the given middlewares.
+// The first middleware is the outermost one, so it runs first.
+func Chain(h fasthttp.RequestHandler, middlewares ...Middleware) fasthttp.RequestHandler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
+
+// ClientIP returns the IP of the client that made the request.
+// Behind trustedProxies reverse proxies the client is the address appended to
+// X-Forwarded-For by the outermost trusted proxy, i.e. the trustedProxies-th
+// entry from the right, the entries on its left are set by the client and
+// can not be trusted. Without trusted proxies the connection address is used.
+func ClientIP(ctx *fasthttp.RequestCtx, trustedProxies int) string {
+	if trustedProxies > 0 {
+		hops := bytes.Split(ctx.Request.Header.Peek("X-Forwarded-For"), []byte(","))
+		if len(hops) >= trustedProxies {
+			if ip := bytes.TrimSpace(hops[len(hops)-trustedProxies]); len(ip) > 0 {
+				return string(ip)
+			}
+		}
+	}
+	return ctx.RemoteIP().String()
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// sendError writes a JSON error response with the same format as the v2 handlers.
+func sendError(ctx *fasthttp.RequestCtx, statusCode int, message string) {
+	jsonResponse, err := json.Marshal(errorResponse{Error: message})
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+	ctx.SetStatusCode(statusCode)
+	ctx.Response.Header.SetContentType("application/json")
+	ctx.SetBody(jsonResponse)
+}
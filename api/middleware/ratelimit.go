@@ -0,0 +1,159 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/api/config"
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v2/apikeys"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/valyala/fasthttp"
+)
+
+// APIKeyUserValue is the request user value holding the *apikeys.Key that
+// identifies the client. When it is set, the client is rate limited per
+// API key instead of per IP.
+const APIKeyUserValue = "apiKey"
+
+// Limiter takes a token from the bucket of a client for the given policy.
+type Limiter interface {
+	Take(policy string, client string, rate float64, burst int) (bool, time.Duration, error)
+}
+
+// StoreLimiter stores the token buckets in the db store. With the Redis store
+// the limits are shared by every API instance.
+type StoreLimiter struct {
+	Store db.Store
+}
+
+// Take implements Limiter.
+func (l StoreLimiter) Take(policy string, client string, rate float64, burst int) (bool, time.Duration, error) {
+	return db.RedisTakeToken(l.Store, policy, client, rate, burst)
+}
+
+type rateLimitPolicy struct {
+	config.RateLimitPolicy
+	routes routeGroup
+}
+
+// RateLimiter applies token-bucket limits to the requests
+// according to the policy of the route group they belong to.
+type RateLimiter struct {
+	limiter        Limiter
+	trustedProxies int
+	multiplier     float64
+	exempt         routeGroup
+	policies       []rateLimitPolicy
+	defaultPolicy  config.RateLimitPolicy
+}
+
+// NewRateLimiter creates a rate limiter from the configuration.
+// Policies are matched in order, the default policy applies to
+// every route that does not belong to any of them.
+func NewRateLimiter(cfg config.RateLimitConfig, limiter Limiter) *RateLimiter {
+	policies := make([]rateLimitPolicy, 0, len(cfg.Policies))
+	for _, p := range cfg.Policies {
+		policies = append(policies, rateLimitPolicy{
+			RateLimitPolicy: p,
+			routes:          newRouteGroup(p.Routes),
+		})
+	}
+
+	defaultPolicy := cfg.Default
+	if defaultPolicy.Name == "" {
+		defaultPolicy.Name = "default"
+	}
+
+	trustedProxies := 0
+	if cfg.TrustProxy {
+		trustedProxies = cfg.TrustedProxies
+		if trustedProxies < 1 {
+			trustedProxies = 1
+		}
+	}
+
+	return &RateLimiter{
+		limiter:        limiter,
+		trustedProxies: trustedProxies,
+		multiplier:     cfg.AuthenticatedMultiplier,
+		exempt:         newRouteGroup(cfg.Exempt),
+		policies:       policies,
+		defaultPolicy:  defaultPolicy,
+	}
+}
+
+// Middleware returns the middleware that rejects the requests over
+// the limit with a 429 status code and a Retry-After header.
+// If the limiter fails the request is allowed, the API must keep working
+// when Redis is not available.
+func (rl *RateLimiter) Middleware(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		path := string(ctx.Path())
+		if rl.exempt.match(path) {
+			next(ctx)
+			return
+		}
+
+		policy := rl.policyFor(path)
+		// A policy without rate is unlimited
+		if policy.Rate <= 0 || policy.Burst <= 0 {
+			next(ctx)
+			return
+		}
+
+		client, multiplier := rl.client(ctx)
+		rate := policy.Rate * multiplier
+		burst := int(math.Ceil(float64(policy.Burst) * multiplier))
+
+		allowed, retryAfter, err := rl.limiter.Take(policy.Name, client, rate, burst)
+		if err != nil {
+			logging.FromContext(ctx).Error("Error applying rate limit", "error", err)
+			next(ctx)
+			return
+		}
+
+		if !allowed {
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			if seconds < 1 {
+				seconds = 1
+			}
+			ctx.Response.Header.Set("Retry-After", strconv.Itoa(seconds))
+			sendError(ctx, http.StatusTooManyRequests, "Too many requests, please try again later")
+			return
+		}
+
+		next(ctx)
+	}
+}
+
+func (rl *RateLimiter) policyFor(path string) config.RateLimitPolicy {
+	for _, p := range rl.policies {
+		if p.routes.match(path) {
+			return p.RateLimitPolicy
+		}
+	}
+	return rl.defaultPolicy
+}
+
+// client returns the bucket key of the client and the factor applied to its limits.
+// Clients with an API key get the authenticated multiplier, or the one of their key,
+// anonymous clients are limited per IP with the configured policies.
+func (rl *RateLimiter) client(ctx *fasthttp.RequestCtx) (string, float64) {
+	if key, ok := ctx.UserValue(APIKeyUserValue).(*apikeys.Key); ok {
+		multiplier := rl.multiplier
+		if key.RateMultiplier > 0 {
+			multiplier = key.RateMultiplier
+		}
+		if multiplier <= 0 {
+			multiplier = 1
+		}
+		return "key:" + key.ID, multiplier
+	}
+	return "ip:" + ClientIP(ctx, rl.trustedProxies), 1
+}
@@ -0,0 +1,147 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/api/config"
+	"github.com/valyala/fasthttp"
+)
+
+// countingLimiter allows the first `limit` requests of every bucket.
+type countingLimiter struct {
+	limit int
+	taken map[string]int
+	err   error
+}
+
+func (l *countingLimiter) Take(policy string, client string, _ float64, _ int) (bool, time.Duration, error) {
+	if l.err != nil {
+		return false, 0, l.err
+	}
+	key := policy + "|" + client
+	l.taken[key]++
+	return l.taken[key] <= l.limit, 1500 * time.Millisecond, nil
+}
+
+func testRateLimitConfig() config.RateLimitConfig {
+	return config.RateLimitConfig{
+		Enabled:    true,
+		TrustProxy: true,
+		Exempt:     []string{"/metrics"},
+		Default:    config.RateLimitPolicy{Name: "default", Rate: 10, Burst: 10},
+		Policies: []config.RateLimitPolicy{
+			{Name: "erc20", Routes: []string{"/ERC20ModuleBalance/{evmos_address}/{eth_address}"}, Rate: 1, Burst: 1},
+		},
+	}
+}
+
+func doRequest(h fasthttp.RequestHandler, path string, ip string) *fasthttp.RequestCtx {
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	req.SetRequestURI(path)
+	req.Header.Set("X-Forwarded-For", ip)
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Init(req, nil, nil)
+	h(ctx)
+	return ctx
+}
+
+func TestRouteGroupMatch(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"/AllValidators", "/AllValidators", true},
+		{"/AllValidators", "/AllValidators/extra", false},
+		{"/ERC20ModuleBalance/{evmos_address}/{eth_address}", "/ERC20ModuleBalance/evmos1/0x1", true},
+		{"/ERC20ModuleBalance/{evmos_address}/{eth_address}", "/ERC20ModuleBalance/evmos1", false},
+		{"/ERC20ModuleBalance/{evmos_address}/{eth_address}", "/ERC20ModuleBalance//0x1", false},
+		{"/BalanceByDenom/{chain}/{address}/{denom:*}", "/BalanceByDenom/EVMOS/evmos1/ibc/ABC", true},
+	}
+
+	for _, tc := range testCases {
+		if got := newRouteGroup([]string{tc.pattern}).match(tc.path); got != tc.match {
+			t.Fatalf("pattern %s and path %s: expected %v, got %v", tc.pattern, tc.path, tc.match, got)
+		}
+	}
+}
+
+func TestRateLimiterMiddleware(t *testing.T) {
+	limiter := &countingLimiter{limit: 1, taken: map[string]int{}}
+	next := func(ctx *fasthttp.RequestCtx) { ctx.SetStatusCode(http.StatusOK) }
+	h := NewRateLimiter(testRateLimitConfig(), limiter).Middleware(next)
+
+	path := "/ERC20ModuleBalance/evmos1/0x1"
+	if ctx := doRequest(h, path, "1.1.1.1"); ctx.Response.StatusCode() != http.StatusOK {
+		t.Fatalf("First request must be allowed")
+	}
+
+	ctx := doRequest(h, path, "1.1.1.1")
+	if ctx.Response.StatusCode() != http.StatusTooManyRequests {
+		t.Fatalf("Second request must be rate limited, got %d", ctx.Response.StatusCode())
+	}
+	if retryAfter := string(ctx.Response.Header.Peek("Retry-After")); retryAfter != "2" {
+		t.Fatalf("Invalid Retry-After header %q", retryAfter)
+	}
+
+	// Other clients and route groups have their own buckets
+	if ctx := doRequest(h, path, "2.2.2.2"); ctx.Response.StatusCode() != http.StatusOK {
+		t.Fatalf("Requests from other clients must be allowed")
+	}
+	if ctx := doRequest(h, "/AllValidators", "1.1.1.1"); ctx.Response.StatusCode() != http.StatusOK {
+		t.Fatalf("Requests to other route groups must be allowed")
+	}
+
+	// Exempt routes are never limited
+	for i := 0; i < 3; i++ {
+		if ctx := doRequest(h, "/metrics", "1.1.1.1"); ctx.Response.StatusCode() != http.StatusOK {
+			t.Fatalf("Exempt routes must not be rate limited")
+		}
+	}
+}
+
+func TestRateLimiterFailsOpen(t *testing.T) {
+	limiter := &countingLimiter{err: fmt.Errorf("redis is down")}
+	next := func(ctx *fasthttp.RequestCtx) { ctx.SetStatusCode(http.StatusOK) }
+	h := NewRateLimiter(testRateLimitConfig(), limiter).Middleware(next)
+
+	if ctx := doRequest(h, "/AllValidators", "1.1.1.1"); ctx.Response.StatusCode() != http.StatusOK {
+		t.Fatalf("Requests must be allowed when the limiter fails")
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	testCases := []struct {
+		forwarded      string
+		trustedProxies int
+		ip             string
+	}{
+		// The connection address is used without trusted proxies
+		{"1.1.1.1", 0, "0.0.0.0"},
+		{"", 1, "0.0.0.0"},
+		{"1.1.1.1", 1, "1.1.1.1"},
+		// The client can not spoof the entries appended by the trusted proxies
+		{"9.9.9.9, 1.1.1.1", 1, "1.1.1.1"},
+		{"9.9.9.9, 1.1.1.1, 10.0.0.1", 2, "1.1.1.1"},
+		{"1.1.1.1", 2, "0.0.0.0"},
+	}
+
+	for _, tc := range testCases {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.Header.Set("X-Real-IP", "8.8.8.8")
+		if tc.forwarded != "" {
+			ctx.Request.Header.Set("X-Forwarded-For", tc.forwarded)
+		}
+		if ip := ClientIP(ctx, tc.trustedProxies); ip != tc.ip {
+			t.Fatalf("X-Forwarded-For %q behind %d proxies: expected %s, got %s", tc.forwarded, tc.trustedProxies, tc.ip, ip)
+		}
+	}
+}
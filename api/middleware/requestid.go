@@ -0,0 +1,77 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/fasthttp/router"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/tharsis/dashboard-backend/internal/v2/requestctx"
+	"github.com/valyala/fasthttp"
+)
+
+// requestIDRegex restricts the request IDs accepted from the clients so they are safe to log.
+var requestIDRegex = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestLogger assigns an ID to every request and stores a logger tagged with it
+// in the request, see logging.FromContext. The ID sent by the client or the proxy
+// in the X-Request-ID header is reused when valid, and echoed in the response.
+// Every request is logged once completed.
+func RequestLogger(base *logging.Logger) Middleware {
+	return func(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+		return func(ctx *fasthttp.RequestCtx) {
+			start := time.Now()
+
+			requestID := string(ctx.Request.Header.Peek(logging.RequestIDHeader))
+			if !requestIDRegex.MatchString(requestID) {
+				requestID = newRequestID()
+			}
+			ctx.Response.Header.Set(logging.RequestIDHeader, requestID)
+
+			logger := base.With(
+				"request_id", requestID,
+				"method", string(ctx.Method()),
+				"path", string(ctx.Path()),
+			)
+			ctx.SetUserValue(logging.RequestIDUserValue, requestID)
+			ctx.SetUserValue(logging.LoggerUserValue, logger)
+
+			next(ctx)
+
+			route, _ := ctx.UserValue(router.MatchedRoutePathParam).(string)
+			logger.Info("Request completed",
+				"route", route,
+				"status", ctx.Response.StatusCode(),
+				"duration_ms", float64(time.Since(start).Microseconds())/1000,
+			)
+		}
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(b)
+}
+
+// RequestContext attaches to every request a context canceled once the request
+// is served or the timeout elapses, see requestctx.From. The upstream requests
+// sent on behalf of the request use it so they do not outlive it.
+// It has to run after RequestLogger to carry the request ID and logger.
+func RequestContext(timeout time.Duration) Middleware {
+	return func(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+		return func(ctx *fasthttp.RequestCtx) {
+			cancel := requestctx.Attach(ctx, timeout)
+			defer cancel()
+			next(ctx)
+		}
+	}
+}
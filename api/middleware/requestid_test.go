@@ -0,0 +1,97 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/tharsis/dashboard-backend/internal/v2/requestctx"
+	"github.com/valyala/fasthttp"
+)
+
+func TestRequestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	var handlerID string
+	h := RequestLogger(logging.New(&buf, logging.LevelInfo))(func(ctx *fasthttp.RequestCtx) {
+		handlerID = logging.RequestID(ctx)
+		logging.FromContext(ctx).Info("In handler")
+	})
+
+	testCases := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{"client ID is reused", "abc-123", "abc-123"},
+		{"missing ID is generated", "", ""},
+		{"invalid ID is replaced", "bad id\n", ""},
+	}
+
+	for _, tc := range testCases {
+		buf.Reset()
+		req := fasthttp.AcquireRequest()
+		req.SetRequestURI("/v2/height")
+		if tc.header != "" {
+			req.Header.Set(logging.RequestIDHeader, tc.header)
+		}
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Init(req, nil, nil)
+		h(ctx)
+		fasthttp.ReleaseRequest(req)
+
+		id := string(ctx.Response.Header.Peek(logging.RequestIDHeader))
+		if tc.expected != "" && id != tc.expected {
+			t.Fatalf("%s: expected request ID %q, got %q", tc.name, tc.expected, id)
+		}
+		if tc.expected == "" && (len(id) != 32 || id == tc.header) {
+			t.Fatalf("%s: expected a generated request ID, got %q", tc.name, id)
+		}
+		if handlerID != id {
+			t.Fatalf("%s: handler saw request ID %q, response has %q", tc.name, handlerID, id)
+		}
+		if n := strings.Count(buf.String(), `"request_id":"`+id+`"`); n != 2 {
+			t.Fatalf("%s: expected 2 entries with the request ID, got %d: %s", tc.name, n, buf.String())
+		}
+	}
+}
+
+func TestRequestContext(t *testing.T) {
+	var upstream context.Context
+	h := Chain(func(ctx *fasthttp.RequestCtx) {
+		upstream = requestctx.From(ctx)
+		if upstream.Err() != nil {
+			t.Errorf("expected the context to be alive while serving the request")
+		}
+		if _, ok := upstream.Deadline(); !ok {
+			t.Errorf("expected the context to have a deadline")
+		}
+	}, RequestLogger(logging.New(io.Discard, logging.LevelInfo)), RequestContext(time.Minute))
+
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	req.Header.Set(logging.RequestIDHeader, "abc-123")
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Init(req, nil, nil)
+	h(ctx)
+
+	if logging.RequestID(upstream) != "abc-123" {
+		t.Fatalf("expected the request ID in the context, got %q", logging.RequestID(upstream))
+	}
+	if upstream.Err() != context.Canceled {
+		t.Fatalf("expected the context to be canceled once the request is served, got %v", upstream.Err())
+	}
+
+	// Without the middleware the request scope is kept in a context that can be canceled
+	bare := &fasthttp.RequestCtx{}
+	bare.SetUserValue(logging.RequestIDUserValue, "def-456")
+	if c := requestctx.From(bare); logging.RequestID(c) != "def-456" || c.Done() != nil {
+		t.Fatalf("expected a detached context with the request ID")
+	}
+}
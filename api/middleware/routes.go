@@ -0,0 +1,73 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package middleware
+
+import (
+	"strings"
+)
+
+// routePattern matches request paths against a route declared with the
+// router syntax, e.g. /ERC20ModuleBalance/{evmos_address}/{eth_address}.
+// A {name} segment matches any single path segment and a trailing
+// {name:*} segment matches the rest of the path.
+type routePattern struct {
+	segments []string
+	catchAll bool
+}
+
+func parseRoutePattern(pattern string) routePattern {
+	segments := strings.Split(strings.Trim(pattern, "/"), "/")
+	p := routePattern{}
+	for i, s := range segments {
+		if i == len(segments)-1 && isParam(s) && strings.HasSuffix(s, ":*}") {
+			p.catchAll = true
+			break
+		}
+		p.segments = append(p.segments, s)
+	}
+	return p
+}
+
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+func (p routePattern) match(path string) bool {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < len(p.segments) || (!p.catchAll && len(segments) != len(p.segments)) {
+		return false
+	}
+	for i, s := range p.segments {
+		if isParam(s) {
+			if segments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if s != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// routeGroup is a list of route patterns.
+type routeGroup []routePattern
+
+func newRouteGroup(patterns []string) routeGroup {
+	group := make(routeGroup, 0, len(patterns))
+	for _, p := range patterns {
+		group = append(group, parseRoutePattern(p))
+	}
+	return group
+}
+
+func (g routeGroup) match(path string) bool {
+	for _, p := range g {
+		if p.match(path) {
+			return true
+		}
+	}
+	return false
+}
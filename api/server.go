@@ -4,11 +4,15 @@ package api
 
 import (
 	"fmt"
-	"log"
-	"os"
+	"time"
 
 	"github.com/tharsis/dashboard-backend/api/config"
 	"github.com/tharsis/dashboard-backend/api/handler"
+	"github.com/tharsis/dashboard-backend/api/middleware"
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v2/apikeys"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/tharsis/dashboard-backend/internal/v2/telemetry"
 
 	"github.com/fasthttp/router"
 	"github.com/valyala/fasthttp"
@@ -16,40 +20,111 @@ import (
 
 // Server represents the RPC server.
 type Server struct {
-	cfg     *config.Config
-	handler *handler.Handler
-	logger  *log.Logger
+	cfg        *config.Config
+	handler    *handler.Handler
+	store      db.Store
+	logger     *logging.Logger
+	httpServer *fasthttp.Server
 }
 
-func NewServer(cfg *config.Config) Server {
-	handler, err := handler.New()
+func NewServer(cfg *config.Config, store db.Store) Server {
+	handler, err := handler.New(cfg, store)
 	if err != nil {
 		panic(err)
 	}
 
-	return Server{
+	s := Server{
 		cfg:     cfg,
 		handler: handler,
-		// TODO logger should later be changed to one generated by config
-		// and should be passed to the handler
-		logger: log.New(os.Stdout, "INFO ", log.Ldate|log.Ltime|log.Lshortfile),
+		store:   store,
+		logger:  logging.Default(),
 	}
+	s.httpServer = s.newFastHTTPServer()
+	return s
 }
 
 func (s *Server) Start() error {
-	s.logger.Printf("Starting server on port %v", s.cfg.Server.Port)
+	s.logger.Info("Starting server", "port", s.cfg.Server.Port)
 
 	addr := fmt.Sprintf("0.0.0.0:%d", s.cfg.Server.Port)
-	router := s.newRouterWithRoutes()
-	if err := fasthttp.ListenAndServe(addr, router.Handler); err != nil {
-		s.logger.Printf("Error in fasthttp Server: %v\n", err)
+	if err := s.httpServer.ListenAndServe(addr); err != nil {
+		s.logger.Error("Error in fasthttp server", "error", err)
 		return err
 	}
 	return nil
 }
 
+// Shutdown gracefully stops the server. It stops accepting new connections
+// and waits for the in-flight requests and the registry reloads started by
+// the webhook to be completed, up to the configured shutdown timeout.
+func (s *Server) Shutdown() error {
+	s.logger.Info("Shutting down server, waiting for in-flight requests", "timeout", s.cfg.Server.ShutdownTimeout)
+
+	done := make(chan error, 1)
+	go func() {
+		err := s.httpServer.Shutdown()
+		// The webhook requests are done, no reload can be started anymore
+		s.handler.WaitRegistryReloads()
+		done <- err
+	}()
+
+	if s.cfg.Server.ShutdownTimeout <= 0 {
+		return <-done
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(s.cfg.Server.ShutdownTimeout):
+		return fmt.Errorf("server shutdown timed out after %v", s.cfg.Server.ShutdownTimeout)
+	}
+}
+
+func (s *Server) newFastHTTPServer() *fasthttp.Server {
+	cfg := s.cfg.Server
+	return &fasthttp.Server{
+		Handler:              s.newHandler(),
+		Name:                 "dashboard-backend",
+		ReadTimeout:          cfg.ReadTimeout,
+		WriteTimeout:         cfg.WriteTimeout,
+		IdleTimeout:          cfg.IdleTimeout,
+		MaxRequestBodySize:   cfg.MaxRequestBodySize,
+		Concurrency:          cfg.Concurrency,
+		MaxConnsPerIP:        cfg.MaxConnsPerIP,
+		MaxRequestsPerConn:   cfg.MaxRequestsPerConn,
+		MaxKeepaliveDuration: cfg.MaxKeepaliveDuration,
+		DisableKeepalive:     cfg.DisableKeepalive,
+		// Ask keep-alive clients to close the connection once shutdown starts
+		// so the drain is not held up by idle connections.
+		CloseOnShutdown: true,
+		Logger:          s.logger,
+	}
+}
+
+// newHandler wraps the router with the middlewares.
+func (s *Server) newHandler() fasthttp.RequestHandler {
+	middlewares := []middleware.Middleware{
+		middleware.RequestLogger(s.logger),
+		middleware.Environment,
+		middleware.RequestContext(s.cfg.Upstream.RequestTimeout),
+		telemetry.InstrumentRoutes,
+	}
+	// The API key has to be resolved before rate limiting, clients are limited per key
+	if s.cfg.APIKeys.Enabled {
+		apiKeyAuth := middleware.NewAPIKeyAuth(s.cfg.APIKeys.Header, apikeys.NewStore(s.store))
+		middlewares = append(middlewares, apiKeyAuth.Middleware)
+	}
+	if s.cfg.RateLimit.Enabled {
+		rateLimiter := middleware.NewRateLimiter(s.cfg.RateLimit, middleware.StoreLimiter{Store: s.store})
+		middlewares = append(middlewares, rateLimiter.Middleware)
+	}
+	return middleware.Chain(s.newRouterWithRoutes().Handler, middlewares...)
+}
+
 func (s *Server) newRouterWithRoutes() *router.Router {
 	r := router.New()
+	// Required to label the request metrics with the route pattern
+	r.SaveMatchedRoutePath = true
 	s.handler.RegisterRoutes(r)
 	return r
 }
@@ -0,0 +1,160 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/api/config"
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v2/mockchain"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+// testServer serves the API in memory with the mock node as the EVMOS endpoints
+// and the Numia API.
+type testServer struct {
+	node   *mockchain.Node
+	client *fasthttp.Client
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	node := mockchain.New(t)
+	node.UseAsNumia(t)
+	store := db.NewMemoryStore(1000)
+	node.Register(t, store, "EVMOS")
+
+	s := NewServer(&config.Config{}, store)
+	ln := fasthttputil.NewInmemoryListener()
+	go func() { _ = fasthttp.Serve(ln, s.newHandler()) }()
+	t.Cleanup(func() { _ = ln.Close() })
+
+	return &testServer{
+		node: node,
+		client: &fasthttp.Client{
+			Dial: func(string) (net.Conn, error) { return ln.Dial() },
+		},
+	}
+}
+
+func (s *testServer) do(t *testing.T, method string, path string, body string) (int, string) {
+	t.Helper()
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.Header.SetMethod(method)
+	req.SetRequestURI("http://api" + path)
+	req.SetBodyString(body)
+	if err := s.client.Do(req, resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode(), string(resp.Body())
+}
+
+func TestServerRequestFlows(t *testing.T) {
+	s := newTestServer(t)
+
+	testCases := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		status   int
+		contains string
+	}{
+		{name: "numia height", method: http.MethodGet, path: "/v2/height", status: http.StatusOK, contains: `"height":"` + mockchain.Height + `"`},
+		{name: "rest vesting account", method: http.MethodGet, path: "/v2/vesting/" + mockchain.Address, status: http.StatusOK, contains: `"funder_address":"` + mockchain.Address + `"`},
+		{name: "rest broadcast", method: http.MethodPost, path: "/v2/tx/broadcast", body: `{"network":"evmos","tx_bytes":"AQID"}`, status: http.StatusOK, contains: `"tx_hash":"` + mockchain.TxHash + `"`},
+		{name: "v1 rest query", method: http.MethodGet, path: "/totalStakedByAddress/" + mockchain.Address, status: http.StatusOK, contains: `"1500"`},
+		{name: "v1 jrpc query", method: http.MethodGet, path: "/TxStatus/EVMOS/" + mockchain.TxHash, status: http.StatusOK, contains: `"tx_result"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := s.do(t, tc.method, tc.path, tc.body)
+			if status != tc.status || !strings.Contains(body, tc.contains) {
+				t.Fatalf("expected %d with %s, got %d %s", tc.status, tc.contains, status, body)
+			}
+		})
+	}
+
+	var broadcast bool
+	for _, req := range s.node.Requests() {
+		if req.Method == http.MethodPost && req.Path == "/cosmos/tx/v1beta1/txs" {
+			broadcast = strings.Contains(req.Body, `"tx_bytes":"AQID"`)
+		}
+	}
+	if !broadcast {
+		t.Fatalf("expected the transaction to be broadcast to the node")
+	}
+}
+
+func TestServerNodeErrors(t *testing.T) {
+	s := newTestServer(t)
+
+	// The node answers are mapped to the v1 error bodies
+	s.node.Handle(http.MethodGet, "/cosmos/staking/v1beta1/delegations/*", http.StatusNotFound, `{"code":5,"message":"not found"}`)
+	if _, body := s.do(t, http.MethodGet, "/totalStakedByAddress/"+mockchain.Address, ""); !strings.Contains(body, `"0"`) {
+		t.Fatalf("expected an empty stake for an unknown delegator, got %s", body)
+	}
+
+	// Failed broadcasts are not served as successes
+	s.node.Handle(http.MethodPost, "/cosmos/tx/v1beta1/txs", http.StatusInternalServerError, `{"code":13}`)
+	if status, _ := s.do(t, http.MethodPost, "/v2/tx/broadcast", `{"network":"evmos","tx_bytes":"AQID"}`); status != http.StatusInternalServerError {
+		t.Fatalf("expected the failed broadcast to fail, got %d", status)
+	}
+}
+
+// blockingScanStore blocks the scans until release is closed.
+type blockingScanStore struct {
+	db.Store
+	release chan struct{}
+}
+
+func (s *blockingScanStore) Scan(ctx context.Context, prefix string) ([]string, error) {
+	<-s.release
+	return s.Store.Scan(ctx, prefix)
+}
+
+func TestServerShutdownWaitsForRegistryReloads(t *testing.T) {
+	mockchain.New(t).UseAsNumia(t)
+	store := &blockingScanStore{Store: db.NewMemoryStore(100), release: make(chan struct{})}
+	cfg := &config.Config{}
+	cfg.Server.ShutdownTimeout = 100 * time.Millisecond
+	cfg.Registry.WebhookSecret = "secret"
+	s := NewServer(cfg, store)
+
+	body := `{"ref":"refs/heads/main","after":"6dcb09b5b57875f334f61aebed695e2e4193db5e",` +
+		`"repository":{"name":"chain-token-registry","full_name":"evmos/chain-token-registry"}}`
+	mac := hmac.New(sha256.New, []byte(cfg.Registry.WebhookSecret))
+	mac.Write([]byte(body))
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetMethod(http.MethodPost)
+	ctx.Request.Header.Set("X-GitHub-Event", "push")
+	ctx.Request.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	ctx.Request.SetBodyString(body)
+	s.handler.GithubWebhook(ctx)
+	if status := ctx.Response.StatusCode(); status != http.StatusAccepted {
+		t.Fatalf("expected the reload to start, got %d %s", status, ctx.Response.Body())
+	}
+
+	// The reload is blocked, the shutdown times out waiting for it
+	if err := s.Shutdown(); err == nil {
+		t.Fatalf("expected the shutdown to wait for the registry reload")
+	}
+	close(store.release)
+	s.handler.WaitRegistryReloads()
+}
@@ -4,7 +4,6 @@
 package main
 
 import (
-	"log"
 	"os"
 	"os/signal"
 	"syscall"
@@ -12,27 +11,59 @@ import (
 	"github.com/tharsis/dashboard-backend/api"
 	"github.com/tharsis/dashboard-backend/api/config"
 
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
 	"github.com/tharsis/dashboard-backend/internal/v1/metrics"
+	"github.com/tharsis/dashboard-backend/internal/v1/requester"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/tharsis/dashboard-backend/internal/v2/node/upstream"
 )
 
 func main() {
-	// Flush metrics if we are killing the process
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-	go func() {
-		<-c
-		metrics.Flush()
-		os.Exit(1)
-	}()
 
 	// Load the configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
+		logging.Default().Error("Failed to load configuration", "error", err)
+		os.Exit(1)
 	}
 
-	rpcserver := api.NewServer(cfg)
+	if _, err := logging.Setup(cfg.Logging); err != nil {
+		logging.Default().Error("Failed to set up logging", "error", err)
+		os.Exit(1)
+	}
+
+	db.SetProxyCacheConfig(cfg.ProxyCache)
+	upstream.SetHedgeDelay(cfg.Upstream.HedgeDelay)
+
+	registrySource, err := requester.NewRegistrySource(cfg.Registry)
+	if err != nil {
+		logging.Default().Error("Invalid registry configuration", "error", err)
+		os.Exit(1)
+	}
+	requester.SetRegistrySource(registrySource)
+
+	rpcserver := api.NewServer(cfg, db.NewRedisStoreFromEnv())
+
+	// Drain in-flight requests and flush metrics if we are killing the process
+	stopped := make(chan struct{})
+	go func() {
+		<-c
+		if err := rpcserver.Shutdown(); err != nil {
+			logging.Default().Error("Error shutting down RPC server", "error", err)
+		}
+		metrics.Flush()
+		close(stopped)
+	}()
+
 	if err = rpcserver.Start(); err != nil {
-		log.Printf("Error starting RPC server: %v\n", err)
+		logging.Default().Error("Error starting RPC server", "error", err)
+		metrics.Flush()
+		os.Exit(1)
 	}
+
+	// Start returns as soon as the listener is closed,
+	// wait until every in-flight request has been served
+	<-stopped
 }
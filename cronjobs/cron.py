@@ -13,6 +13,7 @@ from constants import INDEXING_DISABLED_ERROR
 from github import get_chain_config
 from helpers import get_chains_info
 from redis_functions import redisSetEndpoint
+from redis_functions import redisSetEndpointsUpdated
 from redis_functions import setPrimaryEndpoint
 from redis_functions import setSecondaryEndpoint
 from redis_functions import setTertiaryEndpoint
@@ -175,6 +176,7 @@ def process_chain(chain: str, chain_info):
         setPrimaryEndpoint(chain, 'rest', rest_list.elements[0].url)
         setSecondaryEndpoint(chain, 'rest', rest_list.elements[1].url)
         setTertiaryEndpoint(chain, 'rest', rest_list.elements[2].url)
+        redisSetEndpointsUpdated(chain)
 
     if len(jrpc_list.elements) > 2:
         jrpc_list.elements.sort(reverse=True)
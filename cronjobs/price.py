@@ -8,7 +8,7 @@ import requests
 
 from github import get_tokens
 from helpers import get_erc20_coins
-from redis_functions import redisSetPrice, redisSetEvmosChange, flushTokens
+from redis_functions import redisSetPrice, redisSetEvmosChange, redisSetPricesUpdated, flushTokens
 
 
 def get_evmos_change():
@@ -58,6 +58,7 @@ def main():
             prices = get_prices("usd", erc20_module_coins)
             get_evmos_change()
             process_assets(prices)
+            redisSetPricesUpdated()
             attempt = 0
             time.sleep(300)
         except Exception as e:
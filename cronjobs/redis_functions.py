@@ -5,6 +5,7 @@ from __future__ import annotations
 import json
 
 import os
+import time
 
 import redis
 
@@ -26,12 +27,17 @@ prod_prefix = "prod-" if ENVIRONMENT == "production" else ""
 
 erc20TokensDirectoryKey = f"{prod_prefix}git-erc20-tokens-directory"
 networkConfig = f"{prod_prefix}git-network-config-directory"
+networkConfigUpdated = f"{networkConfig}-updated"
+pricesUpdated = "prices|updated"
 
 
 def redisSetPrice(asset: str, vs_currency: str, price: float):
     key = f'{asset}|{vs_currency}|price'
     r.mset({key: price})
 
+def redisSetPricesUpdated():
+    r.mset({pricesUpdated: int(time.time())})
+
 def redisSetEvmosChange(change: float):
     key = f'evmos|24h|change'
     r.mset({key: change})
@@ -57,6 +63,12 @@ def redisGetEndpoint(chain: str, endpoint: str, order: int) -> str | None:
     return str(value)
 
 
+def redisSetEndpointsUpdated(chain: str):
+    # read by the API as the freshness of the rankings published by this cron
+    key = f'{chain}|endpoints|updated'
+    r.mset({key: int(time.time())})
+
+
 def setPrimaryEndpoint(chain: str, endpoint: str, url: str):
     return redisSetEndpoint(chain, endpoint, 1, url)
 
@@ -94,6 +106,7 @@ def getTokens():
 
 def setChains(data):
     r.set(networkConfig, json.dumps(data), SECONDS_PER_HOUR*24)
+    r.set(networkConfigUpdated, int(time.time()))
 
 
 def getChains():
@@ -0,0 +1,125 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Fallback policies applied when fewer than MinHealthy endpoints are healthy.
+const (
+	// FallbackKeep keeps the previous ranking
+	FallbackKeep = "keep"
+	// FallbackPublish publishes the healthy endpoints anyway
+	FallbackPublish = "publish"
+)
+
+// Config represents the endpoint cron configuration. It is read from the
+// file given with --config, the flags that are set override it.
+type Config struct {
+	// Interval between the probes of the networks
+	Interval time.Duration `toml:"interval"`
+	// Jitter is the maximum random delay added to the interval, so the
+	// instances of the cron do not probe the nodes at the same time
+	Jitter time.Duration `toml:"jitter"`
+	// TopN is the number of endpoints published per type, 0 publishes every healthy endpoint
+	TopN int `toml:"top_n"`
+	// MinHealthy is the number of healthy endpoints needed to publish a ranking
+	MinHealthy int `toml:"min_healthy"`
+	// Fallback is the policy applied below MinHealthy: keep or publish
+	Fallback string `toml:"fallback"`
+	// MaxHeightLag is the number of blocks an endpoint can be behind the best
+	// one to be healthy, 0 disables the check
+	MaxHeightLag int `toml:"max_height_lag"`
+	// HistoryRetention is how long the checks of each endpoint are kept,
+	// 0 disables the history
+	HistoryRetention time.Duration `toml:"history_retention"`
+	// Once probes the networks a single time and exits, e.g. in CI
+	Once bool `toml:"once"`
+}
+
+// defaultConfig is used for the values missing in the file and the flags.
+func defaultConfig() Config {
+	return Config{
+		Interval:         30 * time.Second,
+		Jitter:           5 * time.Second,
+		TopN:             5,
+		MinHealthy:       1,
+		Fallback:         FallbackKeep,
+		MaxHeightLag:     20,
+		HistoryRetention: 6 * time.Hour,
+	}
+}
+
+// loadConfig builds the configuration from the command line arguments.
+func loadConfig(args []string, output io.Writer) (Config, error) {
+	cfg := defaultConfig()
+
+	fs := flag.NewFlagSet("endpoints", flag.ContinueOnError)
+	fs.SetOutput(output)
+	path := fs.String("config", "", "TOML configuration file, see go-crons/endpoints/config.toml")
+	flags := cfg
+	fs.DurationVar(&flags.Interval, "interval", cfg.Interval, "interval between the probes of the networks")
+	fs.DurationVar(&flags.Jitter, "jitter", cfg.Jitter, "maximum random delay added to the interval")
+	fs.IntVar(&flags.TopN, "top-n", cfg.TopN, "number of endpoints published per type, 0 publishes every healthy endpoint")
+	fs.IntVar(&flags.MinHealthy, "min-healthy", cfg.MinHealthy, "number of healthy endpoints needed to publish a ranking")
+	fs.StringVar(&flags.Fallback, "fallback", cfg.Fallback, "policy below min-healthy: keep the previous ranking or publish the healthy endpoints")
+	fs.IntVar(&flags.MaxHeightLag, "max-height-lag", cfg.MaxHeightLag, "blocks an endpoint can be behind the best one, 0 disables the check")
+	fs.DurationVar(&flags.HistoryRetention, "history-retention", cfg.HistoryRetention, "how long the checks of each endpoint are kept, 0 disables the history")
+	fs.BoolVar(&flags.Once, "once", cfg.Once, "probe the networks once and exit, with an error if a ranking was not published")
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+
+	if *path != "" {
+		if _, err := toml.DecodeFile(*path, &cfg); err != nil {
+			return Config{}, fmt.Errorf("failed to decode config file: %w", err)
+		}
+	}
+
+	// The flags that are set override the file
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "interval":
+			cfg.Interval = flags.Interval
+		case "jitter":
+			cfg.Jitter = flags.Jitter
+		case "top-n":
+			cfg.TopN = flags.TopN
+		case "min-healthy":
+			cfg.MinHealthy = flags.MinHealthy
+		case "fallback":
+			cfg.Fallback = flags.Fallback
+		case "max-height-lag":
+			cfg.MaxHeightLag = flags.MaxHeightLag
+		case "history-retention":
+			cfg.HistoryRetention = flags.HistoryRetention
+		case "once":
+			cfg.Once = flags.Once
+		}
+	})
+
+	return cfg, cfg.validate()
+}
+
+func (c Config) validate() error {
+	if c.Interval <= 0 {
+		return fmt.Errorf("the interval must be positive")
+	}
+	if c.Jitter < 0 || c.TopN < 0 || c.MinHealthy < 0 || c.MaxHeightLag < 0 || c.HistoryRetention < 0 {
+		return fmt.Errorf("the jitter, top-n, min-healthy, max-height-lag and history-retention cannot be negative")
+	}
+	if c.TopN > 0 && c.MinHealthy > c.TopN {
+		return fmt.Errorf("min-healthy (%d) cannot be above top-n (%d)", c.MinHealthy, c.TopN)
+	}
+	if c.Fallback != FallbackKeep && c.Fallback != FallbackPublish {
+		return fmt.Errorf("invalid fallback %q, expected %s or %s", c.Fallback, FallbackKeep, FallbackPublish)
+	}
+	return nil
+}
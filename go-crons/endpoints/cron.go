@@ -4,102 +4,321 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"math/rand"
+	"os"
+	"os/signal"
 	"sort"
 	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/tharsis/dashboard-backend/go-crons/endpoints/helpers"
 	"github.com/tharsis/dashboard-backend/go-crons/endpoints/models"
 	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v1/requester"
 	"github.com/tharsis/dashboard-backend/internal/v1/resources"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
 )
 
-var (
-	running = true
-	wg      sync.WaitGroup
-)
+// rankEndpoints scores the endpoints that answered and sorts them, best first.
+// The score favors the endpoints at the highest height, then the fastest ones:
+// each block behind the highest height costs as much as a second of latency.
+func rankEndpoints(endpoints []models.Endpoint, checkedAt time.Time) []db.RankedEndpoint {
+	maxHeight := 0
+	for _, e := range endpoints {
+		if e.Height > maxHeight {
+			maxHeight = e.Height
+		}
+	}
 
-func sortEndpoints(endpoints []models.Endpoint) []models.Endpoint { //nolint:all
-	sort.SliceStable(endpoints, func(i, j int) bool {
-		if endpoints[i].Height == -1 {
-			return true
+	ranked := make([]db.RankedEndpoint, 0, len(endpoints))
+	for _, e := range endpoints {
+		if e.Height == -1 || e.Latency == -1 {
+			continue
 		}
+		ranked = append(ranked, db.RankedEndpoint{
+			URL:         e.URL,
+			Height:      e.Height,
+			Latency:     e.Latency,
+			LastChecked: checkedAt,
+			Score:       1 / (1 + float64(maxHeight-e.Height) + e.Latency),
+		})
+	}
 
-		if endpoints[i].Latency == -1 {
-			return true
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+	return ranked
+}
+
+// selectEndpoints returns the endpoints of the ranking to publish: the healthy
+// ones, at most MaxHeightLag blocks behind the best height, up to TopN.
+// It returns false when the previous ranking must be kept.
+func selectEndpoints(cfg Config, ranked []db.RankedEndpoint) ([]db.RankedEndpoint, bool) {
+	maxHeight := 0
+	for _, e := range ranked {
+		if e.Height > maxHeight {
+			maxHeight = e.Height
 		}
+	}
 
-		if endpoints[i].Height != endpoints[j].Height {
-			return endpoints[i].Height < endpoints[j].Height
+	healthy := make([]db.RankedEndpoint, 0, len(ranked))
+	for _, e := range ranked {
+		if cfg.MaxHeightLag > 0 && maxHeight-e.Height > cfg.MaxHeightLag {
+			continue
 		}
+		healthy = append(healthy, e)
+	}
 
-		return endpoints[i].Latency > endpoints[j].Latency
-	})
+	if len(healthy) == 0 || len(healthy) < cfg.MinHealthy && cfg.Fallback == FallbackKeep {
+		return nil, false
+	}
+	if cfg.TopN > 0 && len(healthy) > cfg.TopN {
+		healthy = healthy[:cfg.TopN]
+	}
+	return healthy, true
+}
 
-	return endpoints
+// reportRejected logs the endpoints rejected by the probes, e.g. the nodes of
+// another chain, so the registry can be fixed.
+func reportRejected(logger *logging.Logger, endpointType string, endpoints []models.Endpoint) {
+	for _, e := range endpoints {
+		if e.Error != "" {
+			logger.Warn("Endpoint rejected", "endpoint_type", endpointType, "url", e.URL, "error", e.Error)
+		}
+	}
 }
 
-func processNetwork(networkConfig resources.NetworkConfig) {
-	// signal waiting group goroutine is done at the end of the function
-	defer wg.Done()
+// processNetwork ranks the endpoints of every configuration of the network,
+// e.g. mainnet and testnet, and publishes the rankings. It returns the number
+// of rankings that were not published.
+func processNetwork(store db.Store, cfg Config, networkConfig resources.NetworkConfig) int {
+	unpublished := 0
+	identifier := resources.GetNetworkIdentifier(networkConfig)
+	for _, config := range networkConfig.Configurations {
+		unpublished += processConfiguration(store, cfg, identifier, config)
+	}
+	return unpublished
+}
 
-	// get mainnet configuration
-	config := resources.GetMainnetConfig(networkConfig)
+// processConfiguration ranks the endpoints of the configuration and publishes
+// the rankings under the network identifier namespaced by the configuration
+// type, e.g. TESTNET:EVMOS, see db.EnvironmentChain. The mainnet rankings are
+// not namespaced. It returns the number of rankings that were not published.
+func processConfiguration(store db.Store, cfg Config, identifier string, config resources.ConfigurationEntry) int {
+	chain := db.EnvironmentChain(config.ConfigurationType, identifier)
 
-	fmt.Printf("Processing %s network...\n", config.Identifier)
+	logger := logging.Default().With("chain", chain)
+	logger.Info("Processing network...")
+	checkedAt := time.Now()
 
 	// process REST endpoints
-	restEndpoints := helpers.ProcessRest(config.Rest, config.Identifier)
-	// sort endpoints based on latency and height
-	sortEndpoints(restEndpoints)
+	restResults := helpers.ProcessRest(config.Rest, config.Identifier, config.ChainID)
+	reportRejected(logger, "rest", restResults)
+	restEndpoints := rankEndpoints(restResults, time.Now())
 
 	// process JRPC endpoints
-	jrpcEndpoints := helpers.ProcessJrpc(config.Jrpc)
-	// sort endpoints based on latency and height
-	sortEndpoints(jrpcEndpoints)
+	jrpcResults := helpers.ProcessJrpc(config.Jrpc, config.ChainID)
+	reportRejected(logger, "jrpc", jrpcResults)
+	jrpcEndpoints := rankEndpoints(jrpcResults, time.Now())
 
 	// process web3 endpoints if available
-	var web3Endpoints []models.Endpoint
+	var web3Results []models.Endpoint
+	var web3Endpoints []db.RankedEndpoint
 	if len(config.Web3) > 0 {
-		web3Endpoints = helpers.ProcessWeb3(config.Web3)
-		// sort endpoints based on latency and height
-		sortEndpoints((web3Endpoints))
+		web3Results = helpers.ProcessWeb3(config.Web3, config.ChainID)
+		reportRejected(logger, "web3", web3Results)
+		web3Endpoints = rankEndpoints(web3Results, time.Now())
+	}
+
+	// process gRPC endpoints if available
+	var grpcResults []models.Endpoint
+	var grpcEndpoints []db.RankedEndpoint
+	if len(config.GRPC) > 0 {
+		grpcResults = helpers.ProcessGrpc(config.GRPC, config.ChainID)
+		reportRejected(logger, "grpc", grpcResults)
+		grpcEndpoints = rankEndpoints(grpcResults, time.Now())
 	}
 
-	fmt.Printf("Finished processing %s network...\n", config.Identifier)
+	logger.Info("Finished processing network",
+		"rest_endpoints", len(restEndpoints),
+		"jrpc_endpoints", len(jrpcEndpoints),
+		"web3_endpoints", len(web3Endpoints),
+		"grpc_endpoints", len(grpcEndpoints),
+	)
 
-	// store rest result in redis
-	if len(restEndpoints) > 2 {
-		db.RedisSetEndpoint(config.Identifier, "rest", "1", restEndpoints[len(restEndpoints)-1].URL)
-		db.RedisSetEndpoint(config.Identifier, "rest", "2", restEndpoints[len(restEndpoints)-2].URL)
-		db.RedisSetEndpoint(config.Identifier, "rest", "3", restEndpoints[len(restEndpoints)-3].URL)
+	// publish the ranking of each type in redis and record the checks
+	unpublished := 0
+	publish := func(endpointType string, results []models.Endpoint, ranked []db.RankedEndpoint) {
+		published := storeEndpoints(store, cfg, logger, chain, endpointType, ranked)
+		if published == nil {
+			unpublished++
+		}
+		recordHistory(store, cfg, logger, chain, endpointType, results, published, checkedAt)
 	}
-	// store jrc result in redis
-	if len(jrpcEndpoints) > 2 {
-		db.RedisSetEndpoint(config.Identifier, "jrpc", "1", jrpcEndpoints[len(jrpcEndpoints)-1].URL)
-		db.RedisSetEndpoint(config.Identifier, "jrpc", "2", jrpcEndpoints[len(jrpcEndpoints)-2].URL)
-		db.RedisSetEndpoint(config.Identifier, "jrpc", "3", jrpcEndpoints[len(jrpcEndpoints)-3].URL)
+	publish("rest", restResults, restEndpoints)
+	publish("jrpc", jrpcResults, jrpcEndpoints)
+	if len(config.Web3) > 0 {
+		publish("web3", web3Results, web3Endpoints)
 	}
-	// store web3 result in redis
-	if len(web3Endpoints) > 2 {
-		db.RedisSetEndpoint(config.Identifier, "web3", "1", web3Endpoints[len(web3Endpoints)-1].URL)
-		db.RedisSetEndpoint(config.Identifier, "web3", "2", web3Endpoints[len(web3Endpoints)-2].URL)
-		db.RedisSetEndpoint(config.Identifier, "web3", "3", web3Endpoints[len(web3Endpoints)-3].URL)
+	if len(config.GRPC) > 0 {
+		publish("grpc", grpcResults, grpcEndpoints)
 	}
+	return unpublished
 }
 
-func main() {
-	for running {
-		fmt.Println("Fetching network configs...")
-		networkConfigs, err := resources.GetNetworkConfigs()
+// storeEndpoints publishes the endpoints selected from the ranking, or keeps
+// the previous ranking if there are not enough healthy endpoints.
+// It returns the published endpoints, nil when the ranking was not published.
+func storeEndpoints(store db.Store, cfg Config, logger *logging.Logger, chain string, endpointType string, ranked []db.RankedEndpoint) []db.RankedEndpoint {
+	endpoints, ok := selectEndpoints(cfg, ranked)
+	if !ok {
+		logger.Warn("Not enough healthy endpoints, keeping the previous ranking",
+			"endpoint_type", endpointType,
+			"answered", len(ranked),
+			"min_healthy", cfg.MinHealthy,
+		)
+		return nil
+	}
+	if len(endpoints) < cfg.MinHealthy {
+		logger.Warn("Publishing fewer healthy endpoints than the minimum",
+			"endpoint_type", endpointType,
+			"healthy", len(endpoints),
+			"min_healthy", cfg.MinHealthy,
+		)
+	}
+	if err := db.RedisSetEndpointRanking(store, chain, endpointType, endpoints, time.Now()); err != nil {
+		logger.Error("Error storing endpoint ranking", "endpoint_type", endpointType, "error", err)
+		return nil
+	}
+	return endpoints
+}
+
+// recordHistory adds the checks of the endpoints to their history, with their
+// rank in the published ranking, so the nodes in use at a given time and their
+// health can be looked up later.
+func recordHistory(store db.Store, cfg Config, logger *logging.Logger, chain string, endpointType string, results []models.Endpoint, published []db.RankedEndpoint, checkedAt time.Time) {
+	if cfg.HistoryRetention <= 0 {
+		return
+	}
+
+	maxHeight := 0
+	for _, e := range results {
+		if e.Height > maxHeight {
+			maxHeight = e.Height
+		}
+	}
+	ranks := make(map[string]int, len(published))
+	for i, e := range published {
+		ranks[e.URL] = i + 1
+	}
+
+	checks := make(map[string]db.EndpointCheck, len(results))
+	for _, e := range results {
+		check := db.EndpointCheck{
+			CheckedAt:       checkedAt,
+			Height:          e.Height,
+			Latency:         e.Latency,
+			Lag:             -1,
+			Rank:            ranks[e.URL],
+			Error:           e.Error,
+			ChainIDMismatch: e.ChainIDMismatch,
+		}
+		if e.Height != -1 && e.Latency != -1 {
+			check.Lag = maxHeight - e.Height
+		}
+		checks[e.URL] = check
+	}
+	if err := db.RedisAddEndpointChecks(store, chain, endpointType, checks, cfg.HistoryRetention); err != nil {
+		logger.Warn("Error storing endpoint history", "endpoint_type", endpointType, "error", err)
+	}
+}
+
+// probeNetworks ranks the endpoints of every network once.
+// It returns the number of rankings that were not published.
+func probeNetworks(store db.Store, cfg Config) (int, error) {
+	logging.Default().Info("Fetching network configs...")
+	networkConfigs, err := resources.GetNetworkConfigs(store)
+	if err != nil {
+		return 0, fmt.Errorf("error fetching network configs: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	var unpublished int64
+	for _, v := range networkConfigs {
+		wg.Add(1)
+		go func(networkConfig resources.NetworkConfig) {
+			defer wg.Done()
+			atomic.AddInt64(&unpublished, int64(processNetwork(store, cfg, networkConfig)))
+		}(v)
+	}
+	wg.Wait()
+	return int(unpublished), nil
+}
+
+// run probes the networks every interval until ctx is done, the probe in
+// progress is finished first. In once mode the networks are probed a single
+// time and an error is returned if a ranking was not published.
+func run(ctx context.Context, store db.Store, cfg Config) error {
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for {
+		unpublished, err := probeNetworks(store, cfg)
+		if cfg.Once {
+			if err != nil {
+				return err
+			}
+			if unpublished > 0 {
+				return fmt.Errorf("%d endpoint rankings were not published", unpublished)
+			}
+			return nil
+		}
 		if err != nil {
-			// TODO: report to sentry?
-			panic(err)
+			logging.Default().Error("Error probing the networks, retrying at the next interval", "error", err)
+		}
+
+		delay := cfg.Interval
+		if cfg.Jitter > 0 {
+			delay += time.Duration(random.Int63n(int64(cfg.Jitter)))
 		}
-		for _, v := range networkConfigs {
-			go processNetwork(v)
-			wg.Add(1)
+		select {
+		case <-ctx.Done():
+			logging.Default().Info("Shutting down")
+			return nil
+		case <-time.After(delay):
 		}
-		wg.Wait()
+	}
+}
+
+func main() {
+	cfg, err := loadConfig(os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if _, err := logging.Setup(logging.Config{}.LoadEnv()); err != nil {
+		panic(err)
+	}
+	registrySource, err := requester.NewRegistrySource(requester.RegistryConfig{}.LoadEnv())
+	if err != nil {
+		panic(err)
+	}
+	requester.SetRegistrySource(registrySource)
+	store := db.NewRedisStoreFromEnv()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	err = run(ctx, store, cfg)
+	stop()
+	if err != nil {
+		logging.Default().Error("Error running the endpoint cron", "error", err)
+		os.Exit(1)
 	}
 }
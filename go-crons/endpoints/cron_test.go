@@ -0,0 +1,159 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package main
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/go-crons/endpoints/models"
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v1/resources"
+	"github.com/tharsis/dashboard-backend/internal/v2/mockchain"
+)
+
+func TestSelectEndpoints(t *testing.T) {
+	ranked := rankEndpoints([]models.Endpoint{
+		{URL: "a", Height: 100, Latency: 0.1},
+		{URL: "b", Height: 100, Latency: 0.2},
+		{URL: "c", Height: 99, Latency: 0.1},
+		{URL: "lagging", Height: 50, Latency: 0.1},
+		{URL: "down", Height: -1, Latency: -1},
+	}, time.Now())
+
+	tests := []struct {
+		name string
+		cfg  Config
+		want []string
+	}{
+		{"top n", Config{TopN: 2, MinHealthy: 1, Fallback: FallbackKeep, MaxHeightLag: 10}, []string{"a", "b"}},
+		{"every healthy endpoint", Config{MinHealthy: 1, Fallback: FallbackKeep, MaxHeightLag: 10}, []string{"a", "b", "c"}},
+		{"lag check disabled", Config{MinHealthy: 1, Fallback: FallbackKeep}, []string{"a", "b", "c", "lagging"}},
+		{"keep below min healthy", Config{MinHealthy: 4, Fallback: FallbackKeep, MaxHeightLag: 10}, nil},
+		{"publish below min healthy", Config{MinHealthy: 4, Fallback: FallbackPublish, MaxHeightLag: 10}, []string{"a", "b", "c"}},
+	}
+	for _, tc := range tests {
+		endpoints, ok := selectEndpoints(tc.cfg, ranked)
+		if ok != (tc.want != nil) || len(endpoints) != len(tc.want) {
+			t.Fatalf("%s: expected %v, got %+v", tc.name, tc.want, endpoints)
+		}
+		for i, url := range tc.want {
+			if endpoints[i].URL != url {
+				t.Fatalf("%s: expected %v, got %+v", tc.name, tc.want, endpoints)
+			}
+		}
+	}
+
+	if _, ok := selectEndpoints(Config{Fallback: FallbackPublish}, nil); ok {
+		t.Fatalf("expected no ranking to be published without healthy endpoints")
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	cfg, err := loadConfig(nil, io.Discard)
+	if err != nil || cfg != defaultConfig() {
+		t.Fatalf("expected the default config, got %+v %v", cfg, err)
+	}
+
+	// The example file holds the defaults
+	cfg, err = loadConfig([]string{"--config", "config.toml"}, io.Discard)
+	if err != nil || cfg != defaultConfig() {
+		t.Fatalf("expected config.toml to hold the defaults, got %+v %v", cfg, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("interval = \"1m\"\ntop_n = 3\nfallback = \"publish\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = loadConfig([]string{"--config", path, "--top-n", "2", "--once"}, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := defaultConfig()
+	want.Interval = time.Minute
+	want.TopN = 2
+	want.Fallback = FallbackPublish
+	want.Once = true
+	if cfg != want {
+		t.Fatalf("expected %+v, got %+v", want, cfg)
+	}
+
+	for _, args := range [][]string{
+		{"--interval", "0s"},
+		{"--fallback", "drop"},
+		{"--top-n", "2", "--min-healthy", "3"},
+		{"--config", filepath.Join(t.TempDir(), "missing.toml")},
+	} {
+		if _, err := loadConfig(args, io.Discard); err == nil {
+			t.Fatalf("expected an error for %v", args)
+		}
+	}
+}
+
+func TestProcessNetworkRanksEveryEnvironment(t *testing.T) {
+	mainnet := mockchain.New(t)
+	testnet := mockchain.New(t)
+	testnet.Handle(http.MethodGet, "/status", http.StatusOK, `{"jsonrpc":"2.0","id":-1,"result":{`+
+		`"node_info":{"network":"evmos_9000-4","other":{"tx_index":"on"}},`+
+		`"sync_info":{"latest_block_height":"100","latest_block_time":"2023-06-01T00:00:00Z","catching_up":false}}}`)
+	testnet.Handle(http.MethodGet, "/cosmos/base/tendermint/v1beta1/blocks/latest", http.StatusOK,
+		`{"block":{"header":{"chain_id":"evmos_9000-4","height":"100"}}}`)
+
+	networkConfig := resources.NetworkConfig{
+		Prefix: "evmos",
+		Configurations: []resources.ConfigurationEntry{
+			// The testnet node listed by mistake is rejected
+			// The Tendermint RPC endpoints are not probed as gRPC endpoints
+			{ChainID: mockchain.ChainID, Identifier: "evmos", ConfigurationType: "mainnet", Rest: []string{mainnet.URL(), testnet.URL()}, Jrpc: []string{mainnet.URL()}, RPC: []string{mainnet.URL()}},
+			{ChainID: "evmos_9000-4", Identifier: "evmostestnet", ConfigurationType: "testnet", Rest: []string{testnet.URL()}, Jrpc: []string{testnet.URL()}},
+		},
+	}
+	store := db.NewMemoryStore(100)
+	if unpublished := processNetwork(store, Config{MinHealthy: 1, Fallback: FallbackKeep, HistoryRetention: time.Hour}, networkConfig); unpublished != 0 {
+		t.Fatalf("expected every ranking to be published, %d were not", unpublished)
+	}
+
+	if _, err := db.RedisGetEndpointRanking(store, "EVMOS", "grpc"); err != db.ErrNotFound {
+		t.Fatalf("expected no gRPC ranking, got %v", err)
+	}
+
+	// The testnet rankings are namespaced under the identifier of the network
+	for chain, want := range map[string]string{"EVMOS": mainnet.URL(), "TESTNET:EVMOS": testnet.URL()} {
+		for _, endpointType := range []string{"rest", "jrpc"} {
+			ranking, err := db.RedisGetEndpointRanking(store, chain, endpointType)
+			if err != nil {
+				t.Fatalf("%s %s: %v", chain, endpointType, err)
+			}
+			if len(ranking.Endpoints) != 1 || ranking.Endpoints[0].URL != want {
+				t.Fatalf("%s %s: expected %s, got %+v", chain, endpointType, want, ranking.Endpoints)
+			}
+		}
+	}
+
+	// Every check is recorded, with the rank of the published endpoints
+	history, err := db.RedisGetEndpointHistory(store, "EVMOS", "rest", time.Time{})
+	if err != nil || len(history) != 2 {
+		t.Fatalf("expected the history of both endpoints, got %+v %v", history, err)
+	}
+	for _, h := range history {
+		if len(h.Checks) != 1 {
+			t.Fatalf("expected a check of %s, got %+v", h.URL, h.Checks)
+		}
+		check := h.Checks[0]
+		switch h.URL {
+		case mainnet.URL():
+			if check.Rank != 1 || check.Lag != 0 || check.Height != 13281459 || check.Error != "" {
+				t.Fatalf("unexpected check of the mainnet node %+v", check)
+			}
+		case testnet.URL():
+			if check.Rank != 0 || check.Lag != -1 || !check.ChainIDMismatch {
+				t.Fatalf("expected the testnet node to be rejected, got %+v", check)
+			}
+		}
+	}
+}
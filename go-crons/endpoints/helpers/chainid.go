@@ -0,0 +1,55 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/evmos/evmos/v12/types"
+	"github.com/tharsis/dashboard-backend/go-crons/endpoints/models"
+)
+
+// ErrChainIDMismatch is the error of the nodes reporting another chain ID.
+var ErrChainIDMismatch = errors.New("chain ID mismatch")
+
+// verifyChainID returns an error if the chain ID reported by the node is not
+// the expected one, e.g. a node of a testnet or a fork listed by mistake.
+// Nothing is verified when the expected chain ID is unknown.
+func verifyChainID(expected string, got string) error {
+	if expected == "" || got == expected {
+		return nil
+	}
+	return fmt.Errorf("%w: expected %s, got %q", ErrChainIDMismatch, expected, got)
+}
+
+// verifyEthChainID returns an error if the eth_chainId result, a hex number,
+// is not the EIP-155 chain ID of the expected chain ID, e.g. 0x2329 for evmos_9001-2.
+func verifyEthChainID(expected string, got string) error {
+	if expected == "" {
+		return nil
+	}
+	want, err := types.ParseChainID(expected)
+	if err != nil {
+		return fmt.Errorf("chain ID %s has no EIP-155 chain ID: %w", expected, err)
+	}
+	chainID, ok := new(big.Int).SetString(strings.TrimPrefix(got, "0x"), 16)
+	if !ok || chainID.Cmp(want) != 0 {
+		return fmt.Errorf("%w: expected %s (%s), got %q", ErrChainIDMismatch, expected, want, got)
+	}
+	return nil
+}
+
+// rejectedEndpoint is the result of a probe rejected because of err.
+func rejectedEndpoint(endpoint string, err error) models.Endpoint {
+	return models.Endpoint{
+		URL:             endpoint,
+		Latency:         -1,
+		Height:          -1,
+		Error:           err.Error(),
+		ChainIDMismatch: errors.Is(err, ErrChainIDMismatch),
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package helpers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tharsis/dashboard-backend/go-crons/endpoints/models"
+	"github.com/tharsis/dashboard-backend/internal/v2/mockchain"
+)
+
+func TestProbesVerifyChainID(t *testing.T) {
+	node := mockchain.New(t)
+
+	probes := []struct {
+		name  string
+		probe func(chainID string) []models.Endpoint
+	}{
+		{"rest", func(chainID string) []models.Endpoint { return ProcessRest([]string{node.URL()}, "evmos", chainID) }},
+		{"jrpc", func(chainID string) []models.Endpoint { return ProcessJrpc([]string{node.URL()}, chainID) }},
+		{"web3", func(chainID string) []models.Endpoint { return ProcessWeb3([]string{node.URL()}, chainID) }},
+	}
+	for _, p := range probes {
+		res := p.probe(mockchain.ChainID)
+		if len(res) != 1 || res[0].Height != 13281459 || res[0].Error != "" {
+			t.Fatalf("%s: expected the node to be accepted, got %+v", p.name, res)
+		}
+
+		// A testnet node listed as a mainnet one
+		res = p.probe("evmos_9000-4")
+		if len(res) != 1 || res[0].Height != -1 || !strings.Contains(res[0].Error, "chain ID mismatch") {
+			t.Fatalf("%s: expected the node to be rejected, got %+v", p.name, res)
+		}
+	}
+}
+
+func TestVerifyEthChainID(t *testing.T) {
+	if err := verifyEthChainID("evmos_9001-2", "0x2329"); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyEthChainID("", "0x1"); err != nil {
+		t.Fatalf("expected an unknown chain ID not to be verified, got %v", err)
+	}
+	for _, got := range []string{"0x1", "", "0xzz"} {
+		if err := verifyEthChainID("evmos_9001-2", got); err == nil {
+			t.Fatalf("expected %q to be rejected", got)
+		}
+	}
+	if err := verifyEthChainID("osmosis-1", "0x1"); err == nil {
+		t.Fatalf("expected an error for a chain ID without EIP-155 chain ID")
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	"github.com/tharsis/dashboard-backend/go-crons/endpoints/models"
+	"github.com/tharsis/dashboard-backend/internal/v1/requester"
+	"github.com/tharsis/dashboard-backend/internal/v2/node/grpc"
+)
+
+var gwg sync.WaitGroup
+
+// PingGrpc measures the height and latency of the gRPC endpoint with the
+// latest block of the Tendermint service, and verifies its chain ID.
+func PingGrpc(endpoint string, chainID string, c chan models.Endpoint) {
+	defer gwg.Done()
+
+	conn, err := grpc.Dial(endpoint)
+	if err != nil {
+		c <- rejectedEndpoint(endpoint, err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), requester.Client.Timeout)
+	defer cancel()
+
+	// record start time to measure latency
+	start := time.Now()
+
+	res, err := tmservice.NewServiceClient(conn).GetLatestBlock(ctx, &tmservice.GetLatestBlockRequest{})
+	if err != nil {
+		e := models.Endpoint{
+			URL:     endpoint,
+			Latency: -1,
+			Height:  -1,
+		}
+		c <- e
+		return
+	}
+
+	// compute latency
+	duration := time.Since(start).Seconds()
+
+	var blockChainID string
+	var height int64
+	switch {
+	case res.SdkBlock != nil:
+		blockChainID, height = res.SdkBlock.Header.ChainID, res.SdkBlock.Header.Height
+	case res.Block != nil:
+		blockChainID, height = res.Block.Header.ChainID, res.Block.Header.Height
+	default:
+		c <- rejectedEndpoint(endpoint, fmt.Errorf("empty latest block"))
+		return
+	}
+
+	if err := verifyChainID(chainID, blockChainID); err != nil {
+		c <- rejectedEndpoint(endpoint, err)
+		return
+	}
+
+	e := models.Endpoint{
+		URL:     endpoint,
+		Latency: duration,
+		Height:  int(height),
+	}
+
+	c <- e
+}
+
+func ProcessGrpc(grpcEndpoints []string, chainID string) []models.Endpoint {
+	// create a channel to receive results for each gRPC endpoint
+	grpcChannel := make(chan models.Endpoint, len(grpcEndpoints))
+
+	for _, v := range grpcEndpoints {
+		// add goroutine to gRPC wait group
+		gwg.Add(1)
+		// ping gRPC endpoint & get results in a goroutine
+		go PingGrpc(v, chainID, grpcChannel)
+	}
+
+	grpcResults := make([]models.Endpoint, 0)
+
+	done := make(chan struct{})
+
+	// Loop over values sent via channel.
+	// This has to be as a separate goroutine in order to keep channel listener open
+	// while waiting for all endpoints to be pinged & processed
+	go func() {
+		for r := range grpcChannel {
+			grpcResults = append(grpcResults, r)
+		}
+		close(done)
+	}()
+	// wait for all endpoints to be pinged & processed
+	gwg.Wait()
+	// close channel
+	close(grpcChannel)
+	// wait for all values to be read
+	<-done
+
+	return grpcResults
+}
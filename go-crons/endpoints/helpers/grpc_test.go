@@ -0,0 +1,48 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package helpers
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tharsis/dashboard-backend/internal/v2/mockchain"
+	"github.com/tharsis/dashboard-backend/internal/v2/node/grpc"
+	gogrpc "google.golang.org/grpc"
+)
+
+type tendermintServer struct {
+	tmservice.UnimplementedServiceServer
+}
+
+func (*tendermintServer) GetLatestBlock(context.Context, *tmservice.GetLatestBlockRequest) (*tmservice.GetLatestBlockResponse, error) {
+	return &tmservice.GetLatestBlockResponse{
+		Block: &tmproto.Block{Header: tmproto.Header{ChainID: mockchain.ChainID, Height: 13281459}},
+	}, nil
+}
+
+func TestProcessGrpc(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := gogrpc.NewServer(gogrpc.ForceServerCodec(grpc.Codec{}))
+	tmservice.RegisterServiceServer(srv, &tendermintServer{})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	res := ProcessGrpc([]string{lis.Addr().String()}, mockchain.ChainID)
+	if len(res) != 1 || res[0].Height != 13281459 || res[0].Latency < 0 || res[0].Error != "" {
+		t.Fatalf("expected the node to be accepted, got %+v", res)
+	}
+
+	res = ProcessGrpc([]string{lis.Addr().String()}, "evmos_9000-4")
+	if len(res) != 1 || res[0].Height != -1 || !strings.Contains(res[0].Error, "chain ID mismatch") {
+		t.Fatalf("expected the node to be rejected, got %+v", res)
+	}
+}
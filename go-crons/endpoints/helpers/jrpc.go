@@ -19,7 +19,9 @@ import (
 
 var jwg sync.WaitGroup
 
-func PingJrpc(endpoint string, c chan models.Endpoint) {
+// PingJrpc measures the height and latency of the Tendermint JSON-RPC endpoint,
+// which must index the transactions, and verifies the network of the node.
+func PingJrpc(endpoint string, chainID string, c chan models.Endpoint) {
 	defer jwg.Done()
 
 	transactionURL := fmt.Sprintf("%s/tx?hash=0x0000000000000000000000000000000000000000000000000000000000000000", endpoint)
@@ -98,6 +100,11 @@ func PingJrpc(endpoint string, c chan models.Endpoint) {
 	var jsonRes models.JrpcStatusResponse
 	_ = json.Unmarshal(body, &jsonRes)
 
+	if err := verifyChainID(chainID, jsonRes.Result.NodeInfo.Network); err != nil {
+		c <- rejectedEndpoint(endpoint, err)
+		return
+	}
+
 	if jsonRes.Result.NodeInfo.Other.TxIndex == "on" {
 
 		height, err := strconv.Atoi(jsonRes.Result.SyncInfo.LatestBlockHeight)
@@ -123,13 +130,13 @@ func PingJrpc(endpoint string, c chan models.Endpoint) {
 	}
 }
 
-func ProcessJrpc(jrpcEndpoints []string) []models.Endpoint {
+func ProcessJrpc(jrpcEndpoints []string, chainID string) []models.Endpoint {
 	// create a channel to receive results for each jrpc endpoint
 	jrpcChannel := make(chan models.Endpoint, len(jrpcEndpoints))
 
 	for _, v := range jrpcEndpoints {
 		// ping jrpc endpoint & get results in a goroutine
-		go PingJrpc(v, jrpcChannel)
+		go PingJrpc(v, chainID, jrpcChannel)
 		// add goroutine to jrpc wait group
 		jwg.Add(1)
 	}
@@ -50,7 +50,9 @@ func PingNonTendermintRest(endpoint string, c chan models.Endpoint) {
 	c <- e
 }
 
-func PingRest(endpoint string, c chan models.Endpoint) {
+// PingRest measures the height and latency of the REST endpoint and
+// verifies that the latest block belongs to the chain.
+func PingRest(endpoint string, chainID string, c chan models.Endpoint) {
 	defer rwg.Done()
 
 	url := fmt.Sprintf("%s/cosmos/base/tendermint/v1beta1/blocks/latest", endpoint)
@@ -89,6 +91,11 @@ func PingRest(endpoint string, c chan models.Endpoint) {
 	var jsonRes models.RestResponse
 	_ = json.Unmarshal(body, &jsonRes)
 
+	if err := verifyChainID(chainID, jsonRes.Block.Header.ChainID); err != nil {
+		c <- rejectedEndpoint(endpoint, err)
+		return
+	}
+
 	height, err := strconv.Atoi(jsonRes.Block.Header.Height)
 	if err != nil {
 		height = -1
@@ -103,15 +110,16 @@ func PingRest(endpoint string, c chan models.Endpoint) {
 	c <- e
 }
 
-func ProcessRest(restEndpoints []string, chainIdentifier string) []models.Endpoint {
+func ProcessRest(restEndpoints []string, chainIdentifier string, chainID string) []models.Endpoint {
 	// create a channel to receive results for each rest endpoint
 	restChannel := make(chan models.Endpoint, len(restEndpoints))
 
 	for _, v := range restEndpoints {
 		// ping REST endpoint & get results in a goroutine
 		if !strings.Contains(strings.ToUpper(chainIdentifier), "GRAVITY") {
-			go PingRest(v, restChannel)
+			go PingRest(v, chainID, restChannel)
 		} else {
+			// NOTE: the chain ID of these endpoints is not verified, they do not serve the blocks
 			go PingNonTendermintRest(v, restChannel)
 		}
 		// add goroutine to rest wait group
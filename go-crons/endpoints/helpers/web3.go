@@ -18,7 +18,9 @@ import (
 
 var wwg sync.WaitGroup
 
-func PingWeb3(endpoint string, c chan models.Endpoint) {
+// PingWeb3 measures the height and latency of the web3 JSON-RPC endpoint
+// and verifies its eth_chainId.
+func PingWeb3(endpoint string, chainID string, c chan models.Endpoint) {
 	defer wwg.Done()
 
 	url := fmt.Sprintf("%s/cosmos/base/tendermint/v1beta1/blocks/latest", endpoint)
@@ -78,6 +80,25 @@ func PingWeb3(endpoint string, c chan models.Endpoint) {
 		return
 	}
 
+	// the chain ID is queried after measuring the latency
+	payload = bytes.NewBuffer([]byte(`{"jsonrpc":"2.0","method":"eth_chainId","params":[],"id":2}`))
+	resp, err = requester.Client.Post(url, "application/json", payload)
+	if err != nil {
+		c <- rejectedEndpoint(endpoint, fmt.Errorf("error querying eth_chainId: %w", err))
+		return
+	}
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		c <- rejectedEndpoint(endpoint, fmt.Errorf("error querying eth_chainId: %w", err))
+		return
+	}
+	var chainIDRes models.Web3Response
+	_ = json.Unmarshal(body, &chainIDRes)
+	if err := verifyEthChainID(chainID, chainIDRes.Result); err != nil {
+		c <- rejectedEndpoint(endpoint, err)
+		return
+	}
+
 	e := models.Endpoint{
 		URL:     endpoint,
 		Latency: duration,
@@ -87,13 +108,13 @@ func PingWeb3(endpoint string, c chan models.Endpoint) {
 	c <- e
 }
 
-func ProcessWeb3(web3Endpoints []string) []models.Endpoint {
+func ProcessWeb3(web3Endpoints []string, chainID string) []models.Endpoint {
 	// create a channel to receive results for each web3 endpoint
 	web3Channel := make(chan models.Endpoint, len(web3Endpoints))
 
 	for _, v := range web3Endpoints {
 		// ping web3 endpoint & get results in a goroutine
-		go PingWeb3(v, web3Channel)
+		go PingWeb3(v, chainID, web3Channel)
 		// add goroutine to web3 wait group
 		wwg.Add(1)
 	}
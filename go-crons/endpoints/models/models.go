@@ -7,12 +7,17 @@ type Endpoint struct {
 	URL     string  `json:"url"`
 	Height  int     `json:"height"`
 	Latency float64 `json:"latency"`
+	// Error is the reason the endpoint was rejected, e.g. a chain ID mismatch
+	Error string `json:"error,omitempty"`
+	// ChainIDMismatch is set when the node reported another chain ID
+	ChainIDMismatch bool `json:"chain_id_mismatch,omitempty"`
 }
 
 type RestResponse struct {
 	Block struct {
 		Header struct {
-			Height string `json:"height"`
+			ChainID string `json:"chain_id"`
+			Height  string `json:"height"`
 		} `json:"header"`
 	} `json:"block"`
 }
@@ -29,7 +34,8 @@ type JrpcStatusResponse struct {
 			LatestBlockHeight string `json:"latest_block_height"`
 		} `json:"sync_info"`
 		NodeInfo struct {
-			Other struct {
+			Network string `json:"network"`
+			Other   struct {
 				TxIndex string `json:"tx_index"`
 			} `json:"other"`
 		} `json:"node_info"`
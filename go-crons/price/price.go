@@ -11,13 +11,14 @@ import (
 	"github.com/tharsis/dashboard-backend/internal/v1/db"
 	"github.com/tharsis/dashboard-backend/internal/v1/requester"
 	"github.com/tharsis/dashboard-backend/internal/v1/resources"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
 )
 
 var running = true
 
-func processAssets(erc20ModuleCoins []resources.CoinConfig) {
+func processAssets(store db.Store, erc20ModuleCoins []resources.CoinConfig) {
 	for _, v := range erc20ModuleCoins {
-		fmt.Println("Getting price for ", v.Name)
+		logging.Default().Debug("Getting price", "token", v.Name)
 
 		res, err := requester.GetRequestPrice(v.CoingeckoID, "usd")
 		if err != nil {
@@ -31,27 +32,42 @@ func processAssets(erc20ModuleCoins []resources.CoinConfig) {
 		price := jsonRes[v.CoingeckoID]["usd"]
 		stringPrice := fmt.Sprintf("%f", price)
 
-		db.RedisSetPrice(v.CoingeckoID, "usd", stringPrice)
-
-		fmt.Printf("Price %s for %s", stringPrice, v.Name)
+		if err := db.RedisSetPrice(store, v.CoingeckoID, "usd", stringPrice); err != nil {
+			logging.Default().Error("Error storing price", "token", v.Name, "error", err)
+		} else {
+			logging.Default().Info("Price updated", "token", v.Name, "price", stringPrice)
+		}
 
 		time.Sleep(5 * time.Second)
 	}
 }
 
 func main() {
+	if _, err := logging.Setup(logging.Config{}.LoadEnv()); err != nil {
+		panic(err)
+	}
+	registrySource, err := requester.NewRegistrySource(requester.RegistryConfig{}.LoadEnv())
+	if err != nil {
+		panic(err)
+	}
+	requester.SetRegistrySource(registrySource)
+	store := db.NewRedisStoreFromEnv()
+
 	for running {
-		fmt.Println("Fetching ERC20 tokens...")
+		logging.Default().Info("Fetching ERC20 tokens...")
 
-		erc20ModuleCoins, err := resources.GetERC20Tokens()
+		erc20ModuleCoins, err := resources.GetERC20Tokens(store)
 		if err != nil {
 			// TODO: Add retries and report error to sentry??
 			panic(err)
 		}
 
-		fmt.Println("Getting prices...")
+		logging.Default().Info("Getting prices...")
 
-		processAssets(erc20ModuleCoins)
+		processAssets(store, erc20ModuleCoins)
+		if err := db.RedisSetPricesUpdatedAt(store, time.Now()); err != nil {
+			logging.Default().Error("Error storing prices update time", "error", err)
+		}
 
 		time.Sleep(5 * time.Second)
 	}
@@ -4,6 +4,7 @@
 package blockchain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -18,10 +19,13 @@ import (
 
 	"github.com/tharsis/dashboard-backend/internal/v1/db"
 	"github.com/tharsis/dashboard-backend/internal/v1/requester"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/tharsis/dashboard-backend/internal/v2/requestctx"
 )
 
-func GetERC20Balance(contract string, wallet string) (string, error) {
-	cache, err := db.RedisGetERC20Balance(contract, wallet)
+func GetERC20Balance(ctx context.Context, store db.Store, contract string, wallet string) (string, error) {
+	cacheChain := db.EnvironmentChain(requestctx.Environment(ctx), "EVMOS")
+	cache, err := db.RedisGetERC20Balance(store, cacheChain, contract, wallet)
 	if err == nil {
 		return cache, nil
 	}
@@ -38,7 +42,7 @@ func GetERC20Balance(contract string, wallet string) (string, error) {
 	sb.WriteString(`"}, "latest"], "id":1,"jsonrpc":"2.0"}`)
 	jsonBody := []byte(sb.String())
 
-	val, err := requester.MakePostRequest("EVMOS", "web3", "/", jsonBody)
+	val, err := requester.MakePostRequest(ctx, store, "EVMOS", "web3", "/", jsonBody)
 	if err != nil {
 		return "", err
 	}
@@ -53,7 +57,9 @@ func GetERC20Balance(contract string, wallet string) (string, error) {
 		if k == "result" {
 			m := new(big.Int)
 			m.SetString(v.(string), 0)
-			db.RedisSetERC20Balance(contract, wallet, m.String())
+			if err := db.RedisSetERC20Balance(store, cacheChain, contract, wallet, m.String()); err != nil {
+				logging.FromContext(ctx).Warn("Error caching ERC20 balance", "error", err)
+			}
 			return m.String(), nil
 		}
 	}
@@ -4,11 +4,13 @@
 package blockchain
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"strconv"
 	"strings"
 
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
 	"github.com/tharsis/dashboard-backend/internal/v1/requester"
 )
 
@@ -159,7 +161,7 @@ func ConvertV1ToV1Beta(proposal V1GovernanceProposal) (GovernanceProposal, error
 	return v1beta1Prop, nil
 }
 
-func GetProposalsTally(proposals []V1GovernanceProposal) ([]GovernanceProposal, error) {
+func GetProposalsTally(ctx context.Context, store db.Store, proposals []V1GovernanceProposal) ([]GovernanceProposal, error) {
 	// We don't know the length of the proposals in voting period so we can't create an array with a fixed length
 	proposalsWithTally := []GovernanceProposal{}
 	for _, v := range proposals {
@@ -172,7 +174,7 @@ func GetProposalsTally(proposals []V1GovernanceProposal) ([]GovernanceProposal,
 			sb.WriteString("/tally")
 			endpoint := sb.String()
 
-			val, err := requester.MakeGetRequest("EVMOS", "rest", endpoint)
+			val, err := requester.MakeGetRequest(ctx, store, "EVMOS", "rest", endpoint)
 			if err != nil {
 				return nil, err
 			}
@@ -194,7 +196,7 @@ func GetProposalsTally(proposals []V1GovernanceProposal) ([]GovernanceProposal,
 	return proposalsWithTally, nil
 }
 
-func GetV1ProposalsTally(proposals []V1GovernanceProposal) (V1ProposalsResponse, error) {
+func GetV1ProposalsTally(ctx context.Context, store db.Store, proposals []V1GovernanceProposal) (V1ProposalsResponse, error) {
 	proposalsWithTally := []V1GovernanceProposal{}
 	for _, v := range proposals {
 		// Get current proposal tally if proposal is in voting period
@@ -206,7 +208,7 @@ func GetV1ProposalsTally(proposals []V1GovernanceProposal) (V1ProposalsResponse,
 			sb.WriteString("/tally")
 			endpoint := sb.String()
 
-			val, err := requester.MakeGetRequest("EVMOS", "rest", endpoint)
+			val, err := requester.MakeGetRequest(ctx, store, "EVMOS", "rest", endpoint)
 			if err != nil {
 				return V1ProposalsResponse{}, err
 			}
@@ -227,7 +229,7 @@ func GetV1ProposalsTally(proposals []V1GovernanceProposal) (V1ProposalsResponse,
 	sb.WriteString("/cosmos/gov/v1/params/tallying")
 	endpoint := sb.String()
 
-	val, err := requester.MakeGetRequest("EVMOS", "rest", endpoint)
+	val, err := requester.MakeGetRequest(ctx, store, "EVMOS", "rest", endpoint)
 	if err != nil {
 		return V1ProposalsResponse{}, err
 	}
@@ -12,4 +12,11 @@ const (
 	AXELAR  = "AXELAR"
 )
 
-const Mainnet = "mainnet"
+// Configuration types of the registry, the environments the API can serve.
+const (
+	Mainnet = "mainnet"
+	Testnet = "testnet"
+)
+
+// Environments are the environments the clients can select.
+var Environments = []string{Mainnet, Testnet}
@@ -15,26 +15,18 @@ func buildAirtableKey(key string, url string) string {
 	return sb.String()
 }
 
-func RedisGetAirtableRequest(path string) (string, error) {
-	val, err := rdb.Get(ctxRedis, path).Result()
-	return formatRedisResponse(val, err)
+func RedisGetAirtableRequest(s Store, path string) (string, error) {
+	return s.Get(ctxRedis, path)
 }
 
-func RedisSetAirtableRequest(result string, path string) {
-	err := rdb.Set(ctxRedis, path, result, time.Duration(15*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+func RedisSetAirtableRequest(s Store, result string, path string) error {
+	return s.Set(ctxRedis, path, result, time.Duration(15*int(time.Second)))
 }
 
-func RedisGetAirtableFallbackRequest(path string) (string, error) {
-	val, err := rdb.Get(ctxRedis, buildAirtableKey("airtableFallback", path)).Result()
-	return formatRedisResponse(val, err)
+func RedisGetAirtableFallbackRequest(s Store, path string) (string, error) {
+	return s.Get(ctxRedis, buildAirtableKey("airtableFallback", path))
 }
 
-func RedisSetAirtableFallbackRequest(result string, path string) {
-	err := rdb.Set(ctxRedis, buildAirtableKey("airtableFallback", path), result, time.Duration(oneDayExpiration*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+func RedisSetAirtableFallbackRequest(s Store, result string, path string) error {
+	return s.Set(ctxRedis, buildAirtableKey("airtableFallback", path), result, time.Duration(oneDayExpiration*int(time.Second)))
 }
@@ -0,0 +1,75 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package db
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// API keys usage counters are kept for two days so the previous day
+// can still be inspected after midnight
+const apiKeyUsageExpiration = 48 * time.Hour
+
+func buildKeyAPIKey(kind string, value string) string {
+	var sb strings.Builder
+	sb.WriteString("apikey|")
+	sb.WriteString(kind)
+	sb.WriteString("|")
+	sb.WriteString(value)
+	return sb.String()
+}
+
+// RedisSetAPIKey stores the API key record and the index from the key hash to its id.
+func RedisSetAPIKey(s Store, id string, hash string, record string) error {
+	if err := s.Set(ctxRedis, buildKeyAPIKey("id", id), record, 0); err != nil {
+		return err
+	}
+	return s.Set(ctxRedis, buildKeyAPIKey("hash", hash), id, 0)
+}
+
+// RedisUpdateAPIKey overwrites an existing API key record.
+func RedisUpdateAPIKey(s Store, id string, record string) error {
+	return s.Set(ctxRedis, buildKeyAPIKey("id", id), record, 0)
+}
+
+func RedisGetAPIKey(s Store, id string) (string, error) {
+	return s.Get(ctxRedis, buildKeyAPIKey("id", id))
+}
+
+func RedisGetAPIKeyIDByHash(s Store, hash string) (string, error) {
+	return s.Get(ctxRedis, buildKeyAPIKey("hash", hash))
+}
+
+// RedisGetAPIKeyIDs returns the ids of all the stored API keys.
+func RedisGetAPIKeyIDs(s Store) ([]string, error) {
+	prefix := buildKeyAPIKey("id", "")
+	keys, err := s.Scan(ctxRedis, prefix)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, strings.TrimPrefix(key, prefix))
+	}
+	return ids, nil
+}
+
+// RedisIncrAPIKeyUsage increments the usage counter of the API key for the given day.
+func RedisIncrAPIKeyUsage(s Store, id string, day string) (int64, error) {
+	return s.Incr(ctxRedis, buildKeyAPIKey("usage", id+"|"+day), apiKeyUsageExpiration)
+}
+
+// RedisGetAPIKeyUsage returns the usage counter of the API key for the given day.
+func RedisGetAPIKeyUsage(s Store, id string, day string) (int64, error) {
+	val, err := s.Get(ctxRedis, buildKeyAPIKey("usage", id+"|"+day))
+	if err == ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(val, 10, 64)
+}
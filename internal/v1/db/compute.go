@@ -0,0 +1,139 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"golang.org/x/sync/singleflight"
+)
+
+// computeLockPollInterval is how often the value is checked while another
+// instance holds the compute lock.
+const computeLockPollInterval = 100 * time.Millisecond
+
+// defaultComputeLockTTL bounds the time a value is computed under the lock
+// before the waiting instances compute it themselves.
+const defaultComputeLockTTL = 30 * time.Second
+
+// computeGroup deduplicates the concurrent computations of the same key.
+var computeGroup singleflight.Group
+
+// ComputeOptions configures GetOrCompute.
+type ComputeOptions struct {
+	// TTL of the computed value, zero means no expiration.
+	TTL time.Duration
+	// LockTTL enables a lock in the store so a single instance computes the
+	// value, the others wait for it up to LockTTL. Zero disables the lock.
+	LockTTL time.Duration
+	// Valid reports whether a stored value can be served, invalid values are
+	// computed again. All the values are valid if it is nil.
+	Valid func(string) bool
+}
+
+// GetOrCompute returns the value stored at key or computes and stores it.
+// Concurrent calls for the same key in the process share a single computation.
+func GetOrCompute(ctx context.Context, s Store, key string, opts ComputeOptions, compute func() (string, error)) (string, error) {
+	if val, ok := getValid(ctx, s, key, opts); ok {
+		return val, nil
+	}
+
+	// Keys are only shared by callers using the same store.
+	res, err, _ := computeGroup.Do(fmt.Sprintf("%p|%s", s, key), func() (interface{}, error) {
+		// The value may have been stored while waiting for the group
+		if val, ok := getValid(ctx, s, key, opts); ok {
+			return val, nil
+		}
+
+		if opts.LockTTL > 0 {
+			unlock, val, ok := waitComputeLock(ctx, s, key, opts)
+			if ok {
+				return val, nil
+			}
+			defer unlock()
+		}
+
+		val, err := compute()
+		if err != nil {
+			return "", err
+		}
+		if err := s.Set(ctx, key, val, opts.TTL); err != nil {
+			logging.FromContext(ctx).Warn("Error caching computed value", "key", key, "error", err)
+		}
+		return val, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.(string), nil
+}
+
+func getValid(ctx context.Context, s Store, key string, opts ComputeOptions) (string, bool) {
+	val, err := s.Get(ctx, key)
+	if err != nil || (opts.Valid != nil && !opts.Valid(val)) {
+		return "", false
+	}
+	return val, true
+}
+
+// waitComputeLock takes the compute lock of key. If another instance holds it,
+// it waits for the value to be stored and returns it with ok set.
+// Otherwise the returned function releases the lock, if it was taken.
+func waitComputeLock(ctx context.Context, s Store, key string, opts ComputeOptions) (unlock func(), val string, ok bool) {
+	lockKey := "lock|" + key
+	token, err := newLockToken()
+	if err != nil {
+		logging.FromContext(ctx).Warn("Error creating compute lock token", "key", key, "error", err)
+		return func() {}, "", false
+	}
+
+	deadline := time.Now().Add(opts.LockTTL)
+	for {
+		acquired, err := s.SetNX(ctx, lockKey, token, opts.LockTTL)
+		if err != nil {
+			// Compute without the lock rather than failing the request
+			logging.FromContext(ctx).Warn("Error taking compute lock", "key", key, "error", err)
+			return func() {}, "", false
+		}
+		if acquired {
+			unlock := func() {
+				if err := s.DeleteIfEqual(ctx, lockKey, token); err != nil {
+					logging.FromContext(ctx).Warn("Error releasing compute lock", "key", key, "error", err)
+				}
+			}
+			// The previous holder may have stored the value before releasing the lock
+			if val, ok := getValid(ctx, s, key, opts); ok {
+				unlock()
+				return nil, val, true
+			}
+			return unlock, "", false
+		}
+
+		if val, ok := getValid(ctx, s, key, opts); ok {
+			return nil, val, true
+		}
+		if time.Now().After(deadline) {
+			return func() {}, "", false
+		}
+
+		select {
+		case <-ctx.Done():
+			return func() {}, "", false
+		case <-time.After(computeLockPollInterval):
+		}
+	}
+}
+
+func newLockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
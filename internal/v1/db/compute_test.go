@@ -0,0 +1,114 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package db
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetOrCompute(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(100)
+
+	var calls int32
+	release := make(chan struct{})
+	compute := func() (string, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "value", nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]string, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			val, err := GetOrCompute(ctx, store, "key", ComputeOptions{}, compute)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			results[i] = val
+		}(i)
+	}
+	// Let the goroutines join the computation before it completes
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("expected a single computation, got %d", calls)
+	}
+	for _, val := range results {
+		if val != "value" {
+			t.Fatalf("expected value, got %q", val)
+		}
+	}
+	if val, err := store.Get(ctx, "key"); err != nil || val != "value" {
+		t.Fatalf("expected the value to be stored, got %q, %v", val, err)
+	}
+
+	// Errors are not cached
+	errCompute := errors.New("upstream failed")
+	if _, err := GetOrCompute(ctx, store, "other", ComputeOptions{}, func() (string, error) {
+		return "", errCompute
+	}); !errors.Is(err, errCompute) {
+		t.Fatalf("expected the compute error, got %v", err)
+	}
+	if _, err := store.Get(ctx, "other"); err != ErrNotFound {
+		t.Fatalf("expected nothing stored, got %v", err)
+	}
+
+	// Invalid stored values are computed again
+	if err := store.Set(ctx, "list", "[]", 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	opts := ComputeOptions{Valid: func(val string) bool { return val != "[]" }}
+	val, err := GetOrCompute(ctx, store, "list", opts, func() (string, error) {
+		return "[1]", nil
+	})
+	if err != nil || val != "[1]" {
+		t.Fatalf("expected the value to be computed again, got %q, %v", val, err)
+	}
+}
+
+func TestGetOrComputeLock(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(100)
+	opts := ComputeOptions{LockTTL: time.Second}
+
+	// Another instance holds the lock and stores the value
+	if ok, _ := store.SetNX(ctx, "lock|key", "other", time.Second); !ok {
+		t.Fatalf("expected to take the lock")
+	}
+	go func() {
+		time.Sleep(2 * computeLockPollInterval)
+		_ = store.Set(ctx, "key", "from other", 0)
+		_ = store.DeleteIfEqual(ctx, "lock|key", "other")
+	}()
+
+	val, err := GetOrCompute(ctx, store, "key", opts, func() (string, error) {
+		t.Errorf("expected the value of the lock holder")
+		return "", nil
+	})
+	if err != nil || val != "from other" {
+		t.Fatalf("expected the value of the lock holder, got %q, %v", val, err)
+	}
+
+	// The lock is released after computing
+	val, err = GetOrCompute(ctx, store, "new", opts, func() (string, error) {
+		return "computed", nil
+	})
+	if err != nil || val != "computed" {
+		t.Fatalf("expected the value to be computed, got %q, %v", val, err)
+	}
+	if ok, _ := store.SetNX(ctx, "lock|new", "next", time.Second); !ok {
+		t.Fatalf("expected the lock to be released")
+	}
+}
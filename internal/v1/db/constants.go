@@ -10,7 +10,8 @@ import (
 	"github.com/go-redis/redis/v9"
 )
 
-func getRedisHost() *redis.Client {
+// NewRedisClientFromEnv creates a Redis client for the REDIS_HOST server, localhost by default.
+func NewRedisClientFromEnv() *redis.Client {
 	host, set := os.LookupEnv("REDIS_HOST")
 	if !set {
 		host = "localhost"
@@ -23,20 +24,6 @@ func getRedisHost() *redis.Client {
 	})
 }
 
-var (
-	ctxRedis = context.Background()
-	rdb      = getRedisHost()
-)
+var ctxRedis = context.Background()
 
 var expiration = 7
-
-func formatRedisResponse(val string, err error) (string, error) {
-	switch err {
-	case redis.Nil:
-		return "", err
-	case nil:
-		return val, nil
-	default:
-		return "", err
-	}
-}
@@ -14,76 +14,54 @@ var (
 	expirationValidatorWithNoFilter = 60
 )
 
-func RedisSetValidatorWithNoFilter(chain string, result string) {
+// RedisGetOrComputeValidatorWithNoFilter returns the cached validators of the chain
+// or builds them with compute.
+func RedisGetOrComputeValidatorWithNoFilter(s Store, chain string, compute func() (string, error)) (string, error) {
 	// Using 1 hour as cache, creating this object takes too much time and the api response will not change very often
-	err := rdb.Set(ctxRedis, chain+validatorWithNoFilterKey, result, time.Duration(expirationValidatorWithNoFilter*int(time.Minute))).Err()
-	if err != nil {
-		panic(err)
-	}
+	return GetOrCompute(ctxRedis, s, chain+validatorWithNoFilterKey, ComputeOptions{
+		TTL:     time.Duration(expirationValidatorWithNoFilter * int(time.Minute)),
+		LockTTL: defaultComputeLockTTL,
+	}, compute)
 }
 
-func RedisGetValidatorWithNoFilter(chain string) (string, error) {
-	val, err := rdb.Get(ctxRedis, chain+validatorWithNoFilterKey).Result()
-	return formatRedisResponse(val, err)
+func RedisSetUnbondingByAddressWithValidatorInfo(s Store, chain string, address string, result string) error {
+	return s.Set(ctxRedis, chain+address+validatorWithNoFilterKey, result, time.Duration(expiration*int(time.Second)))
 }
 
-func RedisSetUnbondingByAddressWithValidatorInfo(chain string, address string, result string) {
-	err := rdb.Set(ctxRedis, chain+address+validatorWithNoFilterKey, result, time.Duration(expiration*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+func RedisGetUnbondingByAddressWithValidatorInfo(s Store, chain string, address string) (string, error) {
+	return s.Get(ctxRedis, chain+address+validatorWithNoFilterKey)
 }
 
-func RedisGetUnbondingByAddressWithValidatorInfo(chain string, address string) (string, error) {
-	val, err := rdb.Get(ctxRedis, chain+address+validatorWithNoFilterKey).Result()
-	return formatRedisResponse(val, err)
-}
-
-func RedisSetValidatorWithRanks(chain string, result string) {
+// RedisGetOrComputeValidatorWithRanks returns the cached ranked validators of the chain
+// or builds them with compute.
+func RedisGetOrComputeValidatorWithRanks(s Store, chain string, compute func() (string, error)) (string, error) {
 	// Using 1 hour as cache, creating this object takes too much time and the api response will not change very often
-	err := rdb.Set(ctxRedis, chain+validatorWithRanks, result, time.Duration(expirationValidatorWithNoFilter*int(time.Minute))).Err()
-	if err != nil {
-		panic(err)
-	}
-}
-
-func RedisGetValidatorWithRanks(chain string) (string, error) {
-	val, err := rdb.Get(ctxRedis, chain+validatorWithRanks).Result()
-	return formatRedisResponse(val, err)
+	return GetOrCompute(ctxRedis, s, chain+validatorWithRanks, ComputeOptions{
+		TTL:     time.Duration(expirationValidatorWithNoFilter * int(time.Minute)),
+		LockTTL: defaultComputeLockTTL,
+	}, compute)
 }
 
-func RedisSetDelegationsByAddressWithValidatorRanks(chain string, address string, result string) {
-	err := rdb.Set(ctxRedis, chain+address+delegationsWithRanks, result, time.Duration(expiration*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+func RedisSetDelegationsByAddressWithValidatorRanks(s Store, chain string, address string, result string) error {
+	return s.Set(ctxRedis, chain+address+delegationsWithRanks, result, time.Duration(expiration*int(time.Second)))
 }
 
-func RedisGetDelegationsByAddressWithValidatorRanks(chain string, address string) (string, error) {
-	val, err := rdb.Get(ctxRedis, chain+address+delegationsWithRanks).Result()
-	return formatRedisResponse(val, err)
+func RedisGetDelegationsByAddressWithValidatorRanks(s Store, chain string, address string) (string, error) {
+	return s.Get(ctxRedis, chain+address+delegationsWithRanks)
 }
 
-func RedisSetValidatorsByAddressWithValidatorRanks(chain string, address string, result string) {
-	err := rdb.Set(ctxRedis, chain+address+byAddrWithRanks, result, time.Duration(expiration*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+func RedisSetValidatorsByAddressWithValidatorRanks(s Store, chain string, address string, result string) error {
+	return s.Set(ctxRedis, chain+address+byAddrWithRanks, result, time.Duration(expiration*int(time.Second)))
 }
 
-func RedisGetValidatorsByAddressWithValidatorRanks(chain string, address string) (string, error) {
-	val, err := rdb.Get(ctxRedis, chain+address+byAddrWithRanks).Result()
-	return formatRedisResponse(val, err)
+func RedisGetValidatorsByAddressWithValidatorRanks(s Store, chain string, address string) (string, error) {
+	return s.Get(ctxRedis, chain+address+byAddrWithRanks)
 }
 
-func RedisSetAllValidators(chain string, result string) {
-	err := rdb.Set(ctxRedis, chain+allValidators, result, time.Duration(expiration*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+func RedisSetAllValidators(s Store, chain string, result string) error {
+	return s.Set(ctxRedis, chain+allValidators, result, time.Duration(expiration*int(time.Second)))
 }
 
-func RedisGetAllValidators(chain string) (string, error) {
-	val, err := rdb.Get(ctxRedis, chain+allValidators).Result()
-	return formatRedisResponse(val, err)
+func RedisGetAllValidators(s Store, chain string) (string, error) {
+	return s.Get(ctxRedis, chain+allValidators)
 }
@@ -3,10 +3,75 @@
 package db
 
 import (
-	"context"
+	"encoding/json"
+	"fmt"
 	"strings"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/constants"
 )
 
+// EndpointMaxAge is the age after which a ranked endpoint is stale,
+// the endpoint cron checks every endpoint well within it.
+const EndpointMaxAge = 15 * time.Minute
+
+// EndpointRanking is the ranking of the endpoints of a chain for an endpoint
+// type, published at once by the endpoint cron.
+type EndpointRanking struct {
+	// Version is incremented on every publication
+	Version   int64     `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Endpoints are sorted by score, best first
+	Endpoints []RankedEndpoint `json:"endpoints"`
+}
+
+// RankedEndpoint is the result of the last check of an endpoint.
+type RankedEndpoint struct {
+	URL    string `json:"url"`
+	Height int    `json:"height"`
+	// Latency in seconds
+	Latency     float64   `json:"latency"`
+	LastChecked time.Time `json:"last_checked"`
+	Score       float64   `json:"score"`
+}
+
+// Best returns up to n endpoints checked within maxAge, best first.
+// If every endpoint is stale they are all returned rather than none.
+// A non positive n returns every endpoint.
+func (r EndpointRanking) Best(n int, maxAge time.Duration, now time.Time) []RankedEndpoint {
+	endpoints := make([]RankedEndpoint, 0, len(r.Endpoints))
+	for _, e := range r.Endpoints {
+		if now.Sub(e.LastChecked) <= maxAge {
+			endpoints = append(endpoints, e)
+		}
+	}
+	if len(endpoints) == 0 {
+		endpoints = append(endpoints, r.Endpoints...)
+	}
+	if n > 0 && len(endpoints) > n {
+		endpoints = endpoints[:n]
+	}
+	return endpoints
+}
+
+// EndpointURLs returns the URLs of the endpoints.
+func EndpointURLs(endpoints []RankedEndpoint) []string {
+	urls := make([]string, 0, len(endpoints))
+	for _, e := range endpoints {
+		urls = append(urls, e.URL)
+	}
+	return urls
+}
+
+// EnvironmentChain returns the name the data of the chain is stored under for
+// the environment, e.g. TESTNET:EVMOS. The mainnet names are not namespaced.
+func EnvironmentChain(environment string, chain string) string {
+	if environment == "" || environment == constants.Mainnet {
+		return chain
+	}
+	return strings.ToUpper(environment) + ":" + chain
+}
+
 func buildKeyEndpoint(chain, endpoint, index string) string {
 	var sb strings.Builder
 	sb.WriteString(strings.ToUpper(chain))
@@ -17,39 +82,80 @@ func buildKeyEndpoint(chain, endpoint, index string) string {
 	return sb.String()
 }
 
-func RedisGetEndpoint(chain, endpoint, index string) (string, error) {
+func buildKeyEndpointRanking(chain, endpointType string) string {
+	return buildKeyEndpoint(chain, endpointType, "ranking")
+}
+
+// RedisGetEndpoint returns the endpoint stored at index. The rankings are read with
+// RedisGetEndpointRanking, the index 0 is used to pin the preferred web3 endpoint.
+func RedisGetEndpoint(s Store, chain, endpoint, index string) (string, error) {
 	key := buildKeyEndpoint(chain, endpoint, index)
-	val, err := rdb.Get(ctxRedis, key).Result()
+	return s.Get(ctxRedis, key)
+}
+
+// RedisSetEndpointRanking publishes the ranking of the chain endpoints with
+// the next version. The ranking is stored as a single value so readers never
+// see a partially updated ranking.
+func RedisSetEndpointRanking(s Store, chain, endpointType string, endpoints []RankedEndpoint, updatedAt time.Time) error {
+	ranking := EndpointRanking{
+		Version:   1,
+		UpdatedAt: updatedAt,
+		Endpoints: endpoints,
+	}
+	if prev, err := RedisGetEndpointRanking(s, chain, endpointType); err == nil {
+		ranking.Version = prev.Version + 1
+	}
+
+	val, err := json.Marshal(ranking)
 	if err != nil {
-		return "", err
+		return fmt.Errorf("error encoding endpoint ranking: %w", err)
 	}
-	return val, nil
+	return s.Set(ctxRedis, buildKeyEndpointRanking(chain, endpointType), string(val), 0)
 }
 
-func RedisGetEndpoints(chain, serverType string) ([]string, error) {
-	ctx := context.Background()
-	match := buildKeyEndpoint(chain, serverType, "*")
-	iter := rdb.Scan(ctx, 0, match, 0).Iterator()
-	var nodes []string
-	for iter.Next(ctx) {
-		rd, err := rdb.Get(ctx, iter.Val()).Result()
-		if err != nil {
-			return nil, err
-		}
-		nodes = append(nodes, rd)
+// RedisGetEndpointRanking returns the ranking of the chain endpoints.
+// The endpoints published one key per index by the previous cron are returned
+// as a version 0 ranking until a ranking is published, updated when that cron
+// last published the chain endpoints.
+func RedisGetEndpointRanking(s Store, chain, endpointType string) (EndpointRanking, error) {
+	val, err := s.Get(ctxRedis, buildKeyEndpointRanking(chain, endpointType))
+	if err == ErrNotFound {
+		return getLegacyEndpointRanking(s, chain, endpointType)
 	}
-
-	if err := iter.Err(); err != nil {
-		return nil, err
+	if err != nil {
+		return EndpointRanking{}, err
 	}
 
-	return nodes, nil
+	var ranking EndpointRanking
+	if err := json.Unmarshal([]byte(val), &ranking); err != nil {
+		return EndpointRanking{}, fmt.Errorf("error decoding endpoint ranking: %w", err)
+	}
+	return ranking, nil
 }
 
-func RedisSetEndpoint(chain, endpoint, index, url string) {
-	key := buildKeyEndpoint(chain, endpoint, index)
-	err := rdb.Set(ctxRedis, key, url, 0).Err()
-	if err != nil {
-		panic(err)
+func getLegacyEndpointRanking(s Store, chain, endpointType string) (EndpointRanking, error) {
+	var ranking EndpointRanking
+	for _, index := range []string{"1", "2", "3"} {
+		url, err := RedisGetEndpoint(s, chain, endpointType, index)
+		if err == ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return EndpointRanking{}, err
+		}
+		ranking.Endpoints = append(ranking.Endpoints, RankedEndpoint{URL: url})
+	}
+	if len(ranking.Endpoints) == 0 {
+		return EndpointRanking{}, ErrNotFound
+	}
+
+	// Stored by the previous cron once the rest endpoints of the chain are published
+	updatedAt, err := parseTimestamp(s.Get(ctxRedis, buildKeyEndpoint(chain, "endpoints", "updated")))
+	switch {
+	case err == nil:
+		ranking.UpdatedAt = updatedAt
+	case err != ErrNotFound:
+		return EndpointRanking{}, err
 	}
+	return ranking, nil
 }
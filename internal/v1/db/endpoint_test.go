@@ -0,0 +1,114 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package db
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEndpointRanking(t *testing.T) {
+	s := NewMemoryStore(100)
+	now := time.Now()
+
+	if _, err := RedisGetEndpointRanking(s, "EVMOS", "rest"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	// Rankings published by the previous cron are still read
+	if err := s.Set(ctxRedis, "EVMOS|rest|1", "https://a", 0); err != nil {
+		t.Fatal(err)
+	}
+	ranking, err := RedisGetEndpointRanking(s, "evmos", "rest")
+	if err != nil || ranking.Version != 0 || len(ranking.Endpoints) != 1 || !ranking.UpdatedAt.IsZero() {
+		t.Fatalf("expected the legacy ranking, got %+v %v", ranking, err)
+	}
+	// with the time the previous cron published it
+	if err := s.Set(ctxRedis, "EVMOS|endpoints|updated", formatTimestamp(now), 0); err != nil {
+		t.Fatal(err)
+	}
+	if ranking, err = RedisGetEndpointRanking(s, "EVMOS", "rest"); err != nil || ranking.UpdatedAt.Unix() != now.Unix() {
+		t.Fatalf("expected the legacy update time, got %+v %v", ranking, err)
+	}
+
+	endpoints := []RankedEndpoint{
+		{URL: "https://a", Height: 10, Latency: 0.1, LastChecked: now, Score: 0.9},
+		{URL: "https://b", Height: 10, Latency: 0.5, LastChecked: now.Add(-time.Hour), Score: 0.6},
+		{URL: "https://c", Height: 9, Latency: 0.2, LastChecked: now, Score: 0.4},
+	}
+	for version := int64(1); version <= 2; version++ {
+		if err := RedisSetEndpointRanking(s, "EVMOS", "rest", endpoints, now); err != nil {
+			t.Fatal(err)
+		}
+		ranking, err = RedisGetEndpointRanking(s, "EVMOS", "rest")
+		if err != nil || ranking.Version != version {
+			t.Fatalf("expected version %d, got %+v %v", version, ranking, err)
+		}
+	}
+	if ranking.Endpoints[0].Height != 10 || ranking.Endpoints[0].Latency != 0.1 || !ranking.UpdatedAt.Equal(now) {
+		t.Fatalf("expected the measurements to be stored, got %+v", ranking)
+	}
+
+	// Stale endpoints are skipped
+	if urls := EndpointURLs(ranking.Best(0, EndpointMaxAge, now)); !reflect.DeepEqual(urls, []string{"https://a", "https://c"}) {
+		t.Fatalf("expected the fresh endpoints, got %v", urls)
+	}
+	if best := EndpointURLs(ranking.Best(1, EndpointMaxAge, now)); !reflect.DeepEqual(best, []string{"https://a"}) {
+		t.Fatalf("expected the best endpoint, got %v", best)
+	}
+	// Every endpoint is returned when they are all stale
+	if best := ranking.Best(0, EndpointMaxAge, now.Add(2*time.Hour)); len(best) != 3 {
+		t.Fatalf("expected every endpoint, got %v", best)
+	}
+}
+
+func TestEndpointHistory(t *testing.T) {
+	s := NewMemoryStore(100)
+	start := time.Now().Truncate(time.Second)
+
+	// The endpoints without checks within the retention are dropped
+	if err := RedisAddEndpointChecks(s, "EVMOS", "rest", map[string]EndpointCheck{"https://old": {CheckedAt: start.Add(-time.Hour)}}, 2*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	down := EndpointCheck{CheckedAt: start.Add(2 * time.Minute), Height: -1, Latency: -1, Lag: -1, Error: "chain ID mismatch", ChainIDMismatch: true}
+	for i := 0; i < 4; i++ {
+		checks := map[string]EndpointCheck{
+			"https://a": {CheckedAt: start.Add(time.Duration(i) * time.Minute), Height: 10 + i, Latency: 0.1, Rank: 1},
+		}
+		if i == 2 {
+			checks["https://b"] = down
+		}
+		if err := RedisAddEndpointChecks(s, "EVMOS", "rest", checks, 2*time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Other chains and types are not returned
+	if err := RedisAddEndpointChecks(s, "TESTNET:EVMOS", "rest", map[string]EndpointCheck{"https://c": down}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := RedisGetEndpointHistory(s, "evmos", "rest", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 || history[0].URL != "https://a" || history[1].URL != "https://b" {
+		t.Fatalf("expected the history of both endpoints, got %+v", history)
+	}
+	// The checks older than the retention are dropped
+	if checks := history[0].Checks; len(checks) != 3 || checks[0].Height != 11 || checks[2].Height != 13 {
+		t.Fatalf("expected the checks within the retention, got %+v", checks)
+	}
+	if got := history[1].Checks[0]; len(history[1].Checks) != 1 || !got.CheckedAt.Equal(down.CheckedAt) || got.Error != down.Error || !got.ChainIDMismatch || got.Lag != -1 {
+		t.Fatalf("expected the check to be stored, got %+v", history[1].Checks[0])
+	}
+
+	history, err = RedisGetEndpointHistory(s, "EVMOS", "rest", start.Add(3*time.Minute))
+	if err != nil || len(history) != 2 || len(history[0].Checks) != 1 || len(history[1].Checks) != 0 {
+		t.Fatalf("expected the checks since the given time, got %+v %v", history, err)
+	}
+	if history, err = RedisGetEndpointHistory(s, "EVMOS", "jrpc", time.Time{}); err != nil || len(history) != 0 {
+		t.Fatalf("expected no history, got %+v %v", history, err)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// EndpointCheck is the result of a check of an endpoint by the endpoint cron.
+type EndpointCheck struct {
+	CheckedAt time.Time `json:"checked_at"`
+	// Height is -1 when the endpoint did not answer or was rejected
+	Height int `json:"height"`
+	// Latency in seconds, -1 when the endpoint did not answer or was rejected
+	Latency float64 `json:"latency"`
+	// Lag is the number of blocks behind the best endpoint of the same type, -1
+	// when the endpoint did not answer or was rejected
+	Lag int `json:"lag"`
+	// Rank is the position of the endpoint in the ranking published with the
+	// check, best first, 0 when it was not published
+	Rank int `json:"rank"`
+	// Error is the reason the endpoint was rejected, e.g. a chain ID mismatch
+	Error string `json:"error,omitempty"`
+	// ChainIDMismatch is set when the node reported another chain ID
+	ChainIDMismatch bool `json:"chain_id_mismatch,omitempty"`
+}
+
+// EndpointHistory is the history of an endpoint, oldest check first.
+type EndpointHistory struct {
+	URL    string          `json:"url"`
+	Checks []EndpointCheck `json:"checks"`
+}
+
+// The checks of each endpoint are stored in a sorted set scored by check time,
+// and the endpoints of a chain and type in a sorted set scored by last check,
+// so the cron instances add their checks without overwriting each other's.
+func buildKeyEndpointHistory(chain, endpointType string) string {
+	return buildKeyEndpoint(chain, endpointType, "history-endpoints")
+}
+
+func buildKeyEndpointChecks(chain, endpointType, url string) string {
+	return buildKeyEndpoint(chain, endpointType, "checks|"+url)
+}
+
+func checkScore(t time.Time) float64 {
+	return float64(t.UnixMilli())
+}
+
+// RedisAddEndpointChecks adds the checks, by endpoint URL, to the history of
+// the endpoints of the chain and drops the checks older than retention. The
+// endpoints that are no longer checked, e.g. removed from the registry, are
+// dropped once they have no check within retention.
+func RedisAddEndpointChecks(s Store, chain, endpointType string, checks map[string]EndpointCheck, retention time.Duration) error {
+	for url, check := range checks {
+		member, err := json.Marshal(check)
+		if err != nil {
+			return fmt.Errorf("error encoding endpoint check: %w", err)
+		}
+		score := checkScore(check.CheckedAt)
+		minScore := checkScore(check.CheckedAt.Add(-retention))
+		if err := s.ZAdd(ctxRedis, buildKeyEndpointChecks(chain, endpointType, url), string(member), score, minScore, retention); err != nil {
+			return err
+		}
+		if err := s.ZAdd(ctxRedis, buildKeyEndpointHistory(chain, endpointType), url, score, minScore, retention); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RedisGetEndpointHistory returns the checks made since the given time of the
+// endpoints of the chain, sorted by URL.
+func RedisGetEndpointHistory(s Store, chain, endpointType string, since time.Time) ([]EndpointHistory, error) {
+	// Every endpoint is listed, even without checks since the given time
+	urls, err := s.ZRange(ctxRedis, buildKeyEndpointHistory(chain, endpointType), math.Inf(-1))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(urls)
+
+	history := make([]EndpointHistory, 0, len(urls))
+	for _, url := range urls {
+		members, err := s.ZRange(ctxRedis, buildKeyEndpointChecks(chain, endpointType, url), checkScore(since))
+		if err != nil {
+			return nil, err
+		}
+		checks := make([]EndpointCheck, 0, len(members))
+		for _, member := range members {
+			var check EndpointCheck
+			if err := json.Unmarshal([]byte(member), &check); err != nil {
+				return nil, fmt.Errorf("error decoding endpoint check: %w", err)
+			}
+			checks = append(checks, check)
+		}
+		history = append(history, EndpointHistory{URL: url, Checks: checks})
+	}
+	return history, nil
+}
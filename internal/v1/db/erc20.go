@@ -38,42 +38,45 @@ func buildKeyERC20Balance(chain string, contract string, address string) string
 	return sb.String()
 }
 
-func RedisSetERC20Balance(contract string, address string, balance string) {
-	key := buildKeyERC20Balance("EVMOS", contract, address)
-	err := rdb.Set(ctxRedis, key, balance, time.Duration(expiration*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+// RedisSetERC20Balance caches the balance of the address on chain, see EnvironmentChain.
+func RedisSetERC20Balance(s Store, chain string, contract string, address string, balance string) error {
+	key := buildKeyERC20Balance(chain, contract, address)
+	return s.Set(ctxRedis, key, balance, time.Duration(expiration*int(time.Second)))
 }
 
-func RedisGetERC20Balance(contract string, address string) (string, error) {
-	key := buildKeyERC20Balance("EVMOS", contract, address)
-	val, err := rdb.Get(ctxRedis, key).Result()
-	return formatRedisResponse(val, err)
+func RedisGetERC20Balance(s Store, chain string, contract string, address string) (string, error) {
+	key := buildKeyERC20Balance(chain, contract, address)
+	return s.Get(ctxRedis, key)
 }
 
-func RedisSetERC20TokensDirectory(result string) {
-	err := rdb.Set(ctxRedis, erc20TokensDirectoryKey, result, time.Duration(oneDayExpiration*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+// RedisGetOrComputeERC20TokensDirectory returns the cached ERC20 tokens directory
+// or builds it with compute. Empty directories are built again.
+func RedisGetOrComputeERC20TokensDirectory(s Store, compute func() (string, error)) (string, error) {
+	return GetOrCompute(ctxRedis, s, erc20TokensDirectoryKey, ComputeOptions{
+		TTL:     time.Duration(oneDayExpiration * int(time.Second)),
+		LockTTL: defaultComputeLockTTL,
+		Valid: func(val string) bool {
+			return val != "null" && val != "[]"
+		},
+	}, compute)
 }
 
-func RedisGetERC20TokensDirectory() (string, error) {
-	val, err := rdb.Get(ctxRedis, erc20TokensDirectoryKey).Result()
-	return formatRedisResponse(val, err)
+func RedisSetERC20TokensByName(s Store, name string, result string) error {
+	key := getErc20TokensDirectoryKeyByName(name)
+	return s.Set(ctxRedis, key, result, time.Duration(expiration*int(time.Second)))
 }
 
-func RedisSetERC20TokensByName(name string, result string) {
+func RedisGetERC20TokensByName(s Store, name string) (string, error) {
 	key := getErc20TokensDirectoryKeyByName(name)
-	err := rdb.Set(ctxRedis, key, result, time.Duration(expiration*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+	return s.Get(ctxRedis, key)
 }
 
-func RedisGetERC20TokensByName(name string) (string, error) {
-	key := getErc20TokensDirectoryKeyByName(name)
-	val, err := rdb.Get(ctxRedis, key).Result()
-	return formatRedisResponse(val, err)
+// RedisDeleteERC20TokensDirectory deletes the ERC20 tokens directory, including
+// the tokens stored by name, so they are built again.
+func RedisDeleteERC20TokensDirectory(s Store) error {
+	keys, err := s.Scan(ctxRedis, erc20TokensDirectoryKey+"-")
+	if err != nil {
+		return err
+	}
+	return s.Delete(ctxRedis, append(keys, erc20TokensDirectoryKey)...)
 }
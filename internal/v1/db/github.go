@@ -15,26 +15,28 @@ func buildGithubKey(id string, url string) string {
 	return sb.String()
 }
 
-func RedisSetGithubResponse(url string, result string) {
-	err := rdb.Set(ctxRedis, buildGithubKey("githubcache", url), result, time.Duration(oneDayExpiration*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+func RedisSetGithubResponse(s Store, url string, result string) error {
+	return s.Set(ctxRedis, buildGithubKey("githubcache", url), result, time.Duration(oneDayExpiration*int(time.Second)))
 }
 
-func RedisGetGithubResponse(url string) (string, error) {
-	val, err := rdb.Get(ctxRedis, buildGithubKey("githubcache", url)).Result()
-	return formatRedisResponse(val, err)
+func RedisGetGithubResponse(s Store, url string) (string, error) {
+	return s.Get(ctxRedis, buildGithubKey("githubcache", url))
 }
 
-func RedisSetGithubFallbackResponse(url string, result string) {
-	err := rdb.Set(ctxRedis, buildGithubKey("githubcachefallback", url), result, time.Duration(twoDaysExpiration*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+func RedisSetGithubFallbackResponse(s Store, url string, result string) error {
+	return s.Set(ctxRedis, buildGithubKey("githubcachefallback", url), result, time.Duration(twoDaysExpiration*int(time.Second)))
 }
 
-func RedisGetHithubFallbackResponse(url string) (string, error) {
-	val, err := rdb.Get(ctxRedis, buildGithubKey("githubcachefallback", url)).Result()
-	return formatRedisResponse(val, err)
+func RedisGetHithubFallbackResponse(s Store, url string) (string, error) {
+	return s.Get(ctxRedis, buildGithubKey("githubcachefallback", url))
+}
+
+// RedisDeleteGithubResponses deletes the cached responses of the URLs starting with prefix.
+// The fallback responses are kept, they are only served while GitHub fails.
+func RedisDeleteGithubResponses(s Store, prefix string) error {
+	keys, err := s.Scan(ctxRedis, buildGithubKey("githubcache", prefix))
+	if err != nil {
+		return err
+	}
+	return s.Delete(ctxRedis, keys...)
 }
@@ -9,26 +9,20 @@ import (
 
 var proposalsKey = "governance-props"
 
-func RedisSetGovernanceProposals(proposals string) {
-	err := rdb.Set(ctxRedis, proposalsKey+"-"+"v1beta1", proposals, time.Duration(60*15*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+func RedisSetGovernanceProposals(s Store, proposals string) error {
+	return s.Set(ctxRedis, proposalsKey+"-"+"v1beta1", proposals, time.Duration(60*15*int(time.Second)))
 }
 
-func RedisGetGovernanceProposals() (string, error) {
-	val, err := rdb.Get(ctxRedis, proposalsKey+"-"+"v1beta1").Result()
-	return formatRedisResponse(val, err)
+func RedisGetGovernanceProposals(s Store) (string, error) {
+	return s.Get(ctxRedis, proposalsKey+"-"+"v1beta1")
 }
 
-func RedisSetGovernanceV1Proposals(proposals string) {
-	err := rdb.Set(ctxRedis, proposalsKey+"-"+"v1", proposals, time.Duration(60*15*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+// RedisSetGovernanceV1Proposals caches the proposals of the environment, the
+// mainnet ones without namespace.
+func RedisSetGovernanceV1Proposals(s Store, environment string, proposals string) error {
+	return s.Set(ctxRedis, EnvironmentChain(environment, proposalsKey+"-"+"v1"), proposals, time.Duration(60*15*int(time.Second)))
 }
 
-func RedisGetGovernanceV1Proposals() (string, error) {
-	val, err := rdb.Get(ctxRedis, proposalsKey+"-"+"v1").Result()
-	return formatRedisResponse(val, err)
+func RedisGetGovernanceV1Proposals(s Store, environment string) (string, error) {
+	return s.Get(ctxRedis, EnvironmentChain(environment, proposalsKey+"-"+"v1"))
 }
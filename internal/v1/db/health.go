@@ -0,0 +1,47 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package db
+
+import (
+	"strconv"
+	"time"
+)
+
+// pricesUpdatedKey stores the unix time of the last price update
+var pricesUpdatedKey = "prices|updated"
+
+// RedisPing checks that the store is reachable.
+func RedisPing(s Store) error {
+	return s.Ping(ctxRedis)
+}
+
+func formatTimestamp(t time.Time) string {
+	return strconv.FormatInt(t.Unix(), 10)
+}
+
+func parseTimestamp(val string, err error) (time.Time, error) {
+	if err != nil {
+		return time.Time{}, err
+	}
+	unix, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(unix, 0), nil
+}
+
+// RedisSetPricesUpdatedAt stores the time the prices were last updated.
+func RedisSetPricesUpdatedAt(s Store, t time.Time) error {
+	return s.Set(ctxRedis, pricesUpdatedKey, formatTimestamp(t), 0)
+}
+
+// RedisGetPricesUpdatedAt returns the time the prices were last updated.
+func RedisGetPricesUpdatedAt(s Store) (time.Time, error) {
+	return parseTimestamp(s.Get(ctxRedis, pricesUpdatedKey))
+}
+
+// RedisGetNetworkConfigUpdatedAt returns the time the network config was last stored.
+func RedisGetNetworkConfigUpdatedAt(s Store) (time.Time, error) {
+	return parseTimestamp(s.Get(ctxRedis, networkConfigUpdatedKey))
+}
@@ -0,0 +1,331 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package db
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memoryCache is a bounded in-process LRU cache used while Redis is unavailable.
+// Entries expire like the Redis keys, a zero TTL means no expiration.
+type memoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+	now        func() time.Time
+}
+
+type memoryEntry struct {
+	key       string
+	value     string
+	expiresAt time.Time
+}
+
+func newMemoryCache(maxEntries int) *memoryCache {
+	return &memoryCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      map[string]*list.Element{},
+		now:        time.Now,
+	}
+}
+
+func (c *memoryCache) set(key string, value string, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setLocked(key, value, ttl)
+}
+
+func (c *memoryCache) setLocked(key string, value string, ttl time.Duration) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.now().Add(ttl)
+	}
+
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*memoryEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.ll.Len() > c.maxEntries {
+		c.removeElement(c.ll.Back())
+	}
+}
+
+func (c *memoryCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return "", false
+	}
+	entry := el.Value.(*memoryEntry)
+	if c.expired(entry) {
+		c.removeElement(el)
+		return "", false
+	}
+	c.ll.MoveToFront(el)
+	return entry.value, true
+}
+
+// keys returns the keys starting with prefix.
+func (c *memoryCache) keys(prefix string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var keys []string
+	for key, el := range c.items {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if c.expired(el.Value.(*memoryEntry)) {
+			c.removeElement(el)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// incr increments the integer stored at key and resets its TTL.
+func (c *memoryCache) incr(key string, ttl time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var val int64
+	if el, ok := c.items[key]; ok && !c.expired(el.Value.(*memoryEntry)) {
+		var err error
+		val, err = strconv.ParseInt(el.Value.(*memoryEntry).value, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("value of %s is not an integer", key)
+		}
+	}
+	val++
+
+	c.setLocked(key, strconv.FormatInt(val, 10), ttl)
+	return val, nil
+}
+
+// setNX stores the value only if the key is not set and reports whether it did.
+func (c *memoryCache) setNX(key string, value string, ttl time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok && !c.expired(el.Value.(*memoryEntry)) {
+		return false
+	}
+	c.setLocked(key, value, ttl)
+	return true
+}
+
+func (c *memoryCache) delete(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := c.items[key]; ok {
+			c.removeElement(el)
+		}
+	}
+}
+
+// deleteIfEqual removes the key only if it holds value.
+func (c *memoryCache) deleteIfEqual(key string, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok && el.Value.(*memoryEntry).value == value {
+		c.removeElement(el)
+	}
+}
+
+func (c *memoryCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+func (c *memoryCache) expired(entry *memoryEntry) bool {
+	return !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt)
+}
+
+func (c *memoryCache) removeElement(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*memoryEntry).key)
+}
+
+// MemoryStore stores the values in memory, it is meant for tests and local
+// development without Redis. The least recently used values are evicted once
+// maxEntries values are stored.
+type MemoryStore struct {
+	cache *memoryCache
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	sets    map[string]*sortedSet
+}
+
+type tokenBucket struct {
+	tokens float64
+	ts     time.Time
+}
+
+type sortedSet struct {
+	scores    map[string]float64
+	expiresAt time.Time
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore(maxEntries int) *MemoryStore {
+	return &MemoryStore{
+		cache:   newMemoryCache(maxEntries),
+		buckets: map[string]*tokenBucket{},
+		sets:    map[string]*sortedSet{},
+	}
+}
+
+func (s *MemoryStore) Get(_ context.Context, key string) (string, error) {
+	val, ok := s.cache.get(key)
+	if !ok {
+		return "", ErrNotFound
+	}
+	return val, nil
+}
+
+func (s *MemoryStore) Set(_ context.Context, key string, value string, ttl time.Duration) error {
+	s.cache.set(key, value, ttl)
+	return nil
+}
+
+func (s *MemoryStore) Scan(_ context.Context, prefix string) ([]string, error) {
+	return s.cache.keys(prefix), nil
+}
+
+func (s *MemoryStore) SetNX(_ context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	return s.cache.setNX(key, value, ttl), nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, keys ...string) error {
+	s.cache.delete(keys...)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		delete(s.sets, key)
+	}
+	return nil
+}
+
+func (s *MemoryStore) DeleteIfEqual(_ context.Context, key string, value string) error {
+	s.cache.deleteIfEqual(key, value)
+	return nil
+}
+
+func (s *MemoryStore) Incr(_ context.Context, key string, ttl time.Duration) (int64, error) {
+	return s.cache.incr(key, ttl)
+}
+
+// TakeToken implements the same token bucket as the Redis script.
+func (s *MemoryStore) TakeToken(_ context.Context, key string, rate float64, burst int) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.cache.now()
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(burst), ts: now}
+		s.buckets[key] = bucket
+	}
+
+	elapsed := math.Max(0, now.Sub(bucket.ts).Seconds())
+	bucket.tokens = math.Min(float64(burst), bucket.tokens+elapsed*rate)
+	bucket.ts = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0, nil
+	}
+	retry := time.Duration(math.Ceil((1-bucket.tokens)/rate*1000)) * time.Millisecond
+	return false, retry, nil
+}
+
+func (s *MemoryStore) ZAdd(_ context.Context, key string, member string, score float64, minScore float64, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	set := s.sortedSetLocked(key)
+	if set == nil {
+		set = &sortedSet{scores: map[string]float64{}}
+		s.sets[key] = set
+	}
+	set.scores[member] = score
+	for m, sc := range set.scores {
+		if sc < minScore {
+			delete(set.scores, m)
+		}
+	}
+	if ttl > 0 {
+		set.expiresAt = s.cache.now().Add(ttl)
+	}
+	return nil
+}
+
+func (s *MemoryStore) ZRange(_ context.Context, key string, minScore float64) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	set := s.sortedSetLocked(key)
+	if set == nil {
+		return []string{}, nil
+	}
+	members := make([]string, 0, len(set.scores))
+	for m, sc := range set.scores {
+		if sc >= minScore {
+			members = append(members, m)
+		}
+	}
+	// Same order as Redis, by score then lexicographically
+	sort.Slice(members, func(i, j int) bool {
+		a, b := set.scores[members[i]], set.scores[members[j]]
+		if a != b {
+			return a < b
+		}
+		return members[i] < members[j]
+	})
+	return members, nil
+}
+
+// sortedSetLocked returns the sorted set stored at key, nil if it does not
+// exist or expired.
+func (s *MemoryStore) sortedSetLocked(key string) *sortedSet {
+	set, ok := s.sets[key]
+	if !ok {
+		return nil
+	}
+	if !set.expiresAt.IsZero() && !s.cache.now().Before(set.expiresAt) {
+		delete(s.sets, key)
+		return nil
+	}
+	return set
+}
+
+func (s *MemoryStore) Ping(_ context.Context) error {
+	return nil
+}
@@ -7,13 +7,19 @@ import (
 	"fmt"
 	"os"
 	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
 )
 
 // networkConfigKey represents the Redis key for the network config
 var networkConfigKey string
 
+// networkConfigUpdatedKey stores the unix time of the last network config update
+var networkConfigUpdatedKey string
+
 func init() {
 	networkConfigKey = getNetworkConfigKey()
+	networkConfigUpdatedKey = networkConfigKey + "-updated"
 }
 
 func getNetworkConfigKey() string {
@@ -28,28 +34,49 @@ func getNetworkConfigKeyByName(name string) string {
 	return fmt.Sprintf("%s-%s", networkConfigKey, name)
 }
 
-func RedisSetNetworkConfig(result string) {
-	err := rdb.Set(ctxRedis, networkConfigKey, result, time.Duration(expiration*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+// RedisGetOrComputeNetworkConfig returns the cached network configs or builds
+// them with compute, recording when they were last updated.
+func RedisGetOrComputeNetworkConfig(s Store, compute func() (string, error)) (string, error) {
+	return GetOrCompute(ctxRedis, s, networkConfigKey, ComputeOptions{
+		TTL:     time.Duration(expiration * int(time.Second)),
+		LockTTL: defaultComputeLockTTL,
+		Valid: func(val string) bool {
+			return val != "null"
+		},
+	}, func() (string, error) {
+		val, err := compute()
+		if err != nil {
+			return "", err
+		}
+		if err := s.Set(ctxRedis, networkConfigUpdatedKey, formatTimestamp(time.Now()), 0); err != nil {
+			logging.Default().Warn("Error storing network config update time", "error", err)
+		}
+		return val, nil
+	})
 }
 
-func RedisGetNetworkConfig() (string, error) {
-	val, err := rdb.Get(ctxRedis, networkConfigKey).Result()
-	return formatRedisResponse(val, err)
+func RedisSetNetworkConfigByName(s Store, name string, result string) error {
+	key := getNetworkConfigKeyByName(name)
+	return s.Set(ctxRedis, key, result, time.Duration(expiration*int(time.Second)))
 }
 
-func RedisSetNetworkConfigByName(name string, result string) {
+func RedisGetNetworkConfigByName(s Store, name string) (string, error) {
 	key := getNetworkConfigKeyByName(name)
-	err := rdb.Set(ctxRedis, key, result, time.Duration(expiration*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+	return s.Get(ctxRedis, key)
 }
 
-func RedisGetNetworkConfigByName(name string) (string, error) {
-	key := getNetworkConfigKeyByName(name)
-	val, err := rdb.Get(ctxRedis, key).Result()
-	return formatRedisResponse(val, err)
+// RedisDeleteNetworkConfig deletes the network configs, including the ones
+// stored by name, so they are built again. The last update time is kept.
+func RedisDeleteNetworkConfig(s Store) error {
+	keys, err := s.Scan(ctxRedis, networkConfigKey+"-")
+	if err != nil {
+		return err
+	}
+	toDelete := []string{networkConfigKey}
+	for _, key := range keys {
+		if key != networkConfigUpdatedKey {
+			toDelete = append(toDelete, key)
+		}
+	}
+	return s.Delete(ctxRedis, toDelete...)
 }
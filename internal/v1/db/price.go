@@ -16,21 +16,16 @@ func buildKeyPrice(asset string, vsCurrency string) string {
 	return sb.String()
 }
 
-func RedisGetPrice(asset string, vsCurrency string) (string, error) {
+func RedisGetPrice(s Store, asset string, vsCurrency string) (string, error) {
 	key := buildKeyPrice(asset, vsCurrency)
-	val, err := rdb.Get(ctxRedis, key).Result()
-	return formatRedisResponse(val, err)
+	return s.Get(ctxRedis, key)
 }
 
-func RedisGet24HChange(asset string) (string, error) {
-	val, err := rdb.Get(ctxRedis, asset+"|24h|change").Result()
-	return formatRedisResponse(val, err)
+func RedisGet24HChange(s Store, asset string) (string, error) {
+	return s.Get(ctxRedis, asset+"|24h|change")
 }
 
-func RedisSetPrice(asset string, vsCurrency string, price string) {
+func RedisSetPrice(s Store, asset string, vsCurrency string, price string) error {
 	key := buildKeyPrice(asset, vsCurrency)
-	err := rdb.Set(ctxRedis, key, price, 0).Err()
-	if err != nil {
-		panic(err)
-	}
+	return s.Set(ctxRedis, key, price, 0)
 }
@@ -5,9 +5,113 @@ package db
 
 import (
 	"strings"
+	"sync"
 	"time"
 )
 
+// ProxyCacheConfig represents the cache of the node responses.
+// The responses are fresh for TTL and are kept as fallback for StaleTTL.
+type ProxyCacheConfig struct {
+	// PROXY_CACHE_STALE_WHILE_REVALIDATE: serve the fallback response while the
+	// fresh one is refreshed in the background instead of waiting for the nodes
+	StaleWhileRevalidate bool `toml:"stale_while_revalidate"`
+	// TTL of the fresh responses, 7s when zero
+	TTL time.Duration `toml:"ttl"`
+	// TTL of the fallback responses, 7m when zero
+	StaleTTL time.Duration `toml:"stale_ttl"`
+	// TTLs per endpoint pattern, the first match is applied
+	Rules []ProxyCacheRule `toml:"rules"`
+}
+
+// ProxyCacheRule overrides the TTLs of the endpoints matching Pattern,
+// where * matches any sequence of characters, e.g. /cosmos/gov/*.
+// Zero TTLs keep the default ones. NoStale endpoints always wait for the nodes
+// once the fresh response expired, e.g. the account sequences.
+type ProxyCacheRule struct {
+	Pattern  string        `toml:"pattern"`
+	TTL      time.Duration `toml:"ttl"`
+	StaleTTL time.Duration `toml:"stale_ttl"`
+	NoStale  bool          `toml:"no_stale"`
+}
+
+var (
+	proxyCacheMu  sync.RWMutex
+	proxyCacheCfg ProxyCacheConfig
+)
+
+// SetProxyCacheConfig sets the configuration used by the proxy cache functions.
+func SetProxyCacheConfig(cfg ProxyCacheConfig) {
+	proxyCacheMu.Lock()
+	defer proxyCacheMu.Unlock()
+	proxyCacheCfg = cfg
+}
+
+// ProxyStaleWhileRevalidate reports whether the fallback response of url is
+// served while the fresh one is refreshed.
+func ProxyStaleWhileRevalidate(url string) bool {
+	proxyCacheMu.RLock()
+	defer proxyCacheMu.RUnlock()
+	if !proxyCacheCfg.StaleWhileRevalidate {
+		return false
+	}
+	for _, rule := range proxyCacheCfg.Rules {
+		if matchPattern(rule.Pattern, url) {
+			return !rule.NoStale
+		}
+	}
+	return true
+}
+
+// proxyCacheTTLs returns the TTLs of the fresh and fallback responses of url.
+func proxyCacheTTLs(url string) (time.Duration, time.Duration) {
+	proxyCacheMu.RLock()
+	defer proxyCacheMu.RUnlock()
+
+	ttl := time.Duration(expiration * int(time.Second))
+	if proxyCacheCfg.TTL > 0 {
+		ttl = proxyCacheCfg.TTL
+	}
+	staleTTL := time.Duration(expiration * int(time.Minute))
+	if proxyCacheCfg.StaleTTL > 0 {
+		staleTTL = proxyCacheCfg.StaleTTL
+	}
+
+	for _, rule := range proxyCacheCfg.Rules {
+		if !matchPattern(rule.Pattern, url) {
+			continue
+		}
+		if rule.TTL > 0 {
+			ttl = rule.TTL
+		}
+		if rule.StaleTTL > 0 {
+			staleTTL = rule.StaleTTL
+		}
+		break
+	}
+	return ttl, staleTTL
+}
+
+// matchPattern reports whether s matches pattern, where * matches any
+// sequence of characters, including slashes.
+func matchPattern(pattern string, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
+
 func buildKeyProxy(chain string, endpoint string, index string) string {
 	var sb strings.Builder
 	sb.WriteString(chain)
@@ -16,30 +120,24 @@ func buildKeyProxy(chain string, endpoint string, index string) string {
 	return sb.String()
 }
 
-func RedisSetProxyResponse(chain string, url string, response string) {
+func RedisSetProxyResponse(s Store, chain string, url string, response string) error {
 	key := buildKeyProxy("proxy", chain, url)
-	err := rdb.Set(ctxRedis, key, response, time.Duration(expiration*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+	ttl, _ := proxyCacheTTLs(url)
+	return s.Set(ctxRedis, key, response, ttl)
 }
 
-func RedisGetProxyResponse(chain string, url string) (string, error) {
+func RedisGetProxyResponse(s Store, chain string, url string) (string, error) {
 	key := buildKeyProxy("proxy", chain, url)
-	val, err := rdb.Get(ctxRedis, key).Result()
-	return formatRedisResponse(val, err)
+	return s.Get(ctxRedis, key)
 }
 
-func RedisSetFallbacResponse(chain string, url string, response string) {
+func RedisSetFallbacResponse(s Store, chain string, url string, response string) error {
 	key := buildKeyProxy("fallback", chain, url)
-	err := rdb.Set(ctxRedis, key, response, time.Duration(expiration*int(time.Minute))).Err()
-	if err != nil {
-		panic(err)
-	}
+	_, staleTTL := proxyCacheTTLs(url)
+	return s.Set(ctxRedis, key, response, staleTTL)
 }
 
-func RedisGetFallbackResponse(chain string, url string) (string, error) {
+func RedisGetFallbackResponse(s Store, chain string, url string) (string, error) {
 	key := buildKeyProxy("fallback", chain, url)
-	val, err := rdb.Get(ctxRedis, key).Result()
-	return formatRedisResponse(val, err)
+	return s.Get(ctxRedis, key)
 }
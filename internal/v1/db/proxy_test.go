@@ -0,0 +1,87 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		s       string
+		match   bool
+	}{
+		{"/cosmos/gov/*", "/cosmos/gov/v1beta1/proposals", true},
+		{"/cosmos/gov/*", "/cosmos/bank/v1beta1/balances", false},
+		{"*/validators*", "/cosmos/staking/v1beta1/validators?pagination.limit=600", true},
+		{"/status", "/status", true},
+		{"/status", "/status/1", false},
+		{"/a*b*c", "/abc", true},
+		{"/a*b*c", "/ab", false},
+	}
+	for _, tt := range tests {
+		if got := matchPattern(tt.pattern, tt.s); got != tt.match {
+			t.Fatalf("matchPattern(%q, %q) = %v, expected %v", tt.pattern, tt.s, got, tt.match)
+		}
+	}
+}
+
+func TestProxyCacheTTLs(t *testing.T) {
+	t.Cleanup(func() { SetProxyCacheConfig(ProxyCacheConfig{}) })
+
+	ttl, staleTTL := proxyCacheTTLs("/cosmos/gov/v1beta1/proposals")
+	if ttl != 7*time.Second || staleTTL != 7*time.Minute {
+		t.Fatalf("expected the default TTLs, got %v %v", ttl, staleTTL)
+	}
+
+	SetProxyCacheConfig(ProxyCacheConfig{
+		TTL: 10 * time.Second,
+		Rules: []ProxyCacheRule{
+			{Pattern: "/cosmos/gov/*", TTL: time.Minute},
+			{Pattern: "/cosmos/*", TTL: time.Hour, StaleTTL: 2 * time.Hour},
+		},
+	})
+
+	ttl, staleTTL = proxyCacheTTLs("/cosmos/gov/v1beta1/proposals")
+	if ttl != time.Minute || staleTTL != 7*time.Minute {
+		t.Fatalf("expected the first rule TTLs, got %v %v", ttl, staleTTL)
+	}
+	ttl, staleTTL = proxyCacheTTLs("/cosmos/bank/v1beta1/balances")
+	if ttl != time.Hour || staleTTL != 2*time.Hour {
+		t.Fatalf("expected the second rule TTLs, got %v %v", ttl, staleTTL)
+	}
+	ttl, _ = proxyCacheTTLs("/evmos/erc20/v1/token_pairs")
+	if ttl != 10*time.Second {
+		t.Fatalf("expected the configured TTL, got %v", ttl)
+	}
+}
+
+func TestProxyStaleWhileRevalidate(t *testing.T) {
+	t.Cleanup(func() { SetProxyCacheConfig(ProxyCacheConfig{}) })
+
+	if ProxyStaleWhileRevalidate("/cosmos/staking/v1beta1/pool") {
+		t.Fatalf("expected stale-while-revalidate to be off by default")
+	}
+
+	SetProxyCacheConfig(ProxyCacheConfig{
+		StaleWhileRevalidate: true,
+		Rules: []ProxyCacheRule{
+			{Pattern: "/cosmos/auth/v1beta1/accounts/*", NoStale: true},
+			{Pattern: "/tx?hash=*", NoStale: true},
+			{Pattern: "/status", NoStale: true},
+			{Pattern: "/cosmos/*", TTL: time.Minute},
+		},
+	})
+
+	for _, url := range []string{"/cosmos/auth/v1beta1/accounts/evmos1abc", "/tx?hash=0xABC", "/status"} {
+		if ProxyStaleWhileRevalidate(url) {
+			t.Fatalf("expected %s to never be served stale", url)
+		}
+	}
+	if !ProxyStaleWhileRevalidate("/cosmos/staking/v1beta1/pool") {
+		t.Fatalf("expected the pool to be served stale")
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package db
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v9"
+)
+
+// tokenBucketScript refills the bucket stored at KEYS[1] based on the elapsed
+// time and takes one token from it if available.
+// ARGV: refill rate (tokens per second), burst size, current time in milliseconds.
+// Returns {allowed (0 or 1), milliseconds until the next token is available}.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+local elapsed = math.max(0, now - ts) / 1000
+tokens = math.min(burst, tokens + elapsed * rate)
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / rate * 1000)
+end
+
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+return {allowed, retry}
+`)
+
+func buildKeyRateLimit(policy string, client string) string {
+	var sb strings.Builder
+	sb.WriteString("ratelimit|")
+	sb.WriteString(policy)
+	sb.WriteString("|")
+	sb.WriteString(client)
+	return sb.String()
+}
+
+// RedisTakeToken takes a token from the client bucket of the given policy.
+// It returns whether the request is allowed and, if it is not, how long the
+// client has to wait before the next token is available.
+func RedisTakeToken(s Store, policy string, client string, rate float64, burst int) (bool, time.Duration, error) {
+	return s.TakeToken(ctxRedis, buildKeyRateLimit(policy, client), rate, burst)
+}
@@ -0,0 +1,274 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v9"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+)
+
+const (
+	// fallbackMaxEntries bounds the in-memory cache used while Redis is unavailable
+	fallbackMaxEntries = 10000
+	// breakerThreshold consecutive failures open the circuit breaker
+	breakerThreshold = 5
+	// breakerCooldown is the time Redis is left alone before it is probed again
+	breakerCooldown = 10 * time.Second
+)
+
+// ErrRedisUnavailable is returned while the circuit breaker is open.
+var ErrRedisUnavailable = errors.New("redis unavailable")
+
+// RedisStore stores the values in Redis.
+// The values are also kept in a bounded in-memory cache that is served while
+// Redis fails, and a circuit breaker stops sending commands to Redis after
+// consecutive failures.
+type RedisStore struct {
+	client   *redis.Client
+	fallback *memoryCache
+	breaker  *circuitBreaker
+}
+
+var _ Store = (*RedisStore)(nil)
+
+// NewRedisStore creates a store using the Redis client.
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{
+		client:   client,
+		fallback: newMemoryCache(fallbackMaxEntries),
+		breaker:  newCircuitBreaker(breakerThreshold, breakerCooldown),
+	}
+}
+
+// NewRedisStoreFromEnv creates a store using the REDIS_HOST server.
+func NewRedisStoreFromEnv() *RedisStore {
+	return NewRedisStore(NewRedisClientFromEnv())
+}
+
+// Available returns false while the commands are not sent to Redis
+// because of previous failures, the values are then served from memory.
+func (s *RedisStore) Available() bool {
+	return !s.breaker.isOpen()
+}
+
+// do runs the Redis command unless the circuit breaker is open,
+// and records its result. redis.Nil is a successful result.
+func (s *RedisStore) do(cmd func() error) error {
+	if !s.breaker.allow() {
+		return ErrRedisUnavailable
+	}
+	err := cmd()
+	s.recordResult(err)
+	return err
+}
+
+func (s *RedisStore) recordResult(err error) {
+	if err == nil || err == redis.Nil {
+		if s.breaker.success() {
+			logging.Default().Info("Redis is available again")
+		}
+		return
+	}
+	if s.breaker.failure() {
+		logging.Default().Error("Redis is unavailable, serving the cache from memory",
+			"error", err,
+			"cooldown", breakerCooldown.String(),
+		)
+	}
+}
+
+// Get returns the value stored in Redis, or in memory if Redis fails.
+// The values read from Redis are kept in memory too.
+func (s *RedisStore) Get(ctx context.Context, key string) (string, error) {
+	gets, err := s.fetch(ctx, key)
+	if err == nil {
+		return gets[0].Result()
+	}
+
+	if val, ok := s.fallback.get(key); ok {
+		return val, nil
+	}
+	return "", err
+}
+
+// fetch reads the values of the keys and their TTLs from Redis in one round
+// trip and keeps them in memory, so the values written by other clients are
+// also served while Redis fails.
+func (s *RedisStore) fetch(ctx context.Context, keys ...string) ([]*redis.StringCmd, error) {
+	gets := make([]*redis.StringCmd, len(keys))
+	err := s.do(func() error {
+		pipe := s.client.Pipeline()
+		ttls := make([]*redis.DurationCmd, len(keys))
+		for i, key := range keys {
+			gets[i] = pipe.Get(ctx, key)
+			ttls[i] = pipe.PTTL(ctx, key)
+		}
+		if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+			return err
+		}
+		for i, key := range keys {
+			if gets[i].Err() == nil {
+				// PTTL is negative for the keys without expiration
+				s.fallback.set(key, gets[i].Val(), ttls[i].Val())
+			}
+		}
+		return nil
+	})
+	return gets, err
+}
+
+// Set stores the value in Redis and in memory.
+// The value is kept in memory even if Redis fails.
+func (s *RedisStore) Set(ctx context.Context, key string, value string, ttl time.Duration) error {
+	s.fallback.set(key, value, ttl)
+	err := s.do(func() error {
+		return s.client.Set(ctx, key, value, ttl).Err()
+	})
+	if err != nil {
+		return fmt.Errorf("error storing %s: %w", key, err)
+	}
+	return nil
+}
+
+// Scan returns the keys stored in Redis, or in memory if Redis fails.
+// The values of the keys missing in memory are read so they can be served
+// while Redis fails.
+func (s *RedisStore) Scan(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	err := s.do(func() error {
+		keys = nil
+		iter := s.client.Scan(ctx, 0, prefix+"*", 0).Iterator()
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+		}
+		return iter.Err()
+	})
+	if err == nil {
+		var missing []string
+		for _, key := range keys {
+			if _, ok := s.fallback.get(key); !ok {
+				missing = append(missing, key)
+			}
+		}
+		if len(missing) > 0 {
+			// The keys are returned even if their values can not be kept in memory
+			_, _ = s.fetch(ctx, missing...)
+		}
+		return keys, nil
+	}
+
+	if keys := s.fallback.keys(prefix); len(keys) > 0 {
+		return keys, nil
+	}
+	return nil, err
+}
+
+// SetNX stores the value in Redis if the key does not exist.
+// It is used for locks, so the value is not kept in memory.
+func (s *RedisStore) SetNX(ctx context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	var ok bool
+	err := s.do(func() error {
+		var err error
+		ok, err = s.client.SetNX(ctx, key, value, ttl).Result()
+		return err
+	})
+	return ok, err
+}
+
+// deleteIfEqualScript deletes KEYS[1] only if its value is ARGV[1].
+var deleteIfEqualScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Delete deletes the keys from Redis and from memory.
+func (s *RedisStore) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	s.fallback.delete(keys...)
+	return s.do(func() error {
+		return s.client.Del(ctx, keys...).Err()
+	})
+}
+
+// DeleteIfEqual deletes the key from Redis if it still holds value.
+func (s *RedisStore) DeleteIfEqual(ctx context.Context, key string, value string) error {
+	return s.do(func() error {
+		return deleteIfEqualScript.Run(ctx, s.client, []string{key}, value).Err()
+	})
+}
+
+// Incr increments the counter in Redis. The counters are not kept in memory.
+func (s *RedisStore) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	var val int64
+	err := s.do(func() error {
+		pipe := s.client.TxPipeline()
+		incr := pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, ttl)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return err
+		}
+		val = incr.Val()
+		return nil
+	})
+	return val, err
+}
+
+// TakeToken takes a token from the bucket stored in Redis.
+func (s *RedisStore) TakeToken(ctx context.Context, key string, rate float64, burst int) (bool, time.Duration, error) {
+	var res []int64
+	err := s.do(func() error {
+		var err error
+		res, err = tokenBucketScript.Run(ctx, s.client, []string{key}, rate, burst, time.Now().UnixMilli()).Int64Slice()
+		return err
+	})
+	if err != nil {
+		return false, 0, err
+	}
+	return res[0] == 1, time.Duration(res[1]) * time.Millisecond, nil
+}
+
+// ZAdd adds the member to the sorted set stored in Redis and trims it.
+// The sorted sets are not kept in memory.
+func (s *RedisStore) ZAdd(ctx context.Context, key string, member string, score float64, minScore float64, ttl time.Duration) error {
+	return s.do(func() error {
+		pipe := s.client.TxPipeline()
+		pipe.ZAdd(ctx, key, redis.Z{Score: score, Member: member})
+		pipe.ZRemRangeByScore(ctx, key, "-inf", "("+formatScore(minScore))
+		if ttl > 0 {
+			pipe.Expire(ctx, key, ttl)
+		}
+		_, err := pipe.Exec(ctx)
+		return err
+	})
+}
+
+// ZRange returns the members of the sorted set stored in Redis.
+func (s *RedisStore) ZRange(ctx context.Context, key string, minScore float64) ([]string, error) {
+	var members []string
+	err := s.do(func() error {
+		var err error
+		members, err = s.client.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: formatScore(minScore), Max: "+inf"}).Result()
+		return err
+	})
+	return members, err
+}
+
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
+
+// Ping checks that Redis is reachable, regardless of the circuit breaker.
+func (s *RedisStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
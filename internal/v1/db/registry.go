@@ -0,0 +1,55 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package db
+
+import (
+	"fmt"
+	"os"
+)
+
+// registryStatusKey represents the Redis key for the status of the registry loads
+var registryStatusKey string
+
+// registryCommitKey represents the Redis key for the commits of the registry repositories
+var registryCommitKey string
+
+func init() {
+	registryStatusKey = getRegistryStatusKey()
+	registryCommitKey = getRegistryCommitKey()
+}
+
+func getRegistryStatusKey() string {
+	env := os.Getenv("ENVIRONMENT")
+	if env == "production" {
+		return "prod-registry-status"
+	}
+	return "registry-status"
+}
+
+func getRegistryCommitKey() string {
+	env := os.Getenv("ENVIRONMENT")
+	if env == "production" {
+		return "prod-registry-commit"
+	}
+	return "registry-commit"
+}
+
+// RedisSetRegistryStatus stores the status of the last load of a registry folder.
+// It does not expire so the status of the last load is always available.
+func RedisSetRegistryStatus(s Store, folder string, status string) error {
+	return s.Set(ctxRedis, fmt.Sprintf("%s-%s", registryStatusKey, folder), status, 0)
+}
+
+func RedisGetRegistryStatus(s Store, folder string) (string, error) {
+	return s.Get(ctxRedis, fmt.Sprintf("%s-%s", registryStatusKey, folder))
+}
+
+// RedisSetRegistryCommit stores the commit of the repository the registry was last loaded from.
+func RedisSetRegistryCommit(s Store, repo string, commit string) error {
+	return s.Set(ctxRedis, fmt.Sprintf("%s-%s", registryCommitKey, repo), commit, 0)
+}
+
+func RedisGetRegistryCommit(s Store, repo string) (string, error) {
+	return s.Get(ctxRedis, fmt.Sprintf("%s-%s", registryCommitKey, repo))
+}
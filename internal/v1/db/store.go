@@ -0,0 +1,45 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v9"
+)
+
+// ErrNotFound is returned by the stores when the key does not exist.
+var ErrNotFound = redis.Nil
+
+// Store is the key-value storage used by the db functions.
+// RedisStore is used in production, MemoryStore in tests.
+type Store interface {
+	// Get returns the value of the key or ErrNotFound.
+	Get(ctx context.Context, key string) (string, error)
+	// Set stores the value of the key, a zero TTL means no expiration.
+	Set(ctx context.Context, key string, value string, ttl time.Duration) error
+	// Scan returns the keys starting with prefix.
+	Scan(ctx context.Context, prefix string) ([]string, error)
+	// SetNX stores the value only if the key does not exist and reports whether it did.
+	SetNX(ctx context.Context, key string, value string, ttl time.Duration) (bool, error)
+	// Delete deletes the keys, the missing ones are ignored.
+	Delete(ctx context.Context, keys ...string) error
+	// DeleteIfEqual deletes the key only if it still holds value.
+	DeleteIfEqual(ctx context.Context, key string, value string) error
+	// Incr increments the counter stored at key and sets its TTL.
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	// TakeToken takes a token from the bucket stored at key, refilled at rate tokens
+	// per second up to burst tokens. If no token is available it returns false and
+	// the time until the next one is.
+	TakeToken(ctx context.Context, key string, rate float64, burst int) (bool, time.Duration, error)
+	// ZAdd adds member with score to the sorted set stored at key, removes the
+	// members scored below minScore and sets the TTL of the set.
+	ZAdd(ctx context.Context, key string, member string, score float64, minScore float64, ttl time.Duration) error
+	// ZRange returns the members of the sorted set stored at key scored at least
+	// minScore, lowest score first.
+	ZRange(ctx context.Context, key string, minScore float64) ([]string, error)
+	// Ping checks that the storage is reachable.
+	Ping(ctx context.Context) error
+}
@@ -0,0 +1,309 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package db
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v9"
+)
+
+func TestMemoryCache(t *testing.T) {
+	now := time.Now()
+	c := newMemoryCache(2)
+	c.now = func() time.Time { return now }
+
+	c.set("a", "1", time.Second)
+	c.set("b", "2", 0)
+	if _, ok := c.get("a"); !ok {
+		t.Fatalf("expected a to be cached")
+	}
+	// b is the least recently used entry
+	c.set("c", "3", 0)
+	if _, ok := c.get("b"); ok {
+		t.Fatalf("expected b to be evicted")
+	}
+	if c.len() != 2 {
+		t.Fatalf("expected 2 entries, got %d", c.len())
+	}
+
+	now = now.Add(time.Second)
+	if _, ok := c.get("a"); ok {
+		t.Fatalf("expected a to be expired")
+	}
+	if val, ok := c.get("c"); !ok || val != "3" {
+		t.Fatalf("expected c without expiration, got %q", val)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := newCircuitBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	if b.failure() {
+		t.Fatalf("expected the breaker to stay closed after 1 failure")
+	}
+	if !b.failure() {
+		t.Fatalf("expected the breaker to open after 2 failures")
+	}
+	if b.allow() {
+		t.Fatalf("expected the commands to be rejected while open")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow() {
+		t.Fatalf("expected a probe after the cooldown")
+	}
+	if b.allow() {
+		t.Fatalf("expected a single probe at a time")
+	}
+	if b.failure() {
+		t.Fatalf("expected a failed probe not to report the breaker opening again")
+	}
+	if b.allow() {
+		t.Fatalf("expected the breaker to open again after a failed probe")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow() || !b.success() {
+		t.Fatalf("expected a successful probe to close the breaker")
+	}
+	if !b.allow() || b.isOpen() {
+		t.Fatalf("expected the breaker to be closed")
+	}
+}
+
+func TestRedisStoreFallback(t *testing.T) {
+	// nothing listens on port 1, every command fails
+	s := NewRedisStore(redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1}))
+
+	if err := RedisSetProxyResponse(s, "EVMOS", "/status", "ok"); err == nil {
+		t.Fatalf("expected the Redis write to fail")
+	}
+	val, err := RedisGetProxyResponse(s, "EVMOS", "/status")
+	if err != nil || val != "ok" {
+		t.Fatalf("expected the value from memory, got %q %v", val, err)
+	}
+	if _, err := RedisGetProxyResponse(s, "EVMOS", "/missing"); err == nil || err == ErrNotFound {
+		t.Fatalf("expected the Redis error, got %v", err)
+	}
+
+	for i := 0; i < breakerThreshold; i++ {
+		_, _ = RedisGetPrice(s, "evmos", "usd")
+	}
+	if s.Available() {
+		t.Fatalf("expected the circuit breaker to be open")
+	}
+	endpoints := []RankedEndpoint{{URL: "https://rest.evmos.org", LastChecked: time.Now()}}
+	if err := RedisSetEndpointRanking(s, "EVMOS", "rest", endpoints, time.Now()); !errors.Is(err, ErrRedisUnavailable) {
+		t.Fatalf("expected ErrRedisUnavailable, got %v", err)
+	}
+	ranking, err := RedisGetEndpointRanking(s, "EVMOS", "rest")
+	if err != nil || len(ranking.Endpoints) != 1 || ranking.Endpoints[0].URL != "https://rest.evmos.org" {
+		t.Fatalf("expected the endpoints from memory, got %v %v", ranking, err)
+	}
+	if _, err := RedisGetAPIKey(s, "1"); !errors.Is(err, ErrRedisUnavailable) {
+		t.Fatalf("expected the lookups of missing keys to fail, got %v", err)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	s := NewMemoryStore(100)
+
+	if _, err := RedisGetChainHeight(s, "EVMOS"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := RedisSetChainHeight(s, "EVMOS", "100"); err != nil {
+		t.Fatal(err)
+	}
+	if val, err := RedisGetChainHeight(s, "EVMOS"); err != nil || val != "100" {
+		t.Fatalf("expected 100, got %q %v", val, err)
+	}
+
+	for i := int64(1); i <= 2; i++ {
+		if usage, err := RedisIncrAPIKeyUsage(s, "1", "2024-01-01"); err != nil || usage != i {
+			t.Fatalf("expected usage %d, got %d %v", i, usage, err)
+		}
+	}
+	if usage, err := RedisGetAPIKeyUsage(s, "2", "2024-01-01"); err != nil || usage != 0 {
+		t.Fatalf("expected no usage, got %d %v", usage, err)
+	}
+
+	allowed, _, _ := RedisTakeToken(s, "default", "ip:1.1.1.1", 1, 1)
+	if !allowed {
+		t.Fatalf("expected the first request to be allowed")
+	}
+	allowed, retry, _ := RedisTakeToken(s, "default", "ip:1.1.1.1", 1, 1)
+	if allowed || retry <= 0 {
+		t.Fatalf("expected the second request to be limited, got %v %s", allowed, retry)
+	}
+
+	for i, member := range []string{"c", "a", "b"} {
+		if err := s.ZAdd(ctxRedis, "set", member, float64(i), 1, time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// c is scored below the min score of the last additions
+	if members, err := s.ZRange(ctxRedis, "set", 0); err != nil || strings.Join(members, ",") != "a,b" {
+		t.Fatalf("expected the members by score, got %v %v", members, err)
+	}
+	if members, err := s.ZRange(ctxRedis, "set", 2); err != nil || strings.Join(members, ",") != "b" {
+		t.Fatalf("expected the members scored at least 2, got %v %v", members, err)
+	}
+}
+
+// fakeRedis is a Redis server answering GET, PTTL, SET and SCAN, enough to
+// test the store against a server that goes down.
+type fakeRedis struct {
+	t        *testing.T
+	listener net.Listener
+	mu       sync.Mutex
+	values   map[string]string
+	conns    []net.Conn
+}
+
+func newFakeRedis(t *testing.T, values map[string]string) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &fakeRedis{t: t, listener: listener, values: values}
+	go r.serve()
+	t.Cleanup(r.close)
+	return r
+}
+
+func (r *fakeRedis) addr() string {
+	return r.listener.Addr().String()
+}
+
+// close stops the server and drops the open connections.
+func (r *fakeRedis) close() {
+	_ = r.listener.Close()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, conn := range r.conns {
+		_ = conn.Close()
+	}
+}
+
+func (r *fakeRedis) serve() {
+	for {
+		conn, err := r.listener.Accept()
+		if err != nil {
+			return
+		}
+		r.mu.Lock()
+		r.conns = append(r.conns, conn)
+		r.mu.Unlock()
+		go r.handle(conn)
+	}
+}
+
+func (r *fakeRedis) handle(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		if _, err := conn.Write([]byte(r.reply(args))); err != nil {
+			return
+		}
+	}
+}
+
+func (r *fakeRedis) reply(args []string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "GET":
+		val, ok := r.values[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(val), val)
+	case "PTTL":
+		if _, ok := r.values[args[1]]; !ok {
+			return ":-2\r\n"
+		}
+		return ":-1\r\n"
+	case "SET":
+		r.values[args[1]] = args[2]
+		return "+OK\r\n"
+	case "SCAN":
+		prefix := strings.TrimSuffix(args[3], "*")
+		var keys []string
+		for key := range r.values {
+			if strings.HasPrefix(key, prefix) {
+				keys = append(keys, fmt.Sprintf("$%d\r\n%s\r\n", len(key), key))
+			}
+		}
+		return fmt.Sprintf("*2\r\n$1\r\n0\r\n*%d\r\n%s", len(keys), strings.Join(keys, ""))
+	default:
+		return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+	}
+}
+
+// readCommand reads a command sent as an array of bulk strings.
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	var n int
+	if _, err := fmt.Fscanf(reader, "*%d\r\n", &n); err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		var size int
+		if _, err := fmt.Fscanf(reader, "$%d\r\n", &size); err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func TestRedisStoreReadThrough(t *testing.T) {
+	// The values were written by another client, e.g. the crons
+	server := newFakeRedis(t, map[string]string{
+		buildKeyProxy("fallback", "EVMOS", "/status"): "ok",
+		networkConfigKey + "-evmos":                   `{"chain":"evmos"}`,
+	})
+	s := NewRedisStore(redis.NewClient(&redis.Options{Addr: server.addr(), MaxRetries: -1}))
+
+	if val, err := RedisGetFallbackResponse(s, "EVMOS", "/status"); err != nil || val != "ok" {
+		t.Fatalf("expected the value from Redis, got %q %v", val, err)
+	}
+	keys, err := s.Scan(context.Background(), networkConfigKey+"-")
+	if err != nil || len(keys) != 1 {
+		t.Fatalf("expected the network config key, got %v %v", keys, err)
+	}
+
+	server.close()
+
+	if val, err := RedisGetFallbackResponse(s, "EVMOS", "/status"); err != nil || val != "ok" {
+		t.Fatalf("expected the value read before Redis went down, got %q %v", val, err)
+	}
+	keys, err = s.Scan(context.Background(), networkConfigKey+"-")
+	if err != nil || len(keys) != 1 {
+		t.Fatalf("expected the scanned key from memory, got %v %v", keys, err)
+	}
+	if val, err := s.Get(context.Background(), keys[0]); err != nil || val != `{"chain":"evmos"}` {
+		t.Fatalf("expected the scanned value from memory, got %q %v", val, err)
+	}
+}
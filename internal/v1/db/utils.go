@@ -15,16 +15,12 @@ func buildKeyChainHeight(chain string) string {
 	return sb.String()
 }
 
-func RedisSetChainHeight(chain string, response string) {
+func RedisSetChainHeight(s Store, chain string, response string) error {
 	key := buildKeyChainHeight(chain)
-	err := rdb.Set(ctxRedis, key, response, time.Duration(expiration*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+	return s.Set(ctxRedis, key, response, time.Duration(expiration*int(time.Second)))
 }
 
-func RedisGetChainHeight(chain string) (string, error) {
+func RedisGetChainHeight(s Store, chain string) (string, error) {
 	key := buildKeyChainHeight(chain)
-	val, err := rdb.Get(ctxRedis, key).Result()
-	return formatRedisResponse(val, err)
+	return s.Get(ctxRedis, key)
 }
@@ -14,26 +14,28 @@ var (
 
 var validatorDirectoryKey = "validator-directory"
 
-func RedisSetValidatorDirectory(result string) {
-	err := rdb.Set(ctxRedis, validatorDirectoryKey, result, time.Duration(oneDayExpiration*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+func RedisSetValidatorDirectory(s Store, result string) error {
+	return s.Set(ctxRedis, validatorDirectoryKey, result, time.Duration(oneDayExpiration*int(time.Second)))
 }
 
-func RedisGetValidatorDirectory() (string, error) {
-	val, err := rdb.Get(ctxRedis, validatorDirectoryKey).Result()
-	return formatRedisResponse(val, err)
+func RedisGetValidatorDirectory(s Store) (string, error) {
+	return s.Get(ctxRedis, validatorDirectoryKey)
 }
 
-func RedisSetValidatorDirectoryNoListed(status string, sort string, result string) {
-	err := rdb.Set(ctxRedis, validatorDirectoryKey+status+sort, result, time.Duration(expiration*int(time.Second))).Err()
-	if err != nil {
-		panic(err)
-	}
+func RedisSetValidatorDirectoryNoListed(s Store, status string, sort string, result string) error {
+	return s.Set(ctxRedis, validatorDirectoryKey+status+sort, result, time.Duration(expiration*int(time.Second)))
 }
 
-func RedisGetValidatorDirectoryNoListed(status string, sort string) (string, error) {
-	val, err := rdb.Get(ctxRedis, validatorDirectoryKey+status+sort).Result()
-	return formatRedisResponse(val, err)
+func RedisGetValidatorDirectoryNoListed(s Store, status string, sort string) (string, error) {
+	return s.Get(ctxRedis, validatorDirectoryKey+status+sort)
+}
+
+// RedisDeleteValidatorDirectory deletes the validator directory and the
+// validators built from it.
+func RedisDeleteValidatorDirectory(s Store) error {
+	keys, err := s.Scan(ctxRedis, validatorDirectoryKey)
+	if err != nil {
+		return err
+	}
+	return s.Delete(ctxRedis, keys...)
 }
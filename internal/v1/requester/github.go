@@ -10,9 +10,11 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path"
 	"strings"
 
 	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
 )
 
 type Tree struct {
@@ -40,8 +42,8 @@ type File struct {
 	URL     string
 }
 
-func QueryGithubWithCache(url string) (string, error) {
-	if val, err := db.RedisGetGithubResponse(url); err == nil {
+func QueryGithubWithCache(store db.Store, url string) (string, error) {
+	if val, err := db.RedisGetGithubResponse(store, url); err == nil {
 		return val, nil
 	}
 
@@ -56,14 +58,14 @@ func QueryGithubWithCache(url string) (string, error) {
 
 	resp, err := Client.Do(req)
 	if err != nil {
-		if val, err := db.RedisGetHithubFallbackResponse(url); err == nil {
+		if val, err := db.RedisGetHithubFallbackResponse(store, url); err == nil {
 			return val, nil
 		}
 		return "", err
 	}
 
 	if resp.StatusCode != 200 {
-		if val, err := db.RedisGetHithubFallbackResponse(url); err == nil {
+		if val, err := db.RedisGetHithubFallbackResponse(store, url); err == nil {
 			return val, nil
 		}
 		return "", fmt.Errorf("github response status code different from 200: %d", resp.StatusCode)
@@ -74,7 +76,7 @@ func QueryGithubWithCache(url string) (string, error) {
 	body, err := io.ReadAll(resp.Body)
 
 	if err != nil || len(string(body)) == 0 {
-		if val, err := db.RedisGetHithubFallbackResponse(url); err == nil {
+		if val, err := db.RedisGetHithubFallbackResponse(store, url); err == nil {
 			return val, nil
 		}
 		return "", err
@@ -84,37 +86,36 @@ func QueryGithubWithCache(url string) (string, error) {
 	// but to support everything in redis as a string it's worth it
 	bodyString := string(body)
 
-	db.RedisSetGithubResponse(url, bodyString)
-	db.RedisSetGithubFallbackResponse(url, bodyString)
+	if err := db.RedisSetGithubResponse(store, url, bodyString); err != nil {
+		logging.Default().Warn("Error caching github response", "error", err)
+	}
+	if err := db.RedisSetGithubFallbackResponse(store, url, bodyString); err != nil {
+		logging.Default().Warn("Error caching github fallback response", "error", err)
+	}
 	return bodyString, nil
 }
 
-func getChainTokenRegistryURL() string {
-	env := os.Getenv("ENVIRONMENT")
-	if env == "production" {
-		return "https://api.github.com/repos/evmos/chain-token-registry/git/trees/production?recursive=1"
-	}
-	return "https://api.github.com/repos/evmos/chain-token-registry/git/trees/main?recursive=1"
+// getTreeURL returns the GitHub API URL of the recursive tree of the served
+// branch of the repository.
+func getTreeURL(repo Repository) string {
+	return GithubAPIURL(repo) + "git/trees/" + Branch(repo) + "?recursive=1"
 }
 
-func GetValidatorDirectory() ([]File, error) {
-	ValidatorsDirectoryURL := "https://api.github.com/repos/evmos/validator-directory/git/trees/main?recursive=1"
-	return GetJsonsFromFolder(ValidatorsDirectoryURL, "mainnet")
+func GetValidatorDirectory(store db.Store) ([]File, error) {
+	return getRegistrySource().Files(store, ValidatorDirectory, "mainnet")
 }
 
-func GetERC20TokensDirectory() ([]File, error) {
-	ERC20TokensDirectoryURL := getChainTokenRegistryURL()
-	return GetJsonsFromFolder(ERC20TokensDirectoryURL, "tokens")
+func GetERC20TokensDirectory(store db.Store) ([]File, error) {
+	return getRegistrySource().Files(store, ChainTokenRegistry, "tokens")
 }
 
-func GetNetworkConfig() ([]File, error) {
-	url := getChainTokenRegistryURL()
-	return GetJsonsFromFolder(url, "chainConfig")
+func GetNetworkConfig(store db.Store) ([]File, error) {
+	return getRegistrySource().Files(store, ChainTokenRegistry, "chainConfig")
 }
 
-func GetJsonsFromFolder(url string, folder string) ([]File, error) {
+func GetJsonsFromFolder(store db.Store, url string, folder string) ([]File, error) {
 	res := []File{}
-	apiResp, err := QueryGithubWithCache(url)
+	apiResp, err := QueryGithubWithCache(store, url)
 	if err != nil {
 		return []File{}, err
 	}
@@ -127,9 +128,10 @@ func GetJsonsFromFolder(url string, folder string) ([]File, error) {
 
 	for _, t := range m.Tree {
 		if t.Mode == "100644" {
-			// Is file
-			if strings.HasPrefix(t.Path, folder+"/") {
-				fileResponse, err := QueryGithubWithCache(t.URL)
+			// Is file, only the JSON files outside of the hidden folders are
+			// read, as with the local source
+			if strings.HasPrefix(t.Path, folder+"/") && path.Ext(t.Path) == ".json" && !isHiddenPath(t.Path) {
+				fileResponse, err := QueryGithubWithCache(store, t.URL)
 				if err != nil {
 					return []File{}, err
 				}
@@ -148,3 +150,13 @@ func GetJsonsFromFolder(url string, folder string) ([]File, error) {
 	}
 	return res, nil
 }
+
+// isHiddenPath reports whether one of the folders of the path is hidden.
+func isHiddenPath(p string) bool {
+	for _, d := range strings.Split(path.Dir(p), "/") {
+		if strings.HasPrefix(d, ".") && d != "." {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,185 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package requester
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+)
+
+// Repository is a GitHub repository the registry files are read from.
+type Repository string
+
+const (
+	// ChainTokenRegistry holds the network configs (chainConfig) and the ERC20 tokens (tokens).
+	ChainTokenRegistry Repository = "chain-token-registry"
+	// ValidatorDirectory holds the validators (mainnet).
+	ValidatorDirectory Repository = "validator-directory"
+)
+
+// Registry sources.
+const (
+	RegistrySourceGithub = "github"
+	RegistrySourceLocal  = "local"
+)
+
+// RegistryConfig selects where the registry files are read from.
+type RegistryConfig struct {
+	// REGISTRY_SOURCE: github, the default, or local
+	Source string `toml:"source"`
+	// REGISTRY_CHAIN_TOKEN_REGISTRY_DIR: directory or git clone of the chain-token-registry, for the local source
+	ChainTokenRegistryDir string `toml:"chain_token_registry_dir"`
+	// REGISTRY_VALIDATOR_DIRECTORY_DIR: directory or git clone of the validator-directory, for the local source
+	ValidatorDirectoryDir string `toml:"validator_directory_dir"`
+	// GITHUB_WEBHOOK_SECRET: secret of the GitHub webhook reloading the registry on push, the webhook is disabled when empty
+	WebhookSecret string `toml:"webhook_secret"`
+}
+
+// LoadEnv overrides the configuration with the REGISTRY_* environment
+// variables that are set.
+func (c RegistryConfig) LoadEnv() RegistryConfig {
+	if source := os.Getenv("REGISTRY_SOURCE"); source != "" {
+		c.Source = source
+	}
+	if dir := os.Getenv("REGISTRY_CHAIN_TOKEN_REGISTRY_DIR"); dir != "" {
+		c.ChainTokenRegistryDir = dir
+	}
+	if dir := os.Getenv("REGISTRY_VALIDATOR_DIRECTORY_DIR"); dir != "" {
+		c.ValidatorDirectoryDir = dir
+	}
+	if secret := os.Getenv("GITHUB_WEBHOOK_SECRET"); secret != "" {
+		c.WebhookSecret = secret
+	}
+	return c
+}
+
+// RegistrySource reads the files of the registry repositories.
+type RegistrySource interface {
+	// Files returns the files of folder and its subfolders in repo, sorted by
+	// path. The URL of the files is their path in the repository, e.g. chainConfig/evmos.json.
+	Files(store db.Store, repo Repository, folder string) ([]File, error)
+}
+
+// NewRegistrySource returns the source selected by the configuration.
+func NewRegistrySource(cfg RegistryConfig) (RegistrySource, error) {
+	switch cfg.Source {
+	case "", RegistrySourceGithub:
+		return GithubSource{}, nil
+	case RegistrySourceLocal:
+		dirs := map[Repository]string{}
+		if cfg.ChainTokenRegistryDir != "" {
+			dirs[ChainTokenRegistry] = cfg.ChainTokenRegistryDir
+		}
+		if cfg.ValidatorDirectoryDir != "" {
+			dirs[ValidatorDirectory] = cfg.ValidatorDirectoryDir
+		}
+		if len(dirs) == 0 {
+			return nil, fmt.Errorf("the local registry source needs the directory of a repository")
+		}
+		return LocalSource{Dirs: dirs}, nil
+	default:
+		return nil, fmt.Errorf("invalid registry source %q", cfg.Source)
+	}
+}
+
+var (
+	registryMu     sync.RWMutex
+	registrySource RegistrySource = GithubSource{}
+)
+
+// SetRegistrySource sets the source the registry files are read from, GitHub by default.
+func SetRegistrySource(src RegistrySource) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registrySource = src
+}
+
+func getRegistrySource() RegistrySource {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return registrySource
+}
+
+// RegistryFromGithub reports whether the registry files are read from GitHub,
+// the pushes to the repositories do not apply to the local copies.
+func RegistryFromGithub() bool {
+	_, ok := getRegistrySource().(GithubSource)
+	return ok
+}
+
+// GithubSource reads the files with the GitHub API, authenticated with GITHUB_KEY.
+// The responses are cached in the store.
+type GithubSource struct{}
+
+func (GithubSource) Files(store db.Store, repo Repository, folder string) ([]File, error) {
+	return GetJsonsFromFolder(store, getTreeURL(repo), folder)
+}
+
+// GithubAPIURL returns the prefix of the GitHub API URLs of the repository,
+// e.g. https://api.github.com/repos/evmos/chain-token-registry/.
+func GithubAPIURL(repo Repository) string {
+	return "https://api.github.com/repos/evmos/" + string(repo) + "/"
+}
+
+// Branch returns the branch of the repository that is served. The production
+// environment serves the production branch of the chain-token-registry.
+func Branch(repo Repository) string {
+	if repo == ChainTokenRegistry && os.Getenv("ENVIRONMENT") == "production" {
+		return "production"
+	}
+	return "main"
+}
+
+// LocalSource reads the JSON files of a local copy of the repositories, e.g. a
+// git clone checked out on the branch to serve. The files are read on every call.
+type LocalSource struct {
+	// Dirs are the root directories of the repositories
+	Dirs map[Repository]string
+}
+
+func (s LocalSource) Files(_ db.Store, repo Repository, folder string) ([]File, error) {
+	root, ok := s.Dirs[repo]
+	if !ok {
+		return []File{}, fmt.Errorf("no local directory for the %s repository", repo)
+	}
+
+	dir := filepath.Join(root, folder)
+	res := []File{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// Skip the .git folder and the other hidden ones
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		res = append(res, File{Content: string(content), URL: filepath.ToSlash(rel)})
+		return nil
+	})
+	if err != nil {
+		return []File{}, err
+	}
+	return res, nil
+}
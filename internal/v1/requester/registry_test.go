@@ -0,0 +1,95 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package requester
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+)
+
+func TestLocalSourceMatchesGithub(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "")
+	files := map[string]string{
+		"chainConfig/evmos.json":      `{"prefix":"evmos"}`,
+		"chainConfig/osmosis.json":    `{"prefix":"osmo"}`,
+		"chainConfig/testnet/x.json":  `{"prefix":"x"}`,
+		"tokens/evmos.json":           `{"coinDenom":"EVMOS"}`,
+		"chainConfig/.hidden/y.json":  `{}`,
+		"chainConfig/README.md":       `# configs`,
+		"chainConfigArchive/old.json": `{}`,
+	}
+	root := t.TempDir()
+	for path, content := range files {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The same repository served by the GitHub API, from the cache
+	store := db.NewMemoryStore(100)
+	tree := TreeResponse{}
+	for _, path := range []string{"chainConfig/evmos.json", "chainConfig/osmosis.json", "chainConfig/testnet/x.json", "tokens/evmos.json", "chainConfig/.hidden/y.json", "chainConfig/README.md"} {
+		url := "https://api.github.com/repos/evmos/chain-token-registry/contents/" + path
+		content, _ := json.Marshal(Content{Content: base64.StdEncoding.EncodeToString([]byte(files[path]))})
+		if err := db.RedisSetGithubResponse(store, url, string(content)); err != nil {
+			t.Fatal(err)
+		}
+		tree.Tree = append(tree.Tree, Tree{Path: path, Mode: "100644", URL: url})
+	}
+	treeJSON, _ := json.Marshal(tree)
+	if err := db.RedisSetGithubResponse(store, getTreeURL(ChainTokenRegistry), string(treeJSON)); err != nil {
+		t.Fatal(err)
+	}
+
+	local := LocalSource{Dirs: map[Repository]string{ChainTokenRegistry: root}}
+	for _, folder := range []string{"chainConfig", "tokens"} {
+		want, err := GithubSource{}.Files(store, ChainTokenRegistry, folder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := local.Files(nil, ChainTokenRegistry, folder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("local %s files differ from GitHub:\n%+v\n%+v", folder, got, want)
+		}
+	}
+
+	if _, err := local.Files(nil, ValidatorDirectory, "mainnet"); err == nil {
+		t.Fatalf("expected an error for a repository without directory")
+	}
+}
+
+func TestNewRegistrySource(t *testing.T) {
+	if src, err := NewRegistrySource(RegistryConfig{}); err != nil || src != (GithubSource{}) {
+		t.Fatalf("expected the GitHub source by default, got %v %v", src, err)
+	}
+	if _, err := NewRegistrySource(RegistryConfig{Source: RegistrySourceLocal}); err == nil {
+		t.Fatalf("expected an error for a local source without directory")
+	}
+	if _, err := NewRegistrySource(RegistryConfig{Source: "s3"}); err == nil {
+		t.Fatalf("expected an error for an unknown source")
+	}
+
+	t.Setenv("REGISTRY_SOURCE", "local")
+	t.Setenv("REGISTRY_CHAIN_TOKEN_REGISTRY_DIR", "/registry")
+	src, err := NewRegistrySource(RegistryConfig{}.LoadEnv())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if local, ok := src.(LocalSource); !ok || local.Dirs[ChainTokenRegistry] != "/registry" {
+		t.Fatalf("unexpected source %+v", src)
+	}
+}
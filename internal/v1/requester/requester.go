@@ -5,26 +5,23 @@ package requester
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/tharsis/dashboard-backend/internal/v1/db"
-	"github.com/tharsis/dashboard-backend/internal/v1/metrics"
+	"github.com/tharsis/dashboard-backend/internal/v2/node/upstream"
 )
 
 var Client = http.Client{
 	Timeout: 2 * time.Second,
 }
 
-var clientLongRequest = http.Client{
-	Timeout: 6 * time.Second,
-}
-
 const BadRequestError = `{"error": "Bad Request"}`
 
 // Right now is not being used because python is saving the prices
@@ -49,60 +46,14 @@ func GetRequestPrice(asset string, vsCurrency string) (string, error) {
 	return string(body), nil
 }
 
-func MakeGetRequest(chain string, endpointType string, url string) (string, error) {
-	i := 1
-	for i < 4 {
-		endpoint, err := db.RedisGetEndpoint(chain, endpointType, strconv.FormatInt(int64(i), 10))
-		if err != nil {
-			i++
-			continue
-		}
-
-		var sb strings.Builder
-		sb.WriteString(endpoint)
-		sb.WriteString(url)
-
-		resp, err := Client.Get(sb.String())
-		if err != nil {
-			i++
-			continue
-		}
-
-		// Handle 404 responses from cosmos api, it's actually element not found
-		if resp.StatusCode == 404 {
-			defer resp.Body.Close()
-			body, _ := io.ReadAll(resp.Body)
-			// endpoint error
-			if strings.Contains(string(body), "Cannot GET") {
-				i++
-				continue
-			}
-			// node element not found
-			return `{"error": "Element not found"}`, nil
-		}
-
-		if resp.StatusCode == 400 {
-			return BadRequestError, nil
-		}
-		if resp.StatusCode != 200 {
-			i++
-			continue
-		}
-
-		defer resp.Body.Close()
-
-		body, err := io.ReadAll(resp.Body)
-
-		if err != nil || len(string(body)) == 0 {
-			i++
-			continue
-		}
-
-		return string(body), nil
+// MakeGetRequest queries the url on the best ranked endpoints of the chain until one of them answers,
+// see upstream.Client. The node answers to bad queries are returned as the error bodies of the v1 API.
+func MakeGetRequest(ctx context.Context, store db.Store, chain string, endpointType string, url string) (string, error) {
+	res, err := upstream.NewClient(store).Get(ctx, chain, endpointType, url)
+	if err != nil {
+		return errorBody(err, http.MethodGet)
 	}
-
-	metrics.Send(fmt.Sprintln("All endpoints failed to get response(GET): ", chain, url))
-	return "", fmt.Errorf("all endpoints are down")
+	return string(res.Body), nil
 }
 
 type status400Params struct {
@@ -111,108 +62,63 @@ type status400Params struct {
 }
 
 // Uses a bigger timeout for broadcast transactions
-func MakeLongPostRequest(chain string, endpointType string, url string, param []byte) (string, error) {
-	return makePostRequestInternal(chain, endpointType, url, param, clientLongRequest)
+func MakeLongPostRequest(ctx context.Context, store db.Store, chain string, endpointType string, url string, param []byte) (string, error) {
+	return makePostRequestInternal(ctx, store, chain, endpointType, url, param, upstream.BroadcastTimeout)
 }
 
-func MakePostRequest(chain string, endpointType string, url string, param []byte) (string, error) {
-	return makePostRequestInternal(chain, endpointType, url, param, Client)
+func MakePostRequest(ctx context.Context, store db.Store, chain string, endpointType string, url string, param []byte) (string, error) {
+	return makePostRequestInternal(ctx, store, chain, endpointType, url, param, upstream.DefaultTimeout)
 }
 
-func makePostRequestInternal(chain string, endpointType string, url string, param []byte, httpClient http.Client) (string, error) {
+func makePostRequestInternal(ctx context.Context, store db.Store, chain string, endpointType string, url string, param []byte, timeout time.Duration) (string, error) {
 	// Post requests are not using a second cache to avoid returning the incorrect value after submiting a transaction
-
-	i := 1
-	if endpointType == "web3" {
+	res, err := upstream.NewClient(store).Do(ctx, upstream.Request{
+		Chain:        chain,
+		EndpointType: endpointType,
+		Method:       http.MethodPost,
+		Path:         url,
+		Body:         param,
+		Timeout:      timeout,
 		// We are using the best bd endpoint as index 0
 		// Right now they only support web3
-		i = 0
+		Pinned: endpointType == upstream.Web3,
+	})
+	if err != nil {
+		return errorBody(err, http.MethodPost)
 	}
+	return string(res.Body), nil
+}
 
-	for i < 4 {
-		endpoint, err := db.RedisGetEndpoint(chain, endpointType, strconv.FormatInt(int64(i), 10))
-		if err != nil {
-			i++
-			continue
-		}
-
-		var sb strings.Builder
-		sb.WriteString(endpoint)
-		sb.WriteString(url)
-		// It has to be created here because Post delets the buffer
-		body := bytes.NewBuffer(param)
-
-		resp, err := httpClient.Post(sb.String(), "application/json", body)
-		if err != nil {
-			i++
-			continue
-		}
+// errorBody maps the upstream errors that are node answers to the error bodies
+// returned by the v1 API, the other errors are returned.
+func errorBody(err error, method string) (string, error) {
+	var upstreamErr *upstream.Error
+	if !errors.As(err, &upstreamErr) {
+		return "", err
+	}
 
-		// Handle 404 responses from cosmos api, it's actually element not found
-		if resp.StatusCode == 404 {
-			defer resp.Body.Close()
-			body, _ := io.ReadAll(resp.Body)
-			// endpoint error
-			if strings.Contains(string(body), "Cannot POST") {
-				i++
-				continue
-			}
-			// node element not found
-			return `{"error": "Element not found"}`, nil
+	switch {
+	case errors.Is(err, upstream.ErrNotFound):
+		// node element not found
+		return `{"error": "Element not found"}`, nil
+	case errors.Is(err, upstream.ErrBadRequest):
+		if method != http.MethodPost {
+			return BadRequestError, nil
 		}
 		// Handle 400 responses from api, the txBytes are incorrect
-		if resp.StatusCode == 400 {
-
-			defer resp.Body.Close()
-
-			bodyResponse, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return BadRequestError, nil
-			}
-
-			if len(string(bodyResponse)) == 0 {
-				return BadRequestError, nil
-			}
-
-			m := status400Params{}
-
-			err = json.Unmarshal(bodyResponse, &m)
-			if err != nil {
-				return BadRequestError, nil
-			}
-
-			if m.Code != 0 {
-				return "{\"error\": \"" + m.Message + "\"}", nil
-			}
-
+		m := status400Params{}
+		if err := json.Unmarshal(upstreamErr.Body, &m); err != nil || m.Code == 0 {
 			return BadRequestError, nil
 		}
-
-		if resp.StatusCode == 500 {
-			// Case: when you send a tx with an incorrect sequence.
-			return `{"error": "Couldn't broadcast tx, please try again"}`, nil
-		}
-
-		// Only 200 and 404 are valid status code responses
-		if resp.StatusCode != 200 && resp.StatusCode != 404 {
-			i++
-			continue
-		}
-
-		defer resp.Body.Close()
-
-		bodyResponse, err := io.ReadAll(resp.Body)
-
-		if err != nil || len(string(bodyResponse)) == 0 {
-			i++
-			continue
-		}
-
-		return string(bodyResponse), nil
+		return "{\"error\": \"" + m.Message + "\"}", nil
+	case errors.Is(err, upstream.ErrServerError):
+		// Case: when you send a tx with an incorrect sequence.
+		return `{"error": "Couldn't broadcast tx, please try again"}`, nil
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "", fmt.Errorf("request canceled: %w", err)
+	default:
+		return "", err
 	}
-
-	metrics.Send(fmt.Sprintln("All endpoints failed to get response(POST): ", chain, url))
-	return "", fmt.Errorf("all endpoints are down")
 }
 
 func MakePostGasPrice(url string) (string, error) {
@@ -0,0 +1,49 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package requester
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+)
+
+func TestMakePostRequestErrorBodies(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   int
+		body     string
+		expected string
+	}{
+		{name: "not found", status: http.StatusNotFound, body: `{}`, expected: `{"error": "Element not found"}`},
+		{name: "bad request", status: http.StatusBadRequest, body: `{"code":3,"message":"invalid tx"}`, expected: `{"error": "invalid tx"}`},
+		{name: "bad request without code", status: http.StatusBadRequest, body: `invalid`, expected: BadRequestError},
+		{name: "server error", status: http.StatusInternalServerError, expected: `{"error": "Couldn't broadcast tx, please try again"}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer node.Close()
+
+			store := db.NewMemoryStore(100)
+			now := time.Now()
+			if err := db.RedisSetEndpointRanking(store, "POST", "rest", []db.RankedEndpoint{{URL: node.URL, LastChecked: now}}, now); err != nil {
+				t.Fatal(err)
+			}
+
+			val, err := MakePostRequest(context.Background(), store, "POST", "rest", "/cosmos/tx/v1beta1/simulate", []byte(`{}`))
+			if err != nil || val != tc.expected {
+				t.Fatalf("expected %q, got %q %v", tc.expected, val, err)
+			}
+		})
+	}
+}
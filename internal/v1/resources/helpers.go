@@ -8,80 +8,81 @@ import (
 
 	"github.com/tharsis/dashboard-backend/internal/v1/constants"
 	"github.com/tharsis/dashboard-backend/internal/v1/db"
-	"github.com/tharsis/dashboard-backend/internal/v1/requester"
 )
 
-func GetERC20Tokens() ([]CoinConfig, error) {
-	var erc20tokens []CoinConfig
-	if redisVal, err := db.RedisGetERC20TokensDirectory(); err == nil && redisVal != "null" && redisVal != "[]" {
-		err = json.Unmarshal([]byte(redisVal), &erc20tokens)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		gitRes, err := requester.GetERC20TokensDirectory()
+// GetERC20Tokens returns the tokens of the registry, the invalid files are skipped.
+func GetERC20Tokens(store db.Store) ([]CoinConfig, error) {
+	val, err := db.RedisGetOrComputeERC20TokensDirectory(store, func() (string, error) {
+		erc20tokens, err := loadRegistryFiles[CoinConfig](store, ERC20TokensFolder, coinConfigValidator)
 		if err != nil {
-			return nil, err
-		}
-
-		for _, v := range gitRes {
-			var coinConfig CoinConfig
-			err := json.Unmarshal([]byte(v.Content), &coinConfig)
-			if err != nil {
-				return nil, err
-			}
-			erc20tokens = append(erc20tokens, coinConfig)
+			return "", err
 		}
 
 		stringRes, err := json.Marshal(erc20tokens)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
-
-		db.RedisSetERC20TokensDirectory(string(stringRes))
+		return string(stringRes), nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	var erc20tokens []CoinConfig
+	if err := json.Unmarshal([]byte(val), &erc20tokens); err != nil {
+		return nil, err
+	}
 	return erc20tokens, nil
 }
 
-func GetNetworkConfigs() ([]NetworkConfig, error) {
-	var networkConfigs []NetworkConfig
-	if redisVal, err := db.RedisGetNetworkConfig(); err == nil && redisVal != "null" {
-		err = json.Unmarshal([]byte(redisVal), &networkConfigs)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		gitRes, err := requester.GetNetworkConfig()
+// GetNetworkConfigs returns the network configs of the registry, the invalid files are skipped.
+func GetNetworkConfigs(store db.Store) ([]NetworkConfig, error) {
+	val, err := db.RedisGetOrComputeNetworkConfig(store, func() (string, error) {
+		networkConfigs, err := loadRegistryFiles[NetworkConfig](store, NetworkConfigsFolder, networkConfigValidator)
 		if err != nil {
-			return nil, err
-		}
-
-		for _, v := range gitRes {
-			var networkConfig NetworkConfig
-			err := json.Unmarshal([]byte(v.Content), &networkConfig)
-			if err != nil {
-				return nil, err
-			}
-			networkConfigs = append(networkConfigs, networkConfig)
+			return "", err
 		}
 
 		stringRes, err := json.Marshal(networkConfigs)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
-
-		db.RedisSetNetworkConfig(string(stringRes))
+		return string(stringRes), nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	var networkConfigs []NetworkConfig
+	if err := json.Unmarshal([]byte(val), &networkConfigs); err != nil {
+		return nil, err
+	}
 	return networkConfigs, nil
 }
 
 func GetMainnetConfig(nc NetworkConfig) ConfigurationEntry {
+	return GetConfig(nc, constants.Mainnet)
+}
+
+// GetConfig returns the configuration of the network for the environment, a
+// configuration type, or an empty one when the network has none.
+func GetConfig(nc NetworkConfig, environment string) ConfigurationEntry {
 	for _, nc := range nc.Configurations {
-		if nc.ConfigurationType == constants.Mainnet {
+		if nc.ConfigurationType == environment {
 			return nc
 		}
 	}
 	return ConfigurationEntry{}
 }
+
+// GetNetworkIdentifier returns the identifier the handlers query the network
+// with in every environment, the one of its mainnet configuration.
+func GetNetworkIdentifier(nc NetworkConfig) string {
+	if identifier := GetMainnetConfig(nc).Identifier; identifier != "" {
+		return identifier
+	}
+	if len(nc.Configurations) > 0 {
+		return nc.Configurations[0].Identifier
+	}
+	return ""
+}
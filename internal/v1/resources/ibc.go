@@ -7,10 +7,11 @@ import (
 	"strings"
 
 	"github.com/tharsis/dashboard-backend/internal/v1/constants"
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
 )
 
-func GetIBCChannels() (map[string]map[string]string, error) {
-	networkConfigs, err := GetNetworkConfigs()
+func GetIBCChannels(store db.Store) (map[string]map[string]string, error) {
+	networkConfigs, err := GetNetworkConfigs(store)
 	if err != nil {
 		return nil, err
 	}
@@ -31,8 +32,8 @@ func GetIBCChannels() (map[string]map[string]string, error) {
 	return ibcChannels, nil
 }
 
-func GetIBCCoins() (map[string]map[string]string, error) {
-	erc20tokens, err := GetERC20Tokens()
+func GetIBCCoins(store db.Store) (map[string]map[string]string, error) {
+	erc20tokens, err := GetERC20Tokens(store)
 	if err != nil {
 		return nil, err
 	}
@@ -3,8 +3,10 @@
 
 package resources
 
+// NetworkConfig is a chainConfig file of the chain-token-registry.
+// The fields tagged as required are validated when the files are loaded.
 type NetworkConfig struct {
-	Prefix       string `json:"prefix"`
+	Prefix       string `json:"prefix" openapi:"required"`
 	GasPriceStep struct {
 		Low     string `json:"low"`
 		Average string `json:"average"`
@@ -13,18 +15,19 @@ type NetworkConfig struct {
 	Bip44 struct {
 		CoinType string `json:"coinType"`
 	} `json:"bip44"`
-	Configurations []ConfigurationEntry `json:"configurations"`
+	Configurations []ConfigurationEntry `json:"configurations" openapi:"required"`
 }
 
 type ConfigurationEntry struct {
-	ChainID    string   `json:"chainId"`
+	ChainID    string   `json:"chainId" openapi:"required"`
 	ChainName  string   `json:"chainName"`
-	Identifier string   `json:"identifier"`
+	Identifier string   `json:"identifier" openapi:"required"`
 	ClientID   string   `json:"clientId"`
 	Rest       []string `json:"rest"`
 	Jrpc       []string `json:"jrpc"`
 	Web3       []string `json:"web3"`
-	RPC        []string `json:"rpc"`
+	RPC        []string `json:"rpc"`            // Tendermint RPC endpoints, e.g. https://tendermint.evmos.example
+	GRPC       []string `json:"grpc,omitempty"` // gRPC endpoints, e.g. grpc.evmos.example:443
 	Currencies []struct {
 		CoinDenom    string `json:"coinDenom"`
 		CoinMinDenom string `json:"coinMinDenom"`
@@ -36,24 +39,26 @@ type ConfigurationEntry struct {
 		DestinationChannel    string   `json:"destinationChannel"`
 		JSONRPC               []string `json:"jsonRPC"`
 	} `json:"source"`
-	ConfigurationType string `json:"configurationType"`
+	ConfigurationType string `json:"configurationType" openapi:"required"`
 	ExplorerTxURL     string `json:"explorerTxUrl"`
 }
 
+// CoinConfig is a tokens file of the chain-token-registry.
+// The fields tagged as required are validated when the files are loaded.
 type CoinConfig struct {
-	CoinDenom           string `json:"coinDenom"`
+	CoinDenom           string `json:"coinDenom" openapi:"required"`
 	MinCoinDenom        string `json:"minCoinDenom"`
 	ImgSrc              string `json:"imgSrc"`
 	PngSrc              string `json:"pngSrc"`
 	Type                string `json:"type"`
-	Exponent            string `json:"exponent"`
-	CosmosDenom         string `json:"cosmosDenom"`
+	Exponent            string `json:"exponent" openapi:"required"`
+	CosmosDenom         string `json:"cosmosDenom" openapi:"required"`
 	Description         string `json:"description"`
 	Name                string `json:"name"`
 	TokenRepresentation string `json:"tokenRepresentation"`
 	Channel             string `json:"channel"`
 	IsIBCEnabled        bool   `json:"isEnabled"`
-	Erc20Address        string `json:"erc20Address"`
+	Erc20Address        string `json:"erc20Address" openapi:"required"`
 	Ibc                 struct {
 		SourceDenom string `json:"sourceDenom"`
 		Source      string `json:"source"`
@@ -63,9 +68,9 @@ type CoinConfig struct {
 		URL            string `json:"url"`
 		HandlingAction string `json:"handlingAction"`
 	} `json:"handledByExternalUI"`
-	CoingeckoID      string `json:"coingeckoId"`
+	CoingeckoID      string `json:"coingeckoId" openapi:"required"`
 	Category         string `json:"category"`
-	CoinSourcePrefix string `json:"coinSourcePrefix"`
+	CoinSourcePrefix string `json:"coinSourcePrefix" openapi:"required"`
 }
 
 type ERC20ModuleCoin struct {
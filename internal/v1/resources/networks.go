@@ -5,10 +5,12 @@ package resources
 
 import (
 	"strings"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
 )
 
-func GetNetworks() ([]string, error) {
-	networkConfigs, err := GetNetworkConfigs()
+func GetNetworks(store db.Store) ([]string, error) {
+	networkConfigs, err := GetNetworkConfigs(store)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,175 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package resources
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v1/requester"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/tharsis/dashboard-backend/internal/v2/openapi"
+)
+
+// Folders of the chain-token-registry the resources are loaded from.
+const (
+	NetworkConfigsFolder = "chainConfig"
+	ERC20TokensFolder    = "tokens"
+)
+
+var (
+	networkConfigValidator = openapi.NewValidator(NetworkConfig{})
+	coinConfigValidator    = openapi.NewValidator(CoinConfig{})
+)
+
+// RegistryFile is the result of the load of a registry file.
+type RegistryFile struct {
+	// Path of the file in the repository, e.g. chainConfig/evmos.json
+	Path   string `json:"path"`
+	Loaded bool   `json:"loaded"`
+	// Error is the reason the file was skipped
+	Error string `json:"error,omitempty"`
+}
+
+// RegistryLoad is the status of the last load of a registry folder.
+type RegistryLoad struct {
+	Folder   string         `json:"folder"`
+	LoadedAt time.Time      `json:"loadedAt"`
+	Loaded   int            `json:"loaded"`
+	Skipped  int            `json:"skipped"`
+	Files    []RegistryFile `json:"files"`
+}
+
+// loadRegistryFiles validates the files of folder against the schema of T and
+// decodes the valid ones. The invalid files are skipped instead of failing
+// the whole load, the status of every file is stored for GetRegistryLoad.
+func loadRegistryFiles[T any](store db.Store, folder string, validator *openapi.Validator) ([]T, error) {
+	var files []requester.File
+	var err error
+	switch folder {
+	case NetworkConfigsFolder:
+		files, err = requester.GetNetworkConfig(store)
+	case ERC20TokensFolder:
+		files, err = requester.GetERC20TokensDirectory(store)
+	default:
+		return nil, fmt.Errorf("unknown registry folder %q", folder)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	load := RegistryLoad{Folder: folder, LoadedAt: time.Now().UTC(), Files: []RegistryFile{}}
+	var res []T
+	for _, f := range files {
+		var v T
+		err := validator.Validate([]byte(f.Content))
+		if err == nil {
+			err = json.Unmarshal([]byte(f.Content), &v)
+		}
+		if err != nil {
+			logging.Default().Warn("Skipping invalid registry file", "path", f.URL, "error", err)
+			load.Skipped++
+			load.Files = append(load.Files, RegistryFile{Path: f.URL, Error: err.Error()})
+			continue
+		}
+		load.Loaded++
+		load.Files = append(load.Files, RegistryFile{Path: f.URL, Loaded: true})
+		res = append(res, v)
+	}
+
+	status, err := json.Marshal(load)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.RedisSetRegistryStatus(store, folder, string(status)); err != nil {
+		logging.Default().Warn("Error storing registry status", "folder", folder, "error", err)
+	}
+	return res, nil
+}
+
+// GetRegistryLoad returns the status of the last load of the registry folder,
+// NetworkConfigsFolder or ERC20TokensFolder. The folder is loaded when it has no status yet.
+func GetRegistryLoad(store db.Store, folder string) (RegistryLoad, error) {
+	val, err := db.RedisGetRegistryStatus(store, folder)
+	if errors.Is(err, db.ErrNotFound) {
+		// The resources can be cached by an older version that did not record
+		// the status, so the files are loaded again instead of using the cache
+		switch folder {
+		case NetworkConfigsFolder:
+			_, err = loadRegistryFiles[NetworkConfig](store, folder, networkConfigValidator)
+		case ERC20TokensFolder:
+			_, err = loadRegistryFiles[CoinConfig](store, folder, coinConfigValidator)
+		default:
+			err = fmt.Errorf("unknown registry folder %q", folder)
+		}
+		if err != nil {
+			return RegistryLoad{}, err
+		}
+		val, err = db.RedisGetRegistryStatus(store, folder)
+	}
+	if err != nil {
+		return RegistryLoad{}, err
+	}
+
+	var load RegistryLoad
+	if err := json.Unmarshal([]byte(val), &load); err != nil {
+		return RegistryLoad{}, err
+	}
+	return load, nil
+}
+
+// ReloadRegistry purges the cached files of the repository and the resources
+// built from them, loads them again and records commit as the loaded one.
+func ReloadRegistry(store db.Store, repo requester.Repository, commit string) error {
+	if err := db.RedisDeleteGithubResponses(store, requester.GithubAPIURL(repo)); err != nil {
+		return fmt.Errorf("error purging the %s github cache: %w", repo, err)
+	}
+
+	switch repo {
+	case requester.ChainTokenRegistry:
+		if err := db.RedisDeleteNetworkConfig(store); err != nil {
+			return err
+		}
+		if err := db.RedisDeleteERC20TokensDirectory(store); err != nil {
+			return err
+		}
+		if _, err := GetNetworkConfigs(store); err != nil {
+			return fmt.Errorf("error reloading the network configs: %w", err)
+		}
+		if _, err := GetERC20Tokens(store); err != nil {
+			return fmt.Errorf("error reloading the ERC20 tokens: %w", err)
+		}
+	case requester.ValidatorDirectory:
+		if err := db.RedisDeleteValidatorDirectory(store); err != nil {
+			return err
+		}
+		if _, err := requester.GetValidatorDirectory(store); err != nil {
+			return fmt.Errorf("error reloading the validator directory: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown registry repository %q", repo)
+	}
+
+	return db.RedisSetRegistryCommit(store, string(repo), commit)
+}
+
+// GetRegistryCommits returns the commits the registry repositories were last
+// reloaded from, by repository. The repositories never reloaded are missing.
+func GetRegistryCommits(store db.Store) (map[string]string, error) {
+	commits := map[string]string{}
+	for _, repo := range []requester.Repository{requester.ChainTokenRegistry, requester.ValidatorDirectory} {
+		commit, err := db.RedisGetRegistryCommit(store, string(repo))
+		if errors.Is(err, db.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		commits[string(repo)] = commit
+	}
+	return commits, nil
+}
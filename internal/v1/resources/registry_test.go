@@ -0,0 +1,112 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package resources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v1/requester"
+)
+
+func TestLoadRegistrySkipsInvalidFiles(t *testing.T) {
+	files := map[string]string{
+		"chainConfig/evmos.json":   `{"prefix":"evmos","configurations":[{"chainId":"evmos_9001-2","identifier":"evmos","configurationType":"mainnet"}]}`,
+		"chainConfig/empty.json":   `{"prefix":"empty","configurations":[]}`,
+		"chainConfig/nochain.json": `{"prefix":"nochain","configurations":[{"identifier":"nochain","configurationType":"mainnet"}]}`,
+		"tokens/evmos.json": `{"coinDenom":"EVMOS","exponent":"18","cosmosDenom":"aevmos","erc20Address":"0xD4949664cD82660AaE99bEdc034a0deA8A0bd517",` +
+			`"coingeckoId":"evmos","coinSourcePrefix":"evmos"}`,
+		"tokens/nogecko.json": `{"coinDenom":"X","exponent":"6","cosmosDenom":"ux","erc20Address":"0x1","coingeckoId":"","coinSourcePrefix":"x"}`,
+		"tokens/broken.json":  `{"coinDenom":`,
+	}
+	root := t.TempDir()
+	for path, content := range files {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	requester.SetRegistrySource(requester.LocalSource{Dirs: map[requester.Repository]string{requester.ChainTokenRegistry: root}})
+	t.Cleanup(func() { requester.SetRegistrySource(requester.GithubSource{}) })
+
+	store := db.NewMemoryStore(100)
+	configs, err := GetNetworkConfigs(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 1 || configs[0].Prefix != "evmos" {
+		t.Fatalf("expected only the evmos network config, got %+v", configs)
+	}
+	tokens, err := GetERC20Tokens(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 1 || tokens[0].CoinDenom != "EVMOS" {
+		t.Fatalf("expected only the EVMOS token, got %+v", tokens)
+	}
+
+	tests := []struct {
+		folder string
+		want   []RegistryFile
+	}{
+		{NetworkConfigsFolder, []RegistryFile{
+			{Path: "chainConfig/empty.json", Error: "configurations cannot be empty"},
+			{Path: "chainConfig/evmos.json", Loaded: true},
+			{Path: "chainConfig/nochain.json", Error: "configurations[0].chainId is required"},
+		}},
+		{ERC20TokensFolder, []RegistryFile{
+			{Path: "tokens/broken.json", Error: "invalid JSON: unexpected EOF"},
+			{Path: "tokens/evmos.json", Loaded: true},
+			{Path: "tokens/nogecko.json", Error: "coingeckoId cannot be empty"},
+		}},
+	}
+	for _, tc := range tests {
+		load, err := GetRegistryLoad(store, tc.folder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if load.Loaded != 1 || load.Skipped != 2 || len(load.Files) != len(tc.want) {
+			t.Fatalf("unexpected %s status %+v", tc.folder, load)
+		}
+		for i, want := range tc.want {
+			if load.Files[i] != want {
+				t.Fatalf("expected %+v, got %+v", want, load.Files[i])
+			}
+		}
+	}
+}
+
+func TestGetRegistryLoadWithoutStatus(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "tokens"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "tokens", "x.json"), []byte(`{"coinDenom":"X"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	requester.SetRegistrySource(requester.LocalSource{Dirs: map[requester.Repository]string{requester.ChainTokenRegistry: root}})
+	t.Cleanup(func() { requester.SetRegistrySource(requester.GithubSource{}) })
+
+	// Tokens cached before the status was recorded
+	store := db.NewMemoryStore(100)
+	if _, err := db.RedisGetOrComputeERC20TokensDirectory(store, func() (string, error) { return `[{"coinDenom":"X"}]`, nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	load, err := GetRegistryLoad(store, ERC20TokensFolder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if load.Skipped != 1 || load.Files[0].Error != "exponent is required" {
+		t.Fatalf("unexpected status %+v", load)
+	}
+	if _, err := GetRegistryLoad(store, "assets"); err == nil {
+		t.Fatalf("expected an error for an unknown folder")
+	}
+}
@@ -5,10 +5,12 @@ package resources
 
 import (
 	"strconv"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
 )
 
-func GetERC20ModuleCoins() (map[string]ERC20ModuleCoin, error) {
-	erc20Tokens, err := GetERC20Tokens()
+func GetERC20ModuleCoins(store db.Store) (map[string]ERC20ModuleCoin, error) {
+	erc20Tokens, err := GetERC20Tokens(store)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,194 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+// Package apikeys manages the API keys given to the integrators of the API.
+// Only the SHA-256 hash of a key is stored, the key itself is returned once
+// when it is created.
+package apikeys
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+)
+
+var (
+	// ErrNotFound is returned when the API key does not exist.
+	ErrNotFound = errors.New("api key not found")
+	// ErrRevoked is returned when the API key has been revoked.
+	ErrRevoked = errors.New("api key revoked")
+)
+
+// Key represents an API key given to an integrator.
+type Key struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// maximum number of requests per UTC day, 0 means unlimited
+	DailyQuota int64 `json:"daily_quota"`
+	// factor applied to the rate limits of the key, 0 uses the configured default
+	RateMultiplier float64    `json:"rate_multiplier"`
+	CreatedAt      time.Time  `json:"created_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+}
+
+// Revoked returns true if the key has been revoked.
+func (k Key) Revoked() bool {
+	return k.RevokedAt != nil
+}
+
+// Store reads and writes the API keys from the db store.
+type Store struct {
+	store db.Store
+}
+
+// NewStore creates an API keys store backed by the db store.
+func NewStore(store db.Store) Store {
+	return Store{store: store}
+}
+
+// Create generates a new API key and stores it.
+// It returns the key record and the secret that has to be shared with the integrator.
+func (s Store) Create(name string, dailyQuota int64, rateMultiplier float64) (Key, string, error) {
+	id, err := randomHex(8)
+	if err != nil {
+		return Key{}, "", err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return Key{}, "", err
+	}
+
+	key := Key{
+		ID:             id,
+		Name:           name,
+		DailyQuota:     dailyQuota,
+		RateMultiplier: rateMultiplier,
+		CreatedAt:      time.Now().UTC(),
+	}
+	record, err := json.Marshal(key)
+	if err != nil {
+		return Key{}, "", err
+	}
+	if err := db.RedisSetAPIKey(s.store, id, hashSecret(secret), string(record)); err != nil {
+		return Key{}, "", err
+	}
+	return key, secret, nil
+}
+
+// Get returns the API key with the given id, including revoked keys.
+func (s Store) Get(id string) (Key, error) {
+	val, err := db.RedisGetAPIKey(s.store, id)
+	if err == db.ErrNotFound {
+		return Key{}, ErrNotFound
+	}
+	if err != nil {
+		return Key{}, err
+	}
+
+	var key Key
+	if err := json.Unmarshal([]byte(val), &key); err != nil {
+		return Key{}, fmt.Errorf("error decoding api key %s: %w", id, err)
+	}
+	return key, nil
+}
+
+// Lookup returns the active API key matching the secret.
+func (s Store) Lookup(secret string) (Key, error) {
+	id, err := db.RedisGetAPIKeyIDByHash(s.store, hashSecret(secret))
+	if err == db.ErrNotFound {
+		return Key{}, ErrNotFound
+	}
+	if err != nil {
+		return Key{}, err
+	}
+
+	key, err := s.Get(id)
+	if err != nil {
+		return Key{}, err
+	}
+	if key.Revoked() {
+		return Key{}, ErrRevoked
+	}
+	return key, nil
+}
+
+// List returns all the stored API keys.
+func (s Store) List() ([]Key, error) {
+	ids, err := db.RedisGetAPIKeyIDs(s.store)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]Key, 0, len(ids))
+	for _, id := range ids {
+		key, err := s.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Revoke marks the API key as revoked, it will be rejected from now on.
+func (s Store) Revoke(id string) (Key, error) {
+	key, err := s.Get(id)
+	if err != nil {
+		return Key{}, err
+	}
+	if key.Revoked() {
+		return key, nil
+	}
+
+	now := time.Now().UTC()
+	key.RevokedAt = &now
+	record, err := json.Marshal(key)
+	if err != nil {
+		return Key{}, err
+	}
+	if err := db.RedisUpdateAPIKey(s.store, id, string(record)); err != nil {
+		return Key{}, err
+	}
+	return key, nil
+}
+
+// IncrementUsage counts a request made with the API key and
+// returns the number of requests made during the current UTC day.
+func (s Store) IncrementUsage(id string, now time.Time) (int64, error) {
+	return db.RedisIncrAPIKeyUsage(s.store, id, day(now))
+}
+
+// Usage returns the number of requests made with the API key during the UTC day of now.
+func (s Store) Usage(id string, now time.Time) (int64, error) {
+	return db.RedisGetAPIKeyUsage(s.store, id, day(now))
+}
+
+// UntilQuotaReset returns the time left until the daily quotas are reset.
+func UntilQuotaReset(now time.Time) time.Duration {
+	now = now.UTC()
+	midnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	return midnight.Sub(now)
+}
+
+func day(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating random bytes: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,51 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package apikeys
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+)
+
+func TestStore(t *testing.T) {
+	s := NewStore(db.NewMemoryStore(100))
+
+	key, secret, err := s.Create("partner", 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := s.Lookup(secret)
+	if err != nil || found.ID != key.ID || found.DailyQuota != 10 {
+		t.Fatalf("expected key %s, got %+v %v", key.ID, found, err)
+	}
+	if _, err := s.Lookup("unknown"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	now := time.Now()
+	for i := int64(1); i <= 2; i++ {
+		if usage, err := s.IncrementUsage(key.ID, now); err != nil || usage != i {
+			t.Fatalf("expected usage %d, got %d %v", i, usage, err)
+		}
+	}
+	if usage, err := s.Usage(key.ID, now.Add(24*time.Hour)); err != nil || usage != 0 {
+		t.Fatalf("expected the usage to reset the next day, got %d %v", usage, err)
+	}
+
+	if _, err := s.Revoke(key.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Lookup(secret); !errors.Is(err, ErrRevoked) {
+		t.Fatalf("expected ErrRevoked, got %v", err)
+	}
+
+	keys, err := s.List()
+	if err != nil || len(keys) != 1 || !keys[0].Revoked() {
+		t.Fatalf("expected the revoked key in the list, got %+v %v", keys, err)
+	}
+}
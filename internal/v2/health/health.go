@@ -0,0 +1,121 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+// Package health runs the dependency checks used by the readiness probe.
+package health
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Status represents the result of a check or of a whole report.
+type Status string
+
+const (
+	// StatusOK means every check passed.
+	StatusOK Status = "ok"
+	// StatusDegraded means only non critical checks failed.
+	StatusDegraded Status = "degraded"
+	// StatusUnavailable means at least one critical check failed.
+	StatusUnavailable Status = "unavailable"
+	// StatusFail is the status of a single failed check.
+	StatusFail Status = "fail"
+)
+
+// Check is a single dependency check. Run returns an error if the dependency
+// is not healthy and an optional detail message otherwise.
+type Check struct {
+	Name string
+	// Critical checks make the whole report unavailable when they fail
+	Critical bool
+	Run      func() (string, error)
+}
+
+// CheckResult is the outcome of a single check.
+type CheckResult struct {
+	Name     string  `json:"name"`
+	Status   Status  `json:"status"`
+	Critical bool    `json:"critical"`
+	Message  string  `json:"message,omitempty"`
+	Duration float64 `json:"duration_ms"`
+}
+
+// Report is the outcome of running a list of checks.
+type Report struct {
+	Status Status        `json:"status"`
+	Checks []CheckResult `json:"checks"`
+}
+
+// Run executes all the checks concurrently and builds the report.
+// A check that does not finish before the timeout is reported as failed.
+func Run(checks []Check, timeout time.Duration) Report {
+	results := make([]CheckResult, len(checks))
+
+	var wg sync.WaitGroup
+	for i := range checks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = runWithTimeout(checks[i], timeout)
+		}(i)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: results}
+	for _, r := range results {
+		if r.Status != StatusFail {
+			continue
+		}
+		if r.Critical {
+			report.Status = StatusUnavailable
+			break
+		}
+		report.Status = StatusDegraded
+	}
+	return report
+}
+
+func runWithTimeout(check Check, timeout time.Duration) CheckResult {
+	type outcome struct {
+		msg string
+		err error
+	}
+
+	start := time.Now()
+	done := make(chan outcome, 1)
+	go func() {
+		msg, err := check.Run()
+		done <- outcome{msg: msg, err: err}
+	}()
+
+	var res outcome
+	select {
+	case res = <-done:
+	case <-time.After(timeout):
+		res.err = fmt.Errorf("check timed out after %v", timeout)
+	}
+
+	result := CheckResult{
+		Name:     check.Name,
+		Status:   StatusOK,
+		Critical: check.Critical,
+		Message:  res.msg,
+		Duration: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if res.err != nil {
+		result.Status = StatusFail
+		result.Message = res.err.Error()
+	}
+	return result
+}
+
+// Freshness returns an error if the given update time is older than maxAge.
+func Freshness(updatedAt time.Time, maxAge time.Duration) (string, error) {
+	age := time.Since(updatedAt).Truncate(time.Second)
+	if age > maxAge {
+		return "", fmt.Errorf("last updated %v ago, max allowed age is %v", age, maxAge)
+	}
+	return fmt.Sprintf("last updated %v ago", age), nil
+}
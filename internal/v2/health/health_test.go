@@ -0,0 +1,61 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package health
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func okCheck(name string, critical bool) Check {
+	return Check{Name: name, Critical: critical, Run: func() (string, error) { return "", nil }}
+}
+
+func failCheck(name string, critical bool) Check {
+	return Check{Name: name, Critical: critical, Run: func() (string, error) { return "", fmt.Errorf("down") }}
+}
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		name   string
+		checks []Check
+		status Status
+	}{
+		{"all ok", []Check{okCheck("a", true), okCheck("b", false)}, StatusOK},
+		{"non critical failure", []Check{okCheck("a", true), failCheck("b", false)}, StatusDegraded},
+		{"critical failure", []Check{failCheck("a", true), failCheck("b", false)}, StatusUnavailable},
+	}
+
+	for _, tc := range testCases {
+		report := Run(tc.checks, time.Second)
+		if report.Status != tc.status {
+			t.Fatalf("%s: expected %s, got %s", tc.name, tc.status, report.Status)
+		}
+		if len(report.Checks) != len(tc.checks) {
+			t.Fatalf("%s: expected %d results, got %d", tc.name, len(tc.checks), len(report.Checks))
+		}
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	slow := Check{Name: "slow", Critical: true, Run: func() (string, error) {
+		time.Sleep(time.Second)
+		return "", nil
+	}}
+
+	report := Run([]Check{slow}, 10*time.Millisecond)
+	if report.Status != StatusUnavailable || report.Checks[0].Status != StatusFail {
+		t.Fatalf("Slow check must fail, got %+v", report)
+	}
+}
+
+func TestFreshness(t *testing.T) {
+	if _, err := Freshness(time.Now().Add(-time.Minute), time.Hour); err != nil {
+		t.Fatalf("Recent update must be fresh: %s", err)
+	}
+	if _, err := Freshness(time.Now().Add(-2*time.Hour), time.Hour); err == nil {
+		t.Fatalf("Old update must be stale")
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package logging
+
+import "context"
+
+const (
+	// RequestIDHeader is the header carrying the request ID, it is echoed
+	// in the responses and sent to the upstream services.
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDUserValue is the fasthttp user value holding the request ID.
+	RequestIDUserValue = "requestID"
+	// LoggerUserValue is the fasthttp user value holding the request-scoped logger.
+	LoggerUserValue = "logger"
+)
+
+// fasthttp.RequestCtx implements context.Context and returns its user values
+// for string keys, so the user values above are read with ctx.Value.
+// contextKey is used for the contexts created by NewContext.
+type contextKey struct{}
+
+type requestScope struct {
+	requestID string
+	logger    *Logger
+}
+
+// NewContext returns a copy of parent carrying the request ID and the logger.
+// It is used to keep the request scope in work that outlives the fasthttp request.
+func NewContext(parent context.Context, requestID string, l *Logger) context.Context {
+	return context.WithValue(parent, contextKey{}, requestScope{requestID: requestID, logger: l})
+}
+
+// FromContext returns the request-scoped logger or the default logger
+// if ctx does not belong to a request.
+func FromContext(ctx context.Context) *Logger {
+	if ctx == nil {
+		return Default()
+	}
+	if l, ok := ctx.Value(LoggerUserValue).(*Logger); ok {
+		return l
+	}
+	if scope, ok := ctx.Value(contextKey{}).(requestScope); ok && scope.logger != nil {
+		return scope.logger
+	}
+	return Default()
+}
+
+// RequestID returns the ID of the request ctx belongs to, or an empty string.
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if id, ok := ctx.Value(RequestIDUserValue).(string); ok {
+		return id
+	}
+	if scope, ok := ctx.Value(contextKey{}).(requestScope); ok {
+		return scope.requestID
+	}
+	return ""
+}
@@ -0,0 +1,247 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+// Package logging provides the structured logger shared by the API and the crons.
+// Every entry is written as a single JSON line with the time, level and message
+// followed by the key-value pairs of the entry, e.g.
+//
+//	{"time":"2023-12-13T10:00:00Z","level":"error","msg":"Error broadcasting tx","request_id":"4f2a...","error":"..."}
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is the severity of a log entry.
+type Level int8
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String returns the name of the level as written in the entries.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	default:
+		return "error"
+	}
+}
+
+// ParseLevel returns the level with the given name, e.g. "info".
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return LevelDebug, nil
+	case "", "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return LevelInfo, fmt.Errorf("invalid log level %q", name)
+	}
+}
+
+// Config represents the logging configuration.
+type Config struct {
+	// LOG_LEVEL: debug, info, warn or error
+	Level string `toml:"level"`
+	// LOG_FILE: file the entries are appended to, stdout when empty
+	File string `toml:"file"`
+}
+
+// LoadEnv overrides the configuration with the LOG_LEVEL and LOG_FILE
+// environment variables if they are set.
+func (c Config) LoadEnv() Config {
+	if level := os.Getenv("LOG_LEVEL"); level != "" {
+		c.Level = level
+	}
+	if file := os.Getenv("LOG_FILE"); file != "" {
+		c.File = file
+	}
+	return c
+}
+
+// output is the destination shared by a logger and the loggers derived from it.
+type output struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Logger writes structured entries at or above its level.
+// It is safe for concurrent use.
+type Logger struct {
+	out    *output
+	level  Level
+	fields []byte
+}
+
+// New creates a logger writing the entries at or above level to w.
+func New(w io.Writer, level Level) *Logger {
+	return &Logger{
+		out:   &output{w: w},
+		level: level,
+	}
+}
+
+// NewFromConfig creates a logger from the configuration.
+func NewFromConfig(cfg Config) (*Logger, error) {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var w io.Writer = os.Stdout
+	if cfg.File != "" {
+		f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("error opening log file: %w", err)
+		}
+		w = f
+	}
+	return New(w, level), nil
+}
+
+// With returns a logger adding the key-value pairs to every entry.
+func (l *Logger) With(keyvals ...interface{}) *Logger {
+	var buf bytes.Buffer
+	buf.Write(l.fields)
+	appendFields(&buf, keyvals)
+	return &Logger{
+		out:    l.out,
+		level:  l.level,
+		fields: buf.Bytes(),
+	}
+}
+
+// Enabled returns true if the entries of the level are written.
+func (l *Logger) Enabled(level Level) bool {
+	return level >= l.level
+}
+
+// Debug writes an entry at debug level.
+func (l *Logger) Debug(msg string, keyvals ...interface{}) {
+	l.log(LevelDebug, msg, keyvals)
+}
+
+// Info writes an entry at info level.
+func (l *Logger) Info(msg string, keyvals ...interface{}) {
+	l.log(LevelInfo, msg, keyvals)
+}
+
+// Warn writes an entry at warn level.
+func (l *Logger) Warn(msg string, keyvals ...interface{}) {
+	l.log(LevelWarn, msg, keyvals)
+}
+
+// Error writes an entry at error level.
+func (l *Logger) Error(msg string, keyvals ...interface{}) {
+	l.log(LevelError, msg, keyvals)
+}
+
+// Printf writes a formatted entry at error level.
+// It lets the logger be used as the fasthttp server logger, which only reports errors.
+func (l *Logger) Printf(format string, args ...interface{}) {
+	l.log(LevelError, fmt.Sprintf(format, args...), nil)
+}
+
+func (l *Logger) log(level Level, msg string, keyvals []interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(`{"time":`)
+	writeValue(&buf, time.Now().UTC().Format(time.RFC3339Nano))
+	buf.WriteString(`,"level":`)
+	writeValue(&buf, level.String())
+	buf.WriteString(`,"msg":`)
+	writeValue(&buf, msg)
+	buf.Write(l.fields)
+	appendFields(&buf, keyvals)
+	buf.WriteString("}\n")
+
+	l.out.mu.Lock()
+	defer l.out.mu.Unlock()
+	_, _ = l.out.w.Write(buf.Bytes())
+}
+
+// appendFields writes the key-value pairs as JSON members, each one preceded by a comma.
+func appendFields(buf *bytes.Buffer, keyvals []interface{}) {
+	for i := 0; i < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = fmt.Sprint(keyvals[i])
+		}
+		var value interface{} = "MISSING"
+		if i+1 < len(keyvals) {
+			value = keyvals[i+1]
+		}
+
+		buf.WriteByte(',')
+		writeValue(buf, key)
+		buf.WriteByte(':')
+		writeValue(buf, value)
+	}
+}
+
+func writeValue(buf *bytes.Buffer, value interface{}) {
+	switch v := value.(type) {
+	case error:
+		value = v.Error()
+	case fmt.Stringer:
+		value = v.String()
+	}
+
+	bz, err := json.Marshal(value)
+	if err != nil {
+		bz, _ = json.Marshal(fmt.Sprint(value))
+	}
+	buf.Write(bz)
+}
+
+var (
+	defaultMu     sync.RWMutex
+	defaultLogger = New(os.Stdout, LevelInfo)
+)
+
+// Default returns the logger used when there is no request-scoped logger.
+func Default() *Logger {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultLogger
+}
+
+// SetDefault replaces the default logger.
+func SetDefault(l *Logger) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultLogger = l
+}
+
+// Setup creates the logger from the configuration and makes it the default one.
+func Setup(cfg Config) (*Logger, error) {
+	l, err := NewFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	SetDefault(l)
+	return l, nil
+}
@@ -0,0 +1,86 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func decodeEntries(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid entry %q: %s", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, LevelInfo).With("request_id", "abc")
+
+	l.Debug("hidden")
+	l.Info("shown", "status", 200)
+	l.Error("failed", "error", errors.New("boom"), "odd")
+
+	entries := decodeEntries(t, &buf)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0]["level"] != "info" || entries[0]["msg"] != "shown" || entries[0]["status"] != float64(200) {
+		t.Fatalf("unexpected entry %v", entries[0])
+	}
+	if entries[1]["error"] != "boom" || entries[1]["odd"] != "MISSING" {
+		t.Fatalf("unexpected entry %v", entries[1])
+	}
+	for _, entry := range entries {
+		if entry["request_id"] != "abc" {
+			t.Fatalf("expected the request ID in %v", entry)
+		}
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	if level, err := ParseLevel("WARN"); err != nil || level != LevelWarn {
+		t.Fatalf("expected warn, got %s %v", level, err)
+	}
+	if level, err := ParseLevel(""); err != nil || level != LevelInfo {
+		t.Fatalf("expected info by default, got %s %v", level, err)
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Fatalf("expected an error for an invalid level")
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, LevelInfo)
+
+	if FromContext(context.Background()) != Default() {
+		t.Fatalf("expected the default logger outside a request")
+	}
+	if RequestID(context.Background()) != "" {
+		t.Fatalf("expected no request ID outside a request")
+	}
+
+	ctx := NewContext(context.Background(), "abc", l)
+	if FromContext(ctx) != l {
+		t.Fatalf("expected the request-scoped logger")
+	}
+	if id := RequestID(ctx); id != "abc" {
+		t.Fatalf("expected request ID abc, got %q", id)
+	}
+}
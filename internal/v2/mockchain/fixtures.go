@@ -0,0 +1,84 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package mockchain
+
+import "net/http"
+
+// Values of the default fixtures, the tests can assert on them.
+const (
+	ChainID = "evmos_9001-2"
+	// Height is the latest block height of the node
+	Height = "13281459"
+	// Address is the account of the default fixtures
+	Address = "evmos1m9705gycgz6ymcharccuwzzhflap3l3nmchpfz"
+	// Validator is the validator of the default fixtures
+	Validator = "evmosvaloper1m9705gycgz6ymcharccuwzzhflap3l3nkkc3gl"
+	// TxHash is the hash of the broadcast and queried transactions
+	TxHash = "3CB7FCC9F5FB31E530CC15665F3FD655AE6CB56CDACAD58D1395C68EDD50D0BB"
+	// GasPrice is the eth_gasPrice result, 1 gwei
+	GasPrice = "0x3b9aca00"
+)
+
+const defaultHeight = 13281459
+
+var defaultRoutes = []route{
+	// Tendermint JSON-RPC
+	{method: http.MethodGet, pattern: "/status", resp: Response{Status: http.StatusOK, Body: `{"jsonrpc":"2.0","id":-1,"result":{` +
+		`"node_info":{"network":"` + ChainID + `","moniker":"mockchain","other":{"tx_index":"on"}},` +
+		`"sync_info":{"latest_block_height":"` + Height + `","latest_block_time":"2023-06-01T00:00:00Z","catching_up":false}}}`}},
+	{method: http.MethodGet, pattern: "/tx?hash=*", resp: Response{Status: http.StatusOK, Body: `{"jsonrpc":"2.0","id":-1,"result":{` +
+		`"hash":"` + TxHash + `","height":"` + Height + `","index":0,"tx_result":{"code":0,"log":"[]"}}}`}},
+
+	// Cosmos REST
+	{method: http.MethodGet, pattern: "/cosmos/base/tendermint/v1beta1/blocks/latest", resp: Response{Status: http.StatusOK, Body: `{"block":{` +
+		`"header":{"chain_id":"` + ChainID + `","height":"` + Height + `","time":"2023-06-01T00:00:00Z"}}}`}},
+	{method: http.MethodGet, pattern: "/cosmos/auth/v1beta1/params", resp: Response{Status: http.StatusOK, Body: `{"params":{` +
+		`"max_memo_characters":"256","tx_sig_limit":"7","tx_size_cost_per_byte":"10"}}`}},
+	{method: http.MethodGet, pattern: "/cosmos/auth/v1beta1/accounts/*", resp: Response{Status: http.StatusOK, Body: `{"account":{` +
+		`"@type":"/evmos.vesting.v2.ClawbackVestingAccount",` +
+		`"base_vesting_account":{"base_account":{"address":"` + Address + `","pub_key":null,"account_number":"1","sequence":"0"},` +
+		`"original_vesting":[{"denom":"aevmos","amount":"1000"}],"delegated_free":[],"delegated_vesting":[],"end_time":"1700000000"},` +
+		`"funder_address":"` + Address + `","start_time":"2023-01-01T00:00:00Z",` +
+		`"lockup_periods":[{"length":"31622400","amount":[{"denom":"aevmos","amount":"1000"}]}],` +
+		`"vesting_periods":[{"length":"31622400","amount":[{"denom":"aevmos","amount":"1000"}]}]}}`}},
+	{method: http.MethodGet, pattern: "/evmos/vesting/v2/balances/*", resp: Response{Status: http.StatusOK, Body: `{` +
+		`"locked":[{"denom":"aevmos","amount":"1000"}],"unvested":[{"denom":"aevmos","amount":"1000"}],"vested":[]}`}},
+	{method: http.MethodGet, pattern: "/cosmos/staking/v1beta1/validators*", resp: Response{Status: http.StatusOK, Body: `{"validators":[{` +
+		`"operator_address":"` + Validator + `","jailed":false,"status":"BOND_STATUS_BONDED","tokens":"1000000",` +
+		`"delegator_shares":"1000000.000000000000000000","description":{"moniker":"mockchain"},` +
+		`"commission":{"commission_rates":{"rate":"0.050000000000000000","max_rate":"0.200000000000000000","max_change_rate":"0.010000000000000000"}}}],` +
+		`"pagination":{"next_key":null,"total":"1"}}`}},
+	{method: http.MethodGet, pattern: "/cosmos/staking/v1beta1/delegations/*", resp: Response{Status: http.StatusOK, Body: `{"delegation_responses":[{` +
+		`"delegation":{"delegator_address":"` + Address + `","validator_address":"` + Validator + `","shares":"1500.000000000000000000"},` +
+		`"balance":{"denom":"aevmos","amount":"1500"}}],"pagination":{"next_key":null,"total":"1"}}`}},
+	{method: http.MethodGet, pattern: "/cosmos/staking/v1beta1/delegators/*", resp: Response{Status: http.StatusOK, Body: `{` +
+		`"unbonding_responses":[],"pagination":{"next_key":null,"total":"0"}}`}},
+	{method: http.MethodGet, pattern: "/cosmos/staking/v1beta1/pool", resp: Response{Status: http.StatusOK, Body: `{"pool":{` +
+		`"not_bonded_tokens":"0","bonded_tokens":"1000000"}}`}},
+	{method: http.MethodPost, pattern: "/cosmos/tx/v1beta1/txs", resp: Response{Status: http.StatusOK, Body: `{"tx_response":{` +
+		`"height":"0","txhash":"` + TxHash + `","codespace":"","code":0,"data":"","raw_log":"[]","logs":[],"info":"",` +
+		`"gas_wanted":"0","gas_used":"0","tx":null,"timestamp":"","events":[]}}`}},
+	{method: http.MethodPost, pattern: "/cosmos/tx/v1beta1/simulate", resp: Response{Status: http.StatusOK, Body: `{` +
+		`"gas_info":{"gas_wanted":"0","gas_used":"150000"},"result":{"data":"","log":"","events":[]}}`}},
+
+	// Numia
+	{method: http.MethodGet, pattern: "/height", resp: Response{Status: http.StatusOK, Body: `{` +
+		`"latestBlockHash":"` + TxHash + `","latestBlockHeight":"` + Height + `","latestBlockTime":"2023-06-01T00:00:00Z"}`}},
+	{method: http.MethodGet, pattern: "/evmos/delegations/*", resp: Response{Status: http.StatusOK, Body: `[{` +
+		`"validatorAddress":"` + Validator + `","delegated":{"denom":"aevmos","amount":"1500"},"unclaimed":{"denom":"aevmos","amount":"10"}}]`}},
+	{method: http.MethodGet, pattern: "/evmos/rewards/*", resp: Response{Status: http.StatusOK, Body: `[{` +
+		`"month":"2023-05","address":"` + Address + `","withdrawn_rewards_usd":1.5,"withdrawn_rewards_evmos":10}]`}},
+	{method: http.MethodGet, pattern: "/evmos/account/*", resp: Response{Status: http.StatusOK, Body: `{` +
+		`"locked":[{"denom":"aevmos","amount":"1000"}],"unvested":[{"denom":"aevmos","amount":"1000"}],"vested":[],` +
+		`"account":{"@type":"/evmos.vesting.v2.ClawbackVestingAccount","funder_address":"` + Address + `","start_time":"2023-01-01T00:00:00Z"}}`}},
+}
+
+// defaultRPCResults are the results of the web3 JSON-RPC methods.
+var defaultRPCResults = map[string]string{
+	"eth_chainId":     `"0x2329"`,
+	"eth_blockNumber": `"0xcaa8b3"`,
+	"eth_gasPrice":    `"` + GasPrice + `"`,
+	// eth_call returns a zero uint256, e.g. an empty ERC20 balance
+	"eth_call": `"0x0000000000000000000000000000000000000000000000000000000000000000"`,
+}
@@ -0,0 +1,203 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+// Package mockchain runs a local fake node for the tests: it answers the
+// Cosmos REST, Tendermint JSON-RPC, web3 JSON-RPC and Numia routes the API
+// queries with scriptable fixtures, so full request flows run in go test.
+package mockchain
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+)
+
+// Response is the answer of the node to a route.
+type Response struct {
+	Status int
+	Body   string
+}
+
+// Request is a request received by the node.
+type Request struct {
+	Method string
+	// Path with its query string
+	Path string
+	Body string
+	// RPCMethod is the method of the JSON-RPC requests
+	RPCMethod string
+}
+
+type route struct {
+	method  string
+	pattern string
+	resp    Response
+}
+
+// Node is a fake node serving fixtures. The routes answer the REST, Tendermint
+// URI and Numia requests, the JSON-RPC methods answer the POST requests with a
+// JSON-RPC body sent to any path.
+type Node struct {
+	server *httptest.Server
+
+	mu sync.Mutex
+	// routes are matched from the last registered one
+	routes   []route
+	rpc      map[string]Response
+	requests []Request
+}
+
+// New starts a node serving the default fixtures, see fixtures.go.
+// The node is closed when the test ends.
+func New(t testing.TB) *Node {
+	t.Helper()
+	n := &Node{rpc: map[string]Response{}}
+	n.server = httptest.NewServer(http.HandlerFunc(n.serveHTTP))
+	t.Cleanup(n.server.Close)
+
+	for _, f := range defaultRoutes {
+		n.Handle(f.method, f.pattern, f.resp.Status, f.resp.Body)
+	}
+	for method, result := range defaultRPCResults {
+		n.HandleRPC(method, result)
+	}
+	return n
+}
+
+// URL returns the base URL of the node.
+func (n *Node) URL() string {
+	return n.server.URL
+}
+
+// Handle answers the requests matching method and pattern with status and body,
+// replacing the fixtures registered before for the same requests.
+// The pattern is matched against the path, and against the query string too
+// when it has one. A * in the pattern matches any characters.
+func (n *Node) Handle(method string, pattern string, status int, body string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.routes = append(n.routes, route{method: method, pattern: pattern, resp: Response{Status: status, Body: body}})
+}
+
+// HandleRPC answers the JSON-RPC requests of method with result, a JSON value.
+func (n *Node) HandleRPC(method string, result string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.rpc[method] = Response{Status: http.StatusOK, Body: `{"jsonrpc":"2.0","id":%ID%,"result":` + result + `}`}
+}
+
+// HandleRPCError answers the JSON-RPC requests of method with an error.
+func (n *Node) HandleRPCError(method string, code int, message string) {
+	rpcErr, _ := json.Marshal(map[string]interface{}{"code": code, "message": message})
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.rpc[method] = Response{Status: http.StatusOK, Body: `{"jsonrpc":"2.0","id":%ID%,"error":` + string(rpcErr) + `}`}
+}
+
+// Requests returns the requests received by the node, oldest first.
+func (n *Node) Requests() []Request {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]Request(nil), n.requests...)
+}
+
+// Register publishes the node as the only REST, Tendermint JSON-RPC and web3
+// endpoint of the chain in the store, as the endpoint cron does.
+func (n *Node) Register(t testing.TB, store db.Store, chain string) {
+	t.Helper()
+	now := time.Now()
+	endpoints := []db.RankedEndpoint{{URL: n.URL(), Height: defaultHeight, LastChecked: now, Score: 1}}
+	for _, endpointType := range []string{"rest", "jrpc", "web3"} {
+		if err := db.RedisSetEndpointRanking(store, chain, endpointType, endpoints, now); err != nil {
+			t.Fatalf("error registering the mock node: %v", err)
+		}
+	}
+}
+
+// UseAsNumia points the Numia client to the node for the rest of the test.
+func (n *Node) UseAsNumia(t testing.TB) {
+	t.Setenv("NUMIA_RPC_ENDPOINT", n.URL())
+	t.Setenv("NUMIA_API_KEY", "mockchain")
+}
+
+func (n *Node) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	path := r.URL.Path
+	if r.URL.RawQuery != "" {
+		path += "?" + r.URL.RawQuery
+	}
+	req := Request{Method: r.Method, Path: path, Body: string(body)}
+
+	var rpcReq struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	if r.Method == http.MethodPost && json.Unmarshal(body, &rpcReq) == nil {
+		req.RPCMethod = rpcReq.Method
+	}
+
+	resp, ok := n.match(req)
+	if !ok {
+		resp = Response{Status: http.StatusNotImplemented, Body: `{"error":"no fixture for ` + r.Method + " " + path + `"}`}
+	}
+	if req.RPCMethod != "" {
+		id := string(rpcReq.ID)
+		if id == "" {
+			id = "null"
+		}
+		resp.Body = strings.ReplaceAll(resp.Body, "%ID%", id)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.Status)
+	_, _ = w.Write([]byte(resp.Body))
+}
+
+// match records the request and returns its fixture.
+func (n *Node) match(req Request) (Response, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.requests = append(n.requests, req)
+
+	if req.RPCMethod != "" {
+		if resp, ok := n.rpc[req.RPCMethod]; ok {
+			return resp, true
+		}
+	}
+	for i := len(n.routes) - 1; i >= 0; i-- {
+		r := n.routes[i]
+		if r.method == req.Method && matchRoute(r.pattern, req.Path) {
+			return r.resp, true
+		}
+	}
+	return Response{}, false
+}
+
+func matchRoute(pattern string, path string) bool {
+	if !strings.Contains(pattern, "?") {
+		path, _, _ = strings.Cut(path, "?")
+	}
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	path = path[len(parts[0]):]
+	for i, part := range parts[1:] {
+		if i == len(parts)-2 {
+			return strings.HasSuffix(path, part)
+		}
+		j := strings.Index(path, part)
+		if j < 0 {
+			return false
+		}
+		path = path[j+len(part):]
+	}
+	return path == ""
+}
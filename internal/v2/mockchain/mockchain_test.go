@@ -0,0 +1,86 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package mockchain
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+)
+
+func request(t *testing.T, method string, url string, body string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(b)
+}
+
+func TestNode(t *testing.T) {
+	n := New(t)
+
+	if status, body := request(t, http.MethodGet, n.URL()+"/status", ""); status != http.StatusOK || !strings.Contains(body, ChainID) {
+		t.Fatalf("expected the default status fixture, got %d %s", status, body)
+	}
+	if status, _ := request(t, http.MethodGet, n.URL()+"/unknown", ""); status != http.StatusNotImplemented {
+		t.Fatalf("expected the requests without fixture to fail, got %d", status)
+	}
+
+	// The last registered fixture wins, the query string is only matched when the pattern has one
+	n.Handle(http.MethodGet, "/cosmos/staking/v1beta1/pool", http.StatusInternalServerError, `{}`)
+	if status, _ := request(t, http.MethodGet, n.URL()+"/cosmos/staking/v1beta1/pool?height=1", ""); status != http.StatusInternalServerError {
+		t.Fatalf("expected the scripted fixture, got %d", status)
+	}
+
+	// A * matches any characters, in the middle of the pattern too
+	n.Handle(http.MethodGet, "/cosmos/bank/v1beta1/balances/*/by_denom", http.StatusOK, `{"balance":{}}`)
+	if status, body := request(t, http.MethodGet, n.URL()+"/cosmos/bank/v1beta1/balances/"+Address+"/by_denom?denom=aevmos", ""); status != http.StatusOK || body != `{"balance":{}}` {
+		t.Fatalf("expected the wildcard fixture, got %d %s", status, body)
+	}
+	if status, _ := request(t, http.MethodGet, n.URL()+"/cosmos/bank/v1beta1/balances/"+Address, ""); status != http.StatusNotImplemented {
+		t.Fatalf("expected the wildcard fixture to match the whole path, got %d", status)
+	}
+
+	// The JSON-RPC answers carry the ID of the request
+	_, body := request(t, http.MethodPost, n.URL()+"/", `{"jsonrpc":"2.0","method":"eth_gasPrice","params":[],"id":7}`)
+	if body != `{"jsonrpc":"2.0","id":7,"result":"`+GasPrice+`"}` {
+		t.Fatalf("unexpected eth_gasPrice answer %s", body)
+	}
+	n.HandleRPCError("eth_call", -32000, "execution reverted")
+	if _, body := request(t, http.MethodPost, n.URL(), `{"jsonrpc":"2.0","method":"eth_call","id":"a"}`); !strings.Contains(body, `"id":"a","error":{"code":-32000`) {
+		t.Fatalf("unexpected eth_call answer %s", body)
+	}
+
+	requests := n.Requests()
+	if len(requests) != 7 || requests[5].RPCMethod != "eth_gasPrice" || requests[2].Path != "/cosmos/staking/v1beta1/pool?height=1" {
+		t.Fatalf("unexpected recorded requests %+v", requests)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	n := New(t)
+	store := db.NewMemoryStore(100)
+	n.Register(t, store, "evmos")
+
+	for _, endpointType := range []string{"rest", "jrpc", "web3"} {
+		ranking, err := db.RedisGetEndpointRanking(store, "EVMOS", endpointType)
+		if err != nil || len(ranking.Endpoints) != 1 || ranking.Endpoints[0].URL != n.URL() {
+			t.Fatalf("expected the node to be the %s endpoint, got %+v %v", endpointType, ranking, err)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+// Package grpc queries the gRPC nodes of the chains with the typed query
+// clients of the Cosmos SDK modules, trying the best ranked nodes until one
+// answers, as the upstream client does for the REST nodes.
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/tharsis/dashboard-backend/internal/v2/node/nodehealth"
+	"github.com/tharsis/dashboard-backend/internal/v2/node/upstream"
+	"github.com/tharsis/dashboard-backend/internal/v2/requestctx"
+	"github.com/tharsis/dashboard-backend/internal/v2/telemetry"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// maxAttempts is the number of ranked nodes tried before giving up
+const maxAttempts = 3
+
+// Client sends the queries to the gRPC nodes of a chain published in the
+// endpoint rankings. It implements the connection of the query clients, e.g.
+// banktypes.NewQueryClient(client), the typed clients are returned by Bank,
+// Staking, Distribution, Auth and Tendermint.
+//
+// Retry policy: the next node is tried when a node can not be reached, times
+// out, does not serve the service or fails with an internal error. The other
+// errors, e.g. NotFound or InvalidArgument, are answers and are returned as
+// upstream.ErrNotFound and upstream.ErrBadRequest errors.
+type Client struct {
+	store   db.Store
+	chain   string
+	tracker *nodehealth.Tracker
+}
+
+var _ gogrpc.ClientConnInterface = (*Client)(nil)
+
+// NewClient creates a client querying the gRPC nodes of the chain ranked in the store.
+func NewClient(store db.Store, chain string) *Client {
+	return &Client{
+		store:   store,
+		chain:   strings.ToUpper(chain),
+		tracker: nodehealth.Default(),
+	}
+}
+
+// Bank returns the query client of the bank module.
+func (c *Client) Bank() banktypes.QueryClient {
+	return banktypes.NewQueryClient(c)
+}
+
+// Staking returns the query client of the staking module.
+func (c *Client) Staking() stakingtypes.QueryClient {
+	return stakingtypes.NewQueryClient(c)
+}
+
+// Distribution returns the query client of the distribution module.
+func (c *Client) Distribution() distributiontypes.QueryClient {
+	return distributiontypes.NewQueryClient(c)
+}
+
+// Auth returns the query client of the auth module.
+func (c *Client) Auth() authtypes.QueryClient {
+	return authtypes.NewQueryClient(c)
+}
+
+// Tendermint returns the client of the Tendermint service, e.g. the latest block.
+func (c *Client) Tendermint() tmservice.ServiceClient {
+	return tmservice.NewServiceClient(c)
+}
+
+// Invoke sends the unary call to the best ranked nodes of the chain until one
+// answers. The calls are canceled with ctx, see requestctx.From, carry its
+// request ID and go to the nodes of its environment, see requestctx.Environment.
+// The errors are *upstream.Error values.
+func (c *Client) Invoke(ctx context.Context, method string, args interface{}, reply interface{}, opts ...gogrpc.CallOption) error {
+	ctx = requestctx.From(ctx)
+	// Query the nodes of the environment selected by the request
+	c = c.forChain(db.EnvironmentChain(requestctx.Environment(ctx), c.chain))
+	if requestID := logging.RequestID(ctx); requestID != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, strings.ToLower(logging.RequestIDHeader), requestID)
+	}
+	logger := logging.FromContext(ctx).With("chain", c.chain, "endpoint_type", upstream.GRPC, "method", method)
+
+	endpoints, err := c.tracker.Endpoints(c.store, c.chain, upstream.GRPC, maxAttempts)
+	if err != nil && err != db.ErrNotFound {
+		logger.Warn("Error reading endpoint ranking", "error", err)
+	}
+	if len(endpoints) == 0 {
+		telemetry.RecordUpstreamRequest(c.chain, upstream.GRPC, 1, telemetry.OutcomeMissingEndpoint, 0)
+		logger.Error("No endpoint available")
+		return c.newError(method, upstream.ErrNoEndpoints)
+	}
+
+	var failures []string
+	for i, endpoint := range endpoints {
+		done, err := c.attempt(ctx, logger, method, args, reply, opts, endpoint, i+1)
+		if done {
+			return err
+		}
+		failures = append(failures, err.Error())
+		if ctx.Err() != nil {
+			logger.Warn("Request canceled before any endpoint answered", "error", ctx.Err())
+			return c.newError(method, ctx.Err())
+		}
+	}
+
+	logger.Error("All endpoints failed to get response")
+	upstreamErr := c.newError(method, upstream.ErrUnavailable)
+	upstreamErr.Failures = failures
+	return upstreamErr
+}
+
+// attempt sends the call to the node ranked at index. It returns false when
+// the next node has to be tried, with the failure.
+func (c *Client) attempt(ctx context.Context, logger *logging.Logger, method string, args interface{}, reply interface{}, opts []gogrpc.CallOption, endpoint string, index int) (bool, error) {
+	cc, err := conn(endpoint)
+	if err != nil {
+		c.record(index, endpoint, telemetry.OutcomeError, 0)
+		return false, fmt.Errorf("node %s error: %v", endpoint, err)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, upstream.DefaultTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err = cc.Invoke(attemptCtx, method, args, reply, opts...)
+	duration := time.Since(start)
+	if err == nil {
+		c.record(index, endpoint, telemetry.OutcomeSuccess, duration)
+		return true, nil
+	}
+	// The call was canceled, this is not a failure of the node
+	if ctx.Err() != nil {
+		return false, fmt.Errorf("node %s canceled", endpoint)
+	}
+
+	st := status.Convert(err)
+	switch st.Code() {
+	case codes.NotFound:
+		c.record(index, endpoint, telemetry.OutcomeNotFound, duration)
+		return true, c.newAnswerError(method, upstream.ErrNotFound, st)
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange, codes.AlreadyExists:
+		c.record(index, endpoint, telemetry.OutcomeBadRequest, duration)
+		return true, c.newAnswerError(method, upstream.ErrBadRequest, st)
+	case codes.Internal, codes.Unknown:
+		c.record(index, endpoint, telemetry.OutcomeServerError, duration)
+	default:
+		c.record(index, endpoint, telemetry.OutcomeError, duration)
+	}
+	logger.Warn("Upstream request failed", "index", index, "error", err)
+	return false, fmt.Errorf("node %s error: %s", endpoint, st.Message())
+}
+
+// forChain returns the client querying the nodes of chain.
+func (c *Client) forChain(chain string) *Client {
+	if chain == c.chain {
+		return c
+	}
+	clone := *c
+	clone.chain = chain
+	return &clone
+}
+
+// NewStream is not supported, the query services only have unary methods.
+func (c *Client) NewStream(_ context.Context, _ *gogrpc.StreamDesc, method string, _ ...gogrpc.CallOption) (gogrpc.ClientStream, error) {
+	return nil, c.newError(method, errors.New("streams are not supported"))
+}
+
+// record reports the call to the telemetry and its outcome to the node health tracker.
+func (c *Client) record(index int, endpoint string, outcome string, duration time.Duration) {
+	telemetry.RecordUpstreamRequest(c.chain, upstream.GRPC, index, outcome, duration)
+	c.tracker.Record(endpoint, nodehealth.Failed(outcome))
+}
+
+func (c *Client) newError(method string, kind error) *upstream.Error {
+	return &upstream.Error{
+		Kind:         kind,
+		Chain:        c.chain,
+		EndpointType: upstream.GRPC,
+		Path:         method,
+	}
+}
+
+// newAnswerError returns the error of a node answer, the body is the message of the status.
+func (c *Client) newAnswerError(method string, kind error, st *status.Status) *upstream.Error {
+	err := c.newError(method, kind)
+	err.Body = []byte(st.Message())
+	return err
+}
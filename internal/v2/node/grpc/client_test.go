@@ -0,0 +1,126 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v2/node/upstream"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testAddress = "evmos1m9705gycgz6ymcharccuwzzhflap3l3nmchpfz"
+
+type bankServer struct {
+	banktypes.UnimplementedQueryServer
+	calls int64
+}
+
+func (s *bankServer) Balance(_ context.Context, req *banktypes.QueryBalanceRequest) (*banktypes.QueryBalanceResponse, error) {
+	atomic.AddInt64(&s.calls, 1)
+	if req.Denom != "aevmos" {
+		return nil, status.Error(codes.NotFound, "denom not found")
+	}
+	coin := sdk.NewInt64Coin(req.Denom, 1000)
+	return &banktypes.QueryBalanceResponse{Balance: &coin}, nil
+}
+
+// newBankNode starts a gRPC node serving the bank queries.
+func newBankNode(t *testing.T) (string, *bankServer) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := gogrpc.NewServer(gogrpc.ForceServerCodec(Codec{}))
+	bank := &bankServer{}
+	banktypes.RegisterQueryServer(srv, bank)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String(), bank
+}
+
+// closedEndpoint returns the address of a port nobody listens to.
+func closedEndpoint(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+	return addr
+}
+
+func TestClientQueriesTheRankedNodes(t *testing.T) {
+	live, bank := newBankNode(t)
+	store := db.NewMemoryStore(100)
+	now := time.Now()
+	ranking := []db.RankedEndpoint{
+		{URL: closedEndpoint(t), LastChecked: now, Score: 1},
+		{URL: live, LastChecked: now, Score: 0.5},
+	}
+	if err := db.RedisSetEndpointRanking(store, "EVMOS", upstream.GRPC, ranking, now); err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient(store, "evmos")
+
+	res, err := client.Bank().Balance(context.Background(), &banktypes.QueryBalanceRequest{Address: testAddress, Denom: "aevmos"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Balance.String() != "1000aevmos" {
+		t.Fatalf("unexpected balance %s", res.Balance)
+	}
+
+	// Not found is an answer, the next node is not tried
+	_, err = client.Bank().Balance(context.Background(), &banktypes.QueryBalanceRequest{Address: testAddress, Denom: "uatom"})
+	var upstreamErr *upstream.Error
+	if !errors.Is(err, upstream.ErrNotFound) || !errors.As(err, &upstreamErr) || string(upstreamErr.Body) != "denom not found" {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	if calls := atomic.LoadInt64(&bank.calls); calls != 2 {
+		t.Fatalf("expected 2 calls to the live node, got %d", calls)
+	}
+
+	// The node does not serve the staking queries
+	if _, err := client.Staking().Params(context.Background(), nil); !errors.Is(err, upstream.ErrUnavailable) {
+		t.Fatalf("expected an unavailable error, got %v", err)
+	}
+}
+
+func TestClientWithoutEndpoints(t *testing.T) {
+	client := NewClient(db.NewMemoryStore(100), "osmosis")
+	if _, err := client.Auth().Params(context.Background(), nil); !errors.Is(err, upstream.ErrNoEndpoints) {
+		t.Fatalf("expected a no endpoint error, got %v", err)
+	}
+}
+
+func TestTarget(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		address  string
+		secure   bool
+	}{
+		{"grpc.evmos.example:9090", "grpc.evmos.example:9090", false},
+		{"grpc.evmos.example:443", "grpc.evmos.example:443", true},
+		{"https://grpc.evmos.example", "grpc.evmos.example:443", true},
+		{"https://grpc.evmos.example:9443/", "grpc.evmos.example:9443", true},
+		{"http://127.0.0.1:9090", "127.0.0.1:9090", false},
+	}
+	for _, tc := range tests {
+		address, secure := Target(tc.endpoint)
+		if address != tc.address || secure != tc.secure {
+			t.Fatalf("%s: expected %s %v, got %s %v", tc.endpoint, tc.address, tc.secure, address, secure)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package grpc
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Target returns the address of the gRPC endpoint and whether TLS is used.
+// The endpoints are host:port addresses, or URLs: TLS is used for the
+// https:// URLs and the port 443, the https URLs use the port 443 by default.
+func Target(endpoint string) (string, bool) {
+	address := endpoint
+	secure := false
+	switch {
+	case strings.HasPrefix(address, "https://"):
+		address = strings.TrimPrefix(address, "https://")
+		secure = true
+	case strings.HasPrefix(address, "http://"):
+		address = strings.TrimPrefix(address, "http://")
+	}
+	address = strings.TrimRight(address, "/")
+
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		// No port
+		if secure {
+			return address + ":443", true
+		}
+		return address, false
+	}
+	return address, secure || port == "443"
+}
+
+// Dial creates a connection to the gRPC endpoint, see Target. The connection
+// is established by the first call.
+func Dial(endpoint string) (*gogrpc.ClientConn, error) {
+	address, secure := Target(endpoint)
+	creds := insecure.NewCredentials()
+	if secure {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	conn, err := gogrpc.Dial(address,
+		gogrpc.WithTransportCredentials(creds),
+		gogrpc.WithDefaultCallOptions(gogrpc.ForceCodec(Codec{})),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing %s: %w", endpoint, err)
+	}
+	return conn, nil
+}
+
+// conns are the connections to the nodes, shared by the clients of the process.
+var conns = struct {
+	sync.Mutex
+	byEndpoint map[string]*gogrpc.ClientConn
+}{byEndpoint: map[string]*gogrpc.ClientConn{}}
+
+// conn returns the shared connection to the endpoint.
+func conn(endpoint string) (*gogrpc.ClientConn, error) {
+	conns.Lock()
+	defer conns.Unlock()
+	if c, ok := conns.byEndpoint[endpoint]; ok {
+		return c, nil
+	}
+	c, err := Dial(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	conns.byEndpoint[endpoint] = c
+	return c, nil
+}
+
+// gogoMessage is implemented by the gogoproto messages of the Cosmos SDK.
+type gogoMessage interface {
+	Marshal() ([]byte, error)
+	Unmarshal([]byte) error
+}
+
+// Codec encodes the gogoproto messages of the Cosmos SDK query clients,
+// which the default gRPC codec does not support.
+type Codec struct{}
+
+func (Codec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(gogoMessage)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a gogoproto message", v)
+	}
+	return m.Marshal()
+}
+
+func (Codec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(gogoMessage)
+	if !ok {
+		return fmt.Errorf("%T is not a gogoproto message", v)
+	}
+	return m.Unmarshal(data)
+}
+
+// Name is the content subtype of the protobuf messages.
+func (Codec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,185 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+// Package nodehealth tracks the health of the nodes from the outcome of the
+// requests the API sends them, in addition to the endpoint cron checks.
+package nodehealth
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/tharsis/dashboard-backend/internal/v2/telemetry"
+)
+
+const (
+	// defaultThreshold consecutive failures open the circuit breaker of a node
+	defaultThreshold = 5
+	// defaultCooldown is the time a node gets no traffic once its breaker is open
+	defaultCooldown = 30 * time.Second
+	// failureRateWeight is the weight of the last request in the failure rate
+	failureRateWeight = 0.1
+)
+
+var defaultTracker = NewTracker(defaultThreshold, defaultCooldown)
+
+// Default returns the tracker shared by the node clients of the process.
+func Default() *Tracker {
+	return defaultTracker
+}
+
+// Tracker records the outcome of the requests sent to every node.
+// A node failing threshold requests in a row gets no traffic for the cooldown,
+// then a single failure is enough to stop its traffic again. The failure rate
+// of the nodes lowers their score in the endpoint rankings.
+type Tracker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	nodes     map[string]*nodeState
+	now       func() time.Time
+}
+
+type nodeState struct {
+	// consecutive failures
+	failures int
+	openedAt time.Time
+	// moving average of the failures, between 0 and 1
+	failureRate float64
+}
+
+// NewTracker creates a tracker opening the breaker of a node after threshold
+// consecutive failures, for the cooldown.
+func NewTracker(threshold int, cooldown time.Duration) *Tracker {
+	return &Tracker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		nodes:     map[string]*nodeState{},
+		now:       time.Now,
+	}
+}
+
+// Failed reports whether the request outcome is a failure of the node.
+// Not found and bad request responses are answers to bad queries.
+func Failed(outcome string) bool {
+	switch outcome {
+	case telemetry.OutcomeError, telemetry.OutcomeServerError, telemetry.OutcomeBadStatus, telemetry.OutcomeEmptyBody:
+		return true
+	default:
+		return false
+	}
+}
+
+// Record records the outcome of a request sent to the node.
+func (t *Tracker) Record(node string, failed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.nodes[node]
+	if !ok {
+		state = &nodeState{}
+		t.nodes[node] = state
+	}
+
+	if !failed {
+		state.failures = 0
+		state.failureRate *= 1 - failureRateWeight
+		return
+	}
+
+	state.failures++
+	state.failureRate = state.failureRate*(1-failureRateWeight) + failureRateWeight
+	if state.failures >= t.threshold {
+		if state.failures == t.threshold {
+			logging.Default().Warn("Node circuit breaker opened", "node", node, "failures", state.failures)
+		}
+		state.openedAt = t.now()
+	}
+}
+
+// Available reports whether the node can get traffic, it is false while its
+// circuit breaker is open.
+func (t *Tracker) Available(node string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.availableLocked(node)
+}
+
+func (t *Tracker) availableLocked(node string) bool {
+	state, ok := t.nodes[node]
+	if !ok || state.failures < t.threshold {
+		return true
+	}
+	return t.now().Sub(state.openedAt) >= t.cooldown
+}
+
+// Penalty returns the failure rate of the node, between 0 and 1.
+func (t *Tracker) Penalty(node string) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if state, ok := t.nodes[node]; ok {
+		return state.failureRate
+	}
+	return 0
+}
+
+// Rank sorts the endpoints by their score lowered by the penalty of the node,
+// best first, and drops the nodes with an open circuit breaker. If every
+// breaker is open the endpoints are all kept rather than none.
+func (t *Tracker) Rank(endpoints []db.RankedEndpoint) []db.RankedEndpoint {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	type penalized struct {
+		endpoint db.RankedEndpoint
+		score    float64
+		penalty  float64
+	}
+	ranked := make([]penalized, 0, len(endpoints))
+	for _, e := range endpoints {
+		if !t.availableLocked(e.URL) {
+			continue
+		}
+		var penalty float64
+		if state, ok := t.nodes[e.URL]; ok {
+			penalty = state.failureRate
+		}
+		ranked = append(ranked, penalized{endpoint: e, score: e.Score * (1 - penalty), penalty: penalty})
+	}
+	if len(ranked) == 0 {
+		for _, e := range endpoints {
+			ranked = append(ranked, penalized{endpoint: e, score: e.Score})
+		}
+	}
+
+	// The penalty also orders the endpoints without score
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].penalty < ranked[j].penalty
+	})
+
+	res := make([]db.RankedEndpoint, 0, len(ranked))
+	for _, r := range ranked {
+		res = append(res, r.endpoint)
+	}
+	return res
+}
+
+// Endpoints returns the URLs of up to n fresh endpoints of the chain, best first,
+// ranked with the penalties of the tracker. A non positive n returns every endpoint.
+func (t *Tracker) Endpoints(store db.Store, chain string, endpointType string, n int) ([]string, error) {
+	ranking, err := db.RedisGetEndpointRanking(store, chain, endpointType)
+	if err != nil {
+		return nil, err
+	}
+	endpoints := t.Rank(ranking.Best(0, db.EndpointMaxAge, time.Now()))
+	if n > 0 && len(endpoints) > n {
+		endpoints = endpoints[:n]
+	}
+	return db.EndpointURLs(endpoints), nil
+}
@@ -0,0 +1,102 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package nodehealth
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v2/telemetry"
+)
+
+func TestTrackerBreaker(t *testing.T) {
+	now := time.Now()
+	tracker := NewTracker(2, time.Minute)
+	tracker.now = func() time.Time { return now }
+
+	tracker.Record("https://a", true)
+	if !tracker.Available("https://a") {
+		t.Fatalf("expected the node to be available after 1 failure")
+	}
+	tracker.Record("https://a", true)
+	if tracker.Available("https://a") {
+		t.Fatalf("expected the breaker to open after 2 failures")
+	}
+
+	now = now.Add(time.Minute)
+	if !tracker.Available("https://a") {
+		t.Fatalf("expected the node to get traffic after the cooldown")
+	}
+	tracker.Record("https://a", true)
+	if tracker.Available("https://a") {
+		t.Fatalf("expected a single failure to open the breaker again")
+	}
+
+	now = now.Add(time.Minute)
+	tracker.Record("https://a", false)
+	tracker.Record("https://a", true)
+	if !tracker.Available("https://a") {
+		t.Fatalf("expected a success to close the breaker")
+	}
+	if p := tracker.Penalty("https://a"); p <= 0 || p >= 1 {
+		t.Fatalf("expected a penalty between 0 and 1, got %v", p)
+	}
+	if p := tracker.Penalty("https://b"); p != 0 {
+		t.Fatalf("expected no penalty for an unknown node, got %v", p)
+	}
+}
+
+func TestTrackerRank(t *testing.T) {
+	tracker := NewTracker(3, time.Minute)
+	endpoints := []db.RankedEndpoint{
+		{URL: "https://a", Score: 0.5},
+		{URL: "https://b", Score: 0.45},
+		{URL: "https://c", Score: 0.1},
+	}
+
+	// a passes the cron checks but fails the queries
+	tracker.Record("https://a", true)
+	tracker.Record("https://a", true)
+	if ranked := db.EndpointURLs(tracker.Rank(endpoints)); !reflect.DeepEqual(ranked, []string{"https://b", "https://a", "https://c"}) {
+		t.Fatalf("expected the penalty to lower a, got %v", ranked)
+	}
+
+	tracker.Record("https://a", true)
+	if ranked := db.EndpointURLs(tracker.Rank(endpoints)); !reflect.DeepEqual(ranked, []string{"https://b", "https://c"}) {
+		t.Fatalf("expected a to be dropped, got %v", ranked)
+	}
+
+	// Every endpoint is kept when all the breakers are open
+	for _, node := range []string{"https://b", "https://c"} {
+		for i := 0; i < 3; i++ {
+			tracker.Record(node, true)
+		}
+	}
+	if ranked := tracker.Rank(endpoints); len(ranked) != 3 {
+		t.Fatalf("expected every endpoint, got %v", ranked)
+	}
+}
+
+func TestTrackerEndpoints(t *testing.T) {
+	store := db.NewMemoryStore(100)
+	tracker := NewTracker(1, time.Minute)
+	now := time.Now()
+	endpoints := []db.RankedEndpoint{
+		{URL: "https://a", LastChecked: now, Score: 0.9},
+		{URL: "https://b", LastChecked: now, Score: 0.8},
+		{URL: "https://c", LastChecked: now, Score: 0.7},
+	}
+	if err := db.RedisSetEndpointRanking(store, "EVMOS", "rest", endpoints, now); err != nil {
+		t.Fatal(err)
+	}
+
+	tracker.Record("https://a", Failed(telemetry.OutcomeServerError))
+	tracker.Record("https://b", Failed(telemetry.OutcomeNotFound))
+	urls, err := tracker.Endpoints(store, "EVMOS", "rest", 1)
+	if err != nil || !reflect.DeepEqual(urls, []string{"https://b"}) {
+		t.Fatalf("expected the best available endpoint, got %v %v", urls, err)
+	}
+}
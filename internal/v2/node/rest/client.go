@@ -1,136 +1,68 @@
 package rest
 
 import (
-	"bytes"
+	"context"
 	"fmt"
-	"io"
 	"net/http"
-	"net/url"
-	"os"
 	"strings"
 	"time"
 
 	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v2/node/upstream"
 )
 
 type Client struct {
-	nodesEndpoints []string
-	network        string
+	upstream *upstream.Client
+	network  string
 }
 
 // NewClient returns a new instance of a RestClient.
-// It takes a network string as an argument, which is used to collect available REST node's endpoints from redis
-// for the desired network.
-func NewClient(network string) (*Client, error) {
-	nodes, err := getAvailableNodes(network)
-	if err != nil {
-		return nil, fmt.Errorf("error while getting available endpoints from redis: %w", err)
-	}
+// It takes a network string as an argument, which is used to query the REST
+// nodes of the desired network ranked in the store.
+func NewClient(store db.Store, network string) *Client {
 	return &Client{
-		nodesEndpoints: nodes,
-		network:        network,
-	}, nil
+		upstream: upstream.NewClient(store),
+		network:  network,
+	}
 }
 
 // post defines a wrapper around an HTTP POST request with a provided URL and body.
-// An error is returned if the request or reading the body fails.
-func (c *Client) post(endpoint string, body []byte) ([]byte, error) {
-	res, err := c.requestWithRetries("POST", endpoint, body)
+// An error is returned if no node answered successfully.
+func (c *Client) post(ctx context.Context, endpoint string, body []byte, timeout time.Duration) ([]byte, error) {
+	res, err := c.request(ctx, http.MethodPost, endpoint, body, timeout)
 	if err != nil {
 		return nil, fmt.Errorf("error while making post request: %w", err)
 	}
-
-	bz, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %w", err)
-	}
-	return bz, nil
+	return res, nil
 }
 
 // get defines a wrapper around an HTTP GET request with a provided URL.
-// An error is returned if the request or reading the body fails.
-func (c *Client) get(endpoint string) ([]byte, error) {
-	res, err := c.requestWithRetries("GET", endpoint, []byte{})
+// An error is returned if no node answered successfully.
+func (c *Client) get(ctx context.Context, endpoint string) ([]byte, error) {
+	res, err := c.request(ctx, http.MethodGet, endpoint, nil, 0)
 	if err != nil {
 		return nil, fmt.Errorf("error while making get request: %w", err)
 	}
-
-	bz, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %w", err)
-	}
-	return bz, nil
+	return res, nil
 }
 
 type BadRequestError struct {
 	Message string `json:"message"`
 }
 
-// postRequestWithRetries performs a POST request to the provided URL with the provided body.
-// It will retry the request with the next available node if the request fails.
-func (c *Client) requestWithRetries(method string, endpoint string, body []byte) (*http.Response, error) {
-	// TODO: this should be in a config file
-	client := http.Client{
-		Timeout: time.Second * 5,
-	}
-
-	var errorMessages []string
-	for i := range c.nodesEndpoints {
-		queryURL := joinURL(c.nodesEndpoints[i], endpoint)
-
-		var resp *http.Response
-		var err error
-
-		if method == "POST" {
-			resp, err = client.Post(queryURL, "application/json", bytes.NewBuffer(body))
-		} else {
-			resp, err = client.Get(queryURL)
-		}
-
-		if err == nil && resp.StatusCode == http.StatusOK {
-			return resp, nil // success, no need to retry
-		}
-
-		// Collect errors in case no endpoint is available
-		if err != nil {
-			errorMessages = append(errorMessages, fmt.Sprintf("node %v error: %v", c.nodesEndpoints[i], err))
-		} else {
-			errorMessages = append(errorMessages, fmt.Sprintf("node %v status code: %v", c.nodesEndpoints[i], resp.StatusCode))
-		}
-	}
-
-	return nil, fmt.Errorf(
-		"failed to post request at endpoint %v for network %v after %v attempts: %v",
-		endpoint,
-		c.network,
-		len(c.nodesEndpoints),
-		strings.Join(errorMessages, ", "),
-	)
-}
-
-// joinURL joins a base URL and a query path to form a valid URL.
-func joinURL(baseURL string, queryPath string) string {
-	u, err := url.Parse(baseURL)
-	if err != nil {
-		return ""
-	}
-	u.Path = queryPath
-	return u.String()
-}
-
-// getAvailableNodes returns a list of available nodes for the provided network
-// from redis.
-func getAvailableNodes(network string) ([]string, error) {
-	// If env variable env == "local" then the only option is localhost
-	env := os.Getenv("ENV")
-	if env == "local" {
-		return []string{"http://localhost:1317"}, nil
-	}
-
-	endpoints, err := db.RedisGetEndpoints(network, "rest")
+// request sends the request to the REST nodes of the network, see upstream.Client
+// for the retry policy. The requests are canceled with ctx, see requestctx.From.
+func (c *Client) request(ctx context.Context, method string, endpoint string, body []byte, timeout time.Duration) ([]byte, error) {
+	res, err := c.upstream.Do(ctx, upstream.Request{
+		Chain:        strings.ToUpper(c.network),
+		EndpointType: upstream.REST,
+		Method:       method,
+		Path:         endpoint,
+		Body:         body,
+		Timeout:      timeout,
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	return endpoints, nil
+	return res.Body, nil
 }
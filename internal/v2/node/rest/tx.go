@@ -1,9 +1,11 @@
 package rest
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/tharsis/dashboard-backend/internal/v2/encoding"
+	"github.com/tharsis/dashboard-backend/internal/v2/node/upstream"
 
 	"github.com/cosmos/cosmos-sdk/types/tx"
 )
@@ -11,10 +13,10 @@ import (
 // All endpoints under /cosmos/tx/ path should be defined in this file
 
 // BroadcastTx broadcasts transaction bytes to a Tendermint node
-// through its REST API.
-func (c *Client) BroadcastTx(txBytes []byte) (tx.BroadcastTxResponse, error) {
+// through its REST API. The request is canceled with ctx.
+func (c *Client) BroadcastTx(ctx context.Context, txBytes []byte) (tx.BroadcastTxResponse, error) {
 	broadcastTxEndpoint := "cosmos/tx/v1beta1/txs"
-	postResponse, err := c.post(broadcastTxEndpoint, txBytes)
+	postResponse, err := c.post(ctx, broadcastTxEndpoint, txBytes, upstream.BroadcastTimeout)
 	if err != nil {
 		return tx.BroadcastTxResponse{}, err
 	}
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -30,8 +31,8 @@ type VestingByAddressResponse struct {
 	types.QueryBalancesResponse
 }
 
-func (c *Client) GetVestingAccount(address string) (VestingByAddressResponse, error) {
-	accountRes, err := c.get("/cosmos/auth/v1beta1/accounts/" + address)
+func (c *Client) GetVestingAccount(ctx context.Context, address string) (VestingByAddressResponse, error) {
+	accountRes, err := c.get(ctx, "/cosmos/auth/v1beta1/accounts/"+address)
 	if err != nil {
 		return VestingByAddressResponse{}, fmt.Errorf("error querying vesting account from RPC: %s", err.Error())
 	}
@@ -42,7 +43,7 @@ func (c *Client) GetVestingAccount(address string) (VestingByAddressResponse, er
 		return VestingByAddressResponse{}, fmt.Errorf("error decoding vesting account: %s", err.Error())
 	}
 
-	rewardsRes, err := c.get("/evmos/vesting/v2/balances/" + address)
+	rewardsRes, err := c.get(ctx, "/evmos/vesting/v2/balances/"+address)
 	if err != nil {
 		return VestingByAddressResponse{}, fmt.Errorf("error querying vesting balance from RPC: %s", err.Error())
 	}
@@ -0,0 +1,53 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package upstream
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Kinds of the upstream errors, compare them with errors.Is.
+// A canceled request wraps context.Canceled or context.DeadlineExceeded instead.
+var (
+	// ErrNoEndpoints is returned when no endpoint is published for the chain.
+	ErrNoEndpoints = errors.New("no endpoint available")
+	// ErrUnavailable is returned when every node failed.
+	ErrUnavailable = errors.New("all endpoints are down")
+	// ErrNotFound is returned when a node answered 404 for the queried element.
+	ErrNotFound = errors.New("element not found")
+	// ErrBadRequest is returned when a node answered 400.
+	ErrBadRequest = errors.New("bad request")
+	// ErrServerError is returned when a node answered 5xx to a POST request,
+	// they are not retried as the node may have processed them.
+	ErrServerError = errors.New("node internal error")
+)
+
+// Error is returned by the client when the request did not get a successful answer.
+type Error struct {
+	// Kind is one of the error kinds above or the context error
+	Kind         error
+	Chain        string
+	EndpointType string
+	Path         string
+	// StatusCode and Body of the node answer for the not found, bad request
+	// and server errors
+	StatusCode int
+	Body       []byte
+	// Failures of the nodes that were tried, for the unavailable errors
+	Failures []string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%s %s request %s: %v", e.Chain, e.EndpointType, e.Path, e.Kind)
+	if len(e.Failures) > 0 {
+		msg += ": " + strings.Join(e.Failures, ", ")
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
@@ -0,0 +1,93 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package upstream
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// hedgeDelay holds the delay in nanoseconds, hedging is disabled when it is 0
+var hedgeDelay int64
+
+// SetHedgeDelay enables the hedging of the GET requests: the next endpoint is
+// also queried when the previous one has not answered after delay.
+// A zero delay disables hedging.
+func SetHedgeDelay(delay time.Duration) {
+	atomic.StoreInt64(&hedgeDelay, int64(delay))
+}
+
+// HedgeDelay returns the hedging delay, 0 when hedging is disabled.
+func HedgeDelay() time.Duration {
+	return time.Duration(atomic.LoadInt64(&hedgeDelay))
+}
+
+type hedgeResult[T any] struct {
+	val T
+	ok  bool
+}
+
+// hedge calls attempt with the indexes 0 to n-1, starting the next attempt when
+// the previous one fails or has not returned after delay. It returns the
+// result of the first successful attempt and cancels the others.
+func hedge[T any](ctx context.Context, n int, delay time.Duration, attempt func(ctx context.Context, i int) (T, bool)) (T, bool) {
+	var zero T
+	if n == 0 {
+		return zero, false
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Buffered so the canceled attempts never block
+	results := make(chan hedgeResult[T], n)
+	next, pending := 0, 0
+	start := func() {
+		i := next
+		next++
+		pending++
+		go func() {
+			val, ok := attempt(ctx, i)
+			results <- hedgeResult[T]{val: val, ok: ok}
+		}()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	start()
+
+	for pending > 0 {
+		select {
+		case res := <-results:
+			pending--
+			if res.ok {
+				return res.val, true
+			}
+			if next < n {
+				start()
+				resetTimer(timer, delay)
+			}
+		case <-timer.C:
+			if next < n {
+				start()
+				timer.Reset(delay)
+			}
+		case <-ctx.Done():
+			return zero, false
+		}
+	}
+	return zero, false
+}
+
+// resetTimer resets a timer that may not have fired.
+func resetTimer(t *time.Timer, d time.Duration) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+	t.Reset(d)
+}
@@ -0,0 +1,98 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package upstream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+)
+
+func TestHedge(t *testing.T) {
+	canceled := make(chan struct{})
+	attempt := func(ctx context.Context, i int) (string, bool) {
+		switch i {
+		case 0:
+			// The slow node is canceled once the next one answers
+			<-ctx.Done()
+			close(canceled)
+			return "", false
+		case 1:
+			return "", false
+		default:
+			return "fast", true
+		}
+	}
+
+	start := time.Now()
+	val, ok := hedge(context.Background(), 3, 50*time.Millisecond, attempt)
+	if !ok || val != "fast" {
+		t.Fatalf("expected the answer of the third node, got %q %v", val, ok)
+	}
+	// The third node is queried as soon as the second one fails
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the hedged requests to answer early, took %v", elapsed)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the slow request to be canceled")
+	}
+
+	if _, ok := hedge(context.Background(), 2, time.Millisecond, func(context.Context, int) (string, bool) {
+		return "", false
+	}); ok {
+		t.Fatalf("expected a failure when every node fails")
+	}
+}
+
+func TestClientHedged(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		_, _ = w.Write([]byte(`{"node":"slow"}`))
+	}))
+	defer slow.Close()
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"node":"fast"}`))
+	}))
+	defer fast.Close()
+
+	store := db.NewMemoryStore(100)
+	now := time.Now()
+	endpoints := []db.RankedEndpoint{
+		{URL: slow.URL, LastChecked: now, Score: 0.9},
+		{URL: fast.URL, LastChecked: now, Score: 0.8},
+	}
+	if err := db.RedisSetEndpointRanking(store, "HEDGE", "rest", endpoints, now); err != nil {
+		t.Fatal(err)
+	}
+
+	SetHedgeDelay(50 * time.Millisecond)
+	t.Cleanup(func() { SetHedgeDelay(0) })
+
+	start := time.Now()
+	client := NewClient(store)
+	res, err := client.Get(context.Background(), "HEDGE", REST, "/status")
+	if err != nil || string(res.Body) != `{"node":"fast"}` || res.Endpoint != fast.URL {
+		t.Fatalf("expected the answer of the fast node, got %+v %v", res, err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("expected the hedged request to answer early, took %v", elapsed)
+	}
+
+	// Canceled requests are not retried on the next nodes
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.Get(ctx, "HEDGE", REST, "/status"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the canceled request to fail, got %v", err)
+	}
+}
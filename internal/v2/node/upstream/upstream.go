@@ -0,0 +1,300 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+// Package upstream sends the requests to the REST, Tendermint JSON-RPC and web3
+// JSON-RPC nodes of the chains, trying the best ranked nodes until one answers.
+package upstream
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v1/metrics"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/tharsis/dashboard-backend/internal/v2/node/nodehealth"
+	"github.com/tharsis/dashboard-backend/internal/v2/requestctx"
+	"github.com/tharsis/dashboard-backend/internal/v2/telemetry"
+)
+
+// Endpoint types of the nodes.
+const (
+	REST = "rest"
+	JRPC = "jrpc"
+	Web3 = "web3"
+	// GRPC endpoints are queried with the grpc package
+	GRPC = "grpc"
+)
+
+const (
+	// DefaultTimeout bounds a request sent to a single node.
+	DefaultTimeout = 2 * time.Second
+	// BroadcastTimeout bounds the transaction broadcasts sent to a single node.
+	BroadcastTimeout = 6 * time.Second
+	// maxAttempts is the number of ranked nodes tried before giving up
+	maxAttempts = 3
+	// localREST is the node used for the REST requests when ENV is local
+	localREST = "http://localhost:1317"
+)
+
+// httpClient is shared by the clients, the requests are bounded by their context
+var httpClient = &http.Client{}
+
+// Request is a request sent to the nodes of a chain.
+type Request struct {
+	Chain string
+	// EndpointType is REST, JRPC or Web3
+	EndpointType string
+	Method       string
+	// Path is appended to the node URL, with its query string
+	Path string
+	// Body is sent as JSON when set
+	Body []byte
+	// Timeout of the request sent to each node, DefaultTimeout when zero
+	Timeout time.Duration
+	// Pinned queries the endpoint pinned at index 0 before the ranked ones
+	Pinned bool
+}
+
+// Response is the successful answer of a node.
+type Response struct {
+	StatusCode int
+	Body       []byte
+	// Endpoint is the node that answered
+	Endpoint string
+}
+
+// Client sends the requests to the nodes published in the endpoint rankings.
+//
+// Retry policy: the next node is tried when a node can not be reached, times out,
+// answers an empty body, an unexpected status or a 404 for the route itself.
+// A 404 for the queried element and a 400 are answers and are not retried, as
+// well as the 5xx answers to POST requests that the node may have processed.
+// GET requests are hedged when a hedge delay is set, see SetHedgeDelay.
+//
+// Every request is reported to the telemetry and to the node health tracker,
+// and the requests that no node answered are reported to Sentry.
+type Client struct {
+	store   db.Store
+	tracker *nodehealth.Tracker
+}
+
+// NewClient creates a client reading the endpoint rankings from the store.
+func NewClient(store db.Store) *Client {
+	return &Client{
+		store:   store,
+		tracker: nodehealth.Default(),
+	}
+}
+
+// Get sends a GET request to the nodes of the chain.
+func (c *Client) Get(ctx context.Context, chain string, endpointType string, path string) (*Response, error) {
+	return c.Do(ctx, Request{Chain: chain, EndpointType: endpointType, Method: http.MethodGet, Path: path})
+}
+
+// Post sends a POST request with a JSON body to the nodes of the chain.
+func (c *Client) Post(ctx context.Context, chain string, endpointType string, path string, body []byte) (*Response, error) {
+	return c.Do(ctx, Request{Chain: chain, EndpointType: endpointType, Method: http.MethodPost, Path: path, Body: body})
+}
+
+type attemptResult struct {
+	resp *Response
+	err  error
+}
+
+// Do sends the request to the best ranked nodes of the chain until one answers.
+// The requests are canceled with ctx, see requestctx.From, carry its request ID
+// and go to the nodes of its environment, see requestctx.Environment.
+// The errors are *Error values.
+func (c *Client) Do(ctx context.Context, req Request) (*Response, error) {
+	ctx = requestctx.From(ctx)
+	// The nodes of the environment selected by the request, e.g. TESTNET:EVMOS
+	req.Chain = db.EnvironmentChain(requestctx.Environment(ctx), strings.ToUpper(req.Chain))
+	logger := logging.FromContext(ctx).With("chain", req.Chain, "endpoint_type", req.EndpointType, "url", req.Path)
+
+	endpoints, first := c.endpoints(req)
+	if len(endpoints) == 0 {
+		telemetry.RecordUpstreamRequest(req.Chain, req.EndpointType, first, telemetry.OutcomeMissingEndpoint, 0)
+		logger.Error("No endpoint available")
+		return nil, newError(req, ErrNoEndpoints)
+	}
+
+	var mu sync.Mutex
+	var failures []string
+	attempt := func(ctx context.Context, n int) (attemptResult, bool) {
+		res, failure := c.attempt(ctx, logger, req, endpoints[n], first+n)
+		if failure != "" {
+			mu.Lock()
+			failures = append(failures, failure)
+			mu.Unlock()
+			return res, false
+		}
+		return res, true
+	}
+
+	var res attemptResult
+	var ok bool
+	if delay := HedgeDelay(); delay > 0 && req.Method == http.MethodGet {
+		res, ok = hedge(ctx, len(endpoints), delay, attempt)
+	} else {
+		for n := range endpoints {
+			if res, ok = attempt(ctx, n); ok || ctx.Err() != nil {
+				break
+			}
+		}
+	}
+	if ok {
+		return res.resp, res.err
+	}
+
+	if ctx.Err() != nil {
+		logger.Warn("Request canceled before any endpoint answered", "error", ctx.Err())
+		return nil, newError(req, ctx.Err())
+	}
+	logger.Error("All endpoints failed to get response")
+	metrics.Send(fmt.Sprintln("All endpoints failed to get response("+req.Method+"): ", req.Chain, req.Path))
+	err := newError(req, ErrUnavailable)
+	mu.Lock()
+	err.Failures = failures
+	mu.Unlock()
+	return nil, err
+}
+
+// endpoints returns the nodes to query, best first, and the index of the first one.
+func (c *Client) endpoints(req Request) ([]string, int) {
+	// If env variable env == "local" then the only option is localhost
+	if req.EndpointType == REST && os.Getenv("ENV") == "local" {
+		return []string{localREST}, 1
+	}
+
+	var endpoints []string
+	first := 1
+	if req.Pinned {
+		if endpoint, err := db.RedisGetEndpoint(c.store, req.Chain, req.EndpointType, "0"); err == nil {
+			endpoints = append(endpoints, endpoint)
+			first = 0
+		}
+	}
+	ranked, err := c.tracker.Endpoints(c.store, req.Chain, req.EndpointType, maxAttempts)
+	if err != nil && err != db.ErrNotFound {
+		logging.Default().Warn("Error reading endpoint ranking", "chain", req.Chain, "endpoint_type", req.EndpointType, "error", err)
+	}
+	return append(endpoints, ranked...), first
+}
+
+// attempt sends the request to the node ranked at index. It returns a failure
+// message when the next node has to be tried.
+func (c *Client) attempt(ctx context.Context, logger *logging.Logger, req Request, endpoint string, index int) (attemptResult, string) {
+	timeout := req.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(attemptCtx, req.Method, joinURL(endpoint, req.Path), bytes.NewReader(req.Body))
+	if err != nil {
+		return attemptResult{}, fmt.Sprintf("node %s error: %v", endpoint, err)
+	}
+	if len(req.Body) > 0 {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if requestID := logging.RequestID(ctx); requestID != "" {
+		httpReq.Header.Set(logging.RequestIDHeader, requestID)
+	}
+
+	start := time.Now()
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		// The request was canceled, this is not a failure of the node
+		if ctx.Err() != nil {
+			return attemptResult{}, fmt.Sprintf("node %s canceled", endpoint)
+		}
+		c.record(req, index, endpoint, telemetry.OutcomeError, time.Since(start))
+		logger.Warn("Upstream request failed", "index", index, "error", err)
+		return attemptResult{}, fmt.Sprintf("node %s error: %v", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil && ctx.Err() != nil {
+		return attemptResult{}, fmt.Sprintf("node %s canceled", endpoint)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		if err != nil || len(body) == 0 {
+			c.record(req, index, endpoint, telemetry.OutcomeEmptyBody, time.Since(start))
+			return attemptResult{}, fmt.Sprintf("node %s empty body", endpoint)
+		}
+		c.record(req, index, endpoint, telemetry.OutcomeSuccess, time.Since(start))
+		return attemptResult{resp: &Response{StatusCode: resp.StatusCode, Body: body, Endpoint: endpoint}}, ""
+
+	// Handle 404 responses from cosmos api, it's actually element not found
+	case resp.StatusCode == http.StatusNotFound:
+		// endpoint error
+		if bytes.Contains(body, []byte("Cannot "+req.Method)) {
+			c.record(req, index, endpoint, telemetry.OutcomeBadStatus, time.Since(start))
+			return attemptResult{}, fmt.Sprintf("node %s route not found", endpoint)
+		}
+		c.record(req, index, endpoint, telemetry.OutcomeNotFound, time.Since(start))
+		return attemptResult{err: newAnswerError(req, ErrNotFound, resp.StatusCode, body)}, ""
+
+	case resp.StatusCode == http.StatusBadRequest:
+		c.record(req, index, endpoint, telemetry.OutcomeBadRequest, time.Since(start))
+		return attemptResult{err: newAnswerError(req, ErrBadRequest, resp.StatusCode, body)}, ""
+
+	// Only the internal errors are final, the gateway errors of a down node are retried
+	case resp.StatusCode == http.StatusInternalServerError && req.Method != http.MethodGet:
+		telemetry.RecordUpstreamRequest(req.Chain, req.EndpointType, index, telemetry.OutcomeServerError, time.Since(start))
+		// Not counted as a node failure, invalid transactions get internal errors
+		c.tracker.Record(endpoint, false)
+		logger.Warn("Upstream request returned an internal error", "index", index)
+		return attemptResult{err: newAnswerError(req, ErrServerError, resp.StatusCode, body)}, ""
+
+	case resp.StatusCode >= http.StatusInternalServerError:
+		c.record(req, index, endpoint, telemetry.OutcomeServerError, time.Since(start))
+		return attemptResult{}, fmt.Sprintf("node %s status code: %d", endpoint, resp.StatusCode)
+
+	default:
+		c.record(req, index, endpoint, telemetry.OutcomeBadStatus, time.Since(start))
+		return attemptResult{}, fmt.Sprintf("node %s status code: %d", endpoint, resp.StatusCode)
+	}
+}
+
+// record reports the request to the telemetry and its outcome to the node health tracker.
+func (c *Client) record(req Request, index int, endpoint string, outcome string, duration time.Duration) {
+	telemetry.RecordUpstreamRequest(req.Chain, req.EndpointType, index, outcome, duration)
+	c.tracker.Record(endpoint, nodehealth.Failed(outcome))
+}
+
+func newError(req Request, kind error) *Error {
+	return &Error{
+		Kind:         kind,
+		Chain:        req.Chain,
+		EndpointType: req.EndpointType,
+		Path:         req.Path,
+	}
+}
+
+func newAnswerError(req Request, kind error, statusCode int, body []byte) *Error {
+	err := newError(req, kind)
+	err.StatusCode = statusCode
+	err.Body = body
+	return err
+}
+
+// joinURL appends the path, with its query string, to the node URL.
+func joinURL(endpoint string, path string) string {
+	if path == "" {
+		return endpoint
+	}
+	return strings.TrimRight(endpoint, "/") + "/" + strings.TrimLeft(path, "/")
+}
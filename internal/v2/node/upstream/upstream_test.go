@@ -0,0 +1,178 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package upstream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/db"
+	"github.com/tharsis/dashboard-backend/internal/v2/requestctx"
+)
+
+// newNode starts a node answering status and body, and counting its requests.
+func newNode(t *testing.T, status int, body string, requests *int) *httptest.Server {
+	t.Helper()
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(node.Close)
+	return node
+}
+
+func TestClientRetryPolicy(t *testing.T) {
+	testCases := []struct {
+		name   string
+		method string
+		status int
+		body   string
+		// retried is set when the second node has to be queried
+		retried bool
+		kind    error
+	}{
+		{name: "success", method: http.MethodGet, status: http.StatusOK, body: `{}`},
+		{name: "empty body", method: http.MethodGet, status: http.StatusOK, retried: true},
+		{name: "route not found", method: http.MethodGet, status: http.StatusNotFound, body: "Cannot GET /status", retried: true},
+		{name: "element not found", method: http.MethodGet, status: http.StatusNotFound, body: `{"code":5}`, kind: ErrNotFound},
+		{name: "bad request", method: http.MethodPost, status: http.StatusBadRequest, body: `{"code":3}`, kind: ErrBadRequest},
+		{name: "get server error", method: http.MethodGet, status: http.StatusInternalServerError, retried: true},
+		{name: "post server error", method: http.MethodPost, status: http.StatusInternalServerError, kind: ErrServerError},
+		{name: "post bad gateway", method: http.MethodPost, status: http.StatusBadGateway, retried: true},
+		{name: "post unavailable", method: http.MethodPost, status: http.StatusServiceUnavailable, retried: true},
+		{name: "post gateway timeout", method: http.MethodPost, status: http.StatusGatewayTimeout, retried: true},
+		{name: "bad status", method: http.MethodPost, status: http.StatusTeapot, retried: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var firstRequests, secondRequests int
+			first := newNode(t, tc.status, tc.body, &firstRequests)
+			second := newNode(t, http.StatusOK, `{"node":"second"}`, &secondRequests)
+
+			store := db.NewMemoryStore(100)
+			now := time.Now()
+			endpoints := []db.RankedEndpoint{
+				{URL: first.URL, LastChecked: now, Score: 0.9},
+				{URL: second.URL, LastChecked: now, Score: 0.8},
+			}
+			if err := db.RedisSetEndpointRanking(store, "RETRY", REST, endpoints, now); err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := NewClient(store).Do(context.Background(), Request{
+				Chain:        "retry",
+				EndpointType: REST,
+				Method:       tc.method,
+				Path:         "/status",
+				Body:         []byte(`{}`),
+			})
+			if firstRequests != 1 {
+				t.Fatalf("expected the first node to be queried once, got %d", firstRequests)
+			}
+			if tc.retried != (secondRequests == 1) {
+				t.Fatalf("expected retried %v, the second node got %d requests", tc.retried, secondRequests)
+			}
+
+			switch {
+			case tc.kind != nil:
+				var upstreamErr *Error
+				if !errors.Is(err, tc.kind) || !errors.As(err, &upstreamErr) {
+					t.Fatalf("expected a %v error, got %v", tc.kind, err)
+				}
+				if upstreamErr.StatusCode != tc.status || string(upstreamErr.Body) != tc.body {
+					t.Fatalf("expected the node answer in the error, got %d %q", upstreamErr.StatusCode, upstreamErr.Body)
+				}
+			case tc.retried:
+				if err != nil || res.Endpoint != second.URL {
+					t.Fatalf("expected the answer of the second node, got %+v %v", res, err)
+				}
+			default:
+				if err != nil || string(res.Body) != tc.body || res.Endpoint != first.URL {
+					t.Fatalf("expected the answer of the first node, got %+v %v", res, err)
+				}
+			}
+		})
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	store := db.NewMemoryStore(100)
+	client := NewClient(store)
+
+	if _, err := client.Get(context.Background(), "NONE", JRPC, "/status"); !errors.Is(err, ErrNoEndpoints) {
+		t.Fatalf("expected a no endpoint error, got %v", err)
+	}
+
+	var requests int
+	down := newNode(t, http.StatusBadGateway, "", &requests)
+	now := time.Now()
+	if err := db.RedisSetEndpointRanking(store, "DOWN", JRPC, []db.RankedEndpoint{{URL: down.URL, LastChecked: now}}, now); err != nil {
+		t.Fatal(err)
+	}
+	_, err := client.Get(context.Background(), "DOWN", JRPC, "/status")
+	var upstreamErr *Error
+	if !errors.Is(err, ErrUnavailable) || !errors.As(err, &upstreamErr) || len(upstreamErr.Failures) != 1 {
+		t.Fatalf("expected an unavailable error with the node failure, got %v", err)
+	}
+}
+
+func TestClientPinned(t *testing.T) {
+	var pinnedRequests, rankedRequests int
+	pinned := newNode(t, http.StatusOK, `{"node":"pinned"}`, &pinnedRequests)
+	ranked := newNode(t, http.StatusOK, `{"node":"ranked"}`, &rankedRequests)
+
+	store := db.NewMemoryStore(100)
+	now := time.Now()
+	if err := db.RedisSetEndpointRanking(store, "PINNED", Web3, []db.RankedEndpoint{{URL: ranked.URL, LastChecked: now}}, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set(context.Background(), "PINNED|web3|0", pinned.URL, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := NewClient(store).Do(context.Background(), Request{
+		Chain:        "PINNED",
+		EndpointType: Web3,
+		Method:       http.MethodPost,
+		Path:         "/",
+		Body:         []byte(`{}`),
+		Pinned:       true,
+	})
+	if err != nil || res.Endpoint != pinned.URL || rankedRequests != 0 {
+		t.Fatalf("expected the answer of the pinned node, got %+v %v", res, err)
+	}
+}
+
+func TestClientEnvironment(t *testing.T) {
+	var mainnetRequests, testnetRequests int
+	mainnet := newNode(t, http.StatusOK, `{"node":"mainnet"}`, &mainnetRequests)
+	testnet := newNode(t, http.StatusOK, `{"node":"testnet"}`, &testnetRequests)
+
+	store := db.NewMemoryStore(100)
+	now := time.Now()
+	for chain, node := range map[string]string{"EVMOS": mainnet.URL, "TESTNET:EVMOS": testnet.URL} {
+		if err := db.RedisSetEndpointRanking(store, chain, REST, []db.RankedEndpoint{{URL: node, LastChecked: now}}, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client := NewClient(store)
+	res, err := client.Get(context.Background(), "evmos", REST, "/")
+	if err != nil || res.Endpoint != mainnet.URL {
+		t.Fatalf("expected the answer of the mainnet node by default, got %+v %v", res, err)
+	}
+	res, err = client.Get(requestctx.WithEnvironment(context.Background(), "testnet"), "evmos", REST, "/")
+	if err != nil || res.Endpoint != testnet.URL {
+		t.Fatalf("expected the answer of the testnet node, got %+v %v", res, err)
+	}
+	if mainnetRequests != 1 || testnetRequests != 1 {
+		t.Fatalf("expected a request per node, got %d mainnet and %d testnet", mainnetRequests, testnetRequests)
+	}
+}
@@ -3,11 +3,15 @@
 package numia
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
 )
 
 type RPCClient struct {
@@ -35,7 +39,8 @@ func NewRPCClient() (*RPCClient, error) {
 }
 
 // get makes a GET request to the Numia API.
-func (c *RPCClient) get(url string, v any) error {
+// The ID of the request ctx belongs to is forwarded in the X-Request-ID header.
+func (c *RPCClient) get(ctx context.Context, url string, v any) error {
 	req, err := http.NewRequest("GET", c.domain+url, nil)
 	if err != nil {
 		return fmt.Errorf("error creating request: %s", err.Error())
@@ -45,14 +50,26 @@ func (c *RPCClient) get(url string, v any) error {
 	authHeader := fmt.Sprintf("Bearer %s", c.apiKey)
 	req.Header.Set("Authorization", authHeader)
 	req.Header.Set("Accept", "application/json")
+	if requestID := logging.RequestID(ctx); requestID != "" {
+		req.Header.Set(logging.RequestIDHeader, requestID)
+	}
 
 	// Send HTTP request
-	client := &http.Client{}
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+	start := time.Now()
 	resp, err := client.Do(req)
 	if err != nil {
+		logging.FromContext(ctx).Warn("Numia request failed", "url", url, "error", err)
 		return fmt.Errorf("error making request: %s", err.Error())
 	}
 	defer resp.Body.Close()
+	logging.FromContext(ctx).Debug("Numia request completed",
+		"url", url,
+		"status", resp.StatusCode,
+		"duration_ms", float64(time.Since(start).Microseconds())/1000,
+	)
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -2,6 +2,8 @@
 // SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
 package numia
 
+import "context"
+
 type HeightResponse struct {
 	LatestBlockHash   string `json:"latestBlockHash"`
 	LatestBlockHeight string `json:"latestBlockHeight"`
@@ -10,10 +12,10 @@ type HeightResponse struct {
 
 // QueryHeight queries the height of the latest block on the EVMOS blockchain.
 // URL: "https://evmos.numia.xyz/height"
-func (c *RPCClient) QueryHeight() (*HeightResponse, error) {
+func (c *RPCClient) QueryHeight(ctx context.Context) (*HeightResponse, error) {
 	// Unmarshal response into struct
 	var data HeightResponse
-	if err := c.get("/height", &data); err != nil {
+	if err := c.get(ctx, "/height", &data); err != nil {
 		return nil, err
 	}
 
@@ -35,9 +37,9 @@ type DelegationResponse struct {
 // QueryDelegations queries the delegations of the requested address.
 // It handles both Hex and Bech32 addresses.
 // URL: "https://evmos.numia.xyz/evmos/delegations"
-func (c *RPCClient) QueryDelegations(address string) ([]DelegationResponse, error) {
+func (c *RPCClient) QueryDelegations(ctx context.Context, address string) ([]DelegationResponse, error) {
 	var data []DelegationResponse
-	if err := c.get("/evmos/delegations/"+address, &data); err != nil {
+	if err := c.get(ctx, "/evmos/delegations/"+address, &data); err != nil {
 		return nil, err
 	}
 
@@ -54,9 +56,9 @@ type RewardsResponse struct {
 // QueryRewards queries the rewards of the requested address.
 // It handles both Hex and Bech32 addresses.
 // URL: "https://evmos.numia.xyz/evmos/rewards"
-func (c *RPCClient) QueryRewards(address string) ([]RewardsResponse, error) {
+func (c *RPCClient) QueryRewards(ctx context.Context, address string) ([]RewardsResponse, error) {
 	var data []RewardsResponse
-	if err := c.get("/evmos/rewards/"+address, &data); err != nil {
+	if err := c.get(ctx, "/evmos/rewards/"+address, &data); err != nil {
 		return nil, err
 	}
 
@@ -113,9 +115,9 @@ type VestingAccountResponse struct {
 // QueryVestingAccount queries the vesting account information of the requested address.
 // It handles both Hex and Bech32 addresses.
 // URL: "https://evmos.numia.xyz/evmos/account/{address}/vesting_balances"
-func (c *RPCClient) QueryVestingAccount(address string) (VestingAccountResponse, error) {
+func (c *RPCClient) QueryVestingAccount(ctx context.Context, address string) (VestingAccountResponse, error) {
 	var data VestingAccountResponse
-	if err := c.get("/evmos/account/"+address+"/vesting_balances", &data); err != nil {
+	if err := c.get(ctx, "/evmos/account/"+address+"/vesting_balances", &data); err != nil {
 		return VestingAccountResponse{}, err
 	}
 
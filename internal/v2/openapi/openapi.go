@@ -0,0 +1,220 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+// Package openapi builds the OpenAPI 3 document of the API from the Go
+// request and response types, and validates request bodies against it.
+package openapi
+
+import (
+	"regexp"
+	"strings"
+)
+
+const version = "3.0.3"
+
+// Document is an OpenAPI 3.0 document.
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+	Security   []SecurityRequirement           `json:"security,omitempty"`
+}
+
+// Info contains the metadata of the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Components contains the schemas referenced by the operations.
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme describes how the clients authenticate.
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Scheme      string `json:"scheme,omitempty"`
+	Name        string `json:"name,omitempty"`
+	In          string `json:"in,omitempty"`
+}
+
+// SecurityRequirement lists the security schemes required by an operation,
+// an empty requirement makes authentication optional.
+type SecurityRequirement map[string][]string
+
+// Operation is a single API operation on a path.
+type Operation struct {
+	OperationID string                `json:"operationId,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []SecurityRequirement `json:"security,omitempty"`
+}
+
+// Parameter is a path or query parameter.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes the body of an operation.
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes a response of an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a body.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Route describes a route registered in the router.
+// The request and response schemas are generated from the zero values
+// of Request and Response unless RequestSchema or ResponseSchema are set.
+type Route struct {
+	Method string
+	// Path uses the router syntax, e.g. /BalanceByDenom/{chain}/{address}/{denom:*}
+	Path        string
+	Summary     string
+	Description string
+	Tag         string
+	Deprecated  bool
+	Query       []Parameter
+	Request     interface{}
+	// RequestSchema overrides the schema generated from Request
+	RequestSchema *Schema
+	Response      interface{}
+	// ResponseSchema overrides the schema generated from Response
+	ResponseSchema *Schema
+	// ContentType of the successful response, application/json by default
+	ContentType string
+	Security    []SecurityRequirement
+}
+
+// ErrorResponse is the body of the error responses.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+var pathParamRegex = regexp.MustCompile(`\{([^}:]+)(:\*)?\}`)
+
+// PathFromRoute converts a router path to an OpenAPI path, e.g. {denom:*} becomes {denom}.
+func PathFromRoute(route string) string {
+	return pathParamRegex.ReplaceAllString(route, "{$1}")
+}
+
+// NewDocument builds the OpenAPI document describing the routes.
+func NewDocument(info Info, routes []Route) *Document {
+	g := newGenerator()
+	errorSchema := g.schemaOf(ErrorResponse{})
+
+	doc := &Document{
+		OpenAPI: version,
+		Info:    info,
+		Paths:   map[string]map[string]Operation{},
+	}
+
+	for _, route := range routes {
+		op := Operation{
+			OperationID: operationID(route),
+			Summary:     route.Summary,
+			Description: route.Description,
+			Deprecated:  route.Deprecated,
+			Parameters:  pathParameters(route.Path),
+			Security:    route.Security,
+			Responses: map[string]Response{
+				"default": {
+					Description: "Error",
+					Content:     jsonContent(errorSchema),
+				},
+			},
+		}
+		if route.Tag != "" {
+			op.Tags = []string{route.Tag}
+		}
+		op.Parameters = append(op.Parameters, route.Query...)
+
+		if route.Request != nil || route.RequestSchema != nil {
+			schema := route.RequestSchema
+			if schema == nil {
+				schema = g.schemaOf(route.Request)
+			}
+			op.RequestBody = &RequestBody{Required: true, Content: jsonContent(schema)}
+		}
+
+		responseSchema := route.ResponseSchema
+		if responseSchema == nil {
+			responseSchema = g.schemaOf(route.Response)
+		}
+		contentType := route.ContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		op.Responses["200"] = Response{
+			Description: "Successful response",
+			Content:     map[string]MediaType{contentType: {Schema: responseSchema}},
+		}
+
+		path := PathFromRoute(route.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]Operation{}
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = op
+	}
+
+	doc.Components.Schemas = g.components
+	return doc
+}
+
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
+
+func pathParameters(route string) []Parameter {
+	matches := pathParamRegex.FindAllStringSubmatch(route, -1)
+	params := make([]Parameter, 0, len(matches))
+	for _, m := range matches {
+		param := Parameter{
+			Name:     m[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		}
+		if m[2] != "" {
+			param.Description = "Matches the rest of the path, including slashes"
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// operationID builds a unique identifier from the method and the path,
+// e.g. GET /NetworkConfig/{name} becomes getNetworkConfigName.
+func operationID(route Route) string {
+	var sb strings.Builder
+	sb.WriteString(strings.ToLower(route.Method))
+	for _, part := range strings.FieldsFunc(route.Path, func(r rune) bool {
+		return r == '/' || r == '{' || r == '}' || r == ':' || r == '*' || r == '_' || r == '.'
+	}) {
+		sb.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return sb.String()
+}
@@ -0,0 +1,111 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testCoin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+type testEmbedded struct {
+	Memo string `json:"memo"`
+}
+
+type testParams struct {
+	testEmbedded
+	Network string          `json:"network" openapi:"required"`
+	TxBytes []byte          `json:"tx_bytes" openapi:"required"`
+	Gas     uint64          `json:"gas"`
+	Height  int             `json:"height,string"`
+	Coins   []testCoin      `json:"coins"`
+	Extra   json.RawMessage `json:"extra"`
+	Time    time.Time       `json:"time"`
+	Ignored string          `json:"-"`
+}
+
+func TestNewDocument(t *testing.T) {
+	doc := NewDocument(Info{Title: "test", Version: "1"}, []Route{
+		{Method: "POST", Path: "/tx/{chain}", Request: testParams{}, Response: testCoin{}},
+		{Method: "GET", Path: "/balance/{address}/{denom:*}", Response: []testCoin{}},
+	})
+
+	op, ok := doc.Paths["/balance/{address}/{denom}"]["get"]
+	if !ok {
+		t.Fatalf("Catch-all parameters must be converted to OpenAPI parameters")
+	}
+	if len(op.Parameters) != 2 || op.Parameters[1].Name != "denom" {
+		t.Fatalf("Invalid path parameters %+v", op.Parameters)
+	}
+
+	params, ok := doc.Components.Schemas["openapi.testParams"]
+	if !ok {
+		t.Fatalf("Named structs must be stored in the components")
+	}
+	expected := map[string]string{
+		"memo":     "string",
+		"network":  "string",
+		"tx_bytes": "string",
+		"gas":      "integer",
+		"height":   "string",
+		"coins":    "array",
+		"extra":    "",
+		"time":     "string",
+	}
+	if len(params.Properties) != len(expected) {
+		t.Fatalf("Expected %d properties, got %d", len(expected), len(params.Properties))
+	}
+	for name, typ := range expected {
+		if params.Properties[name] == nil || params.Properties[name].Type != typ {
+			t.Fatalf("Expected property %s of type %q", name, typ)
+		}
+	}
+	if strings.Join(params.Required, ",") != "network,tx_bytes" {
+		t.Fatalf("Invalid required properties %v", params.Required)
+	}
+	if params.Properties["coins"].Items.Ref != componentsPrefix+"openapi.testCoin" {
+		t.Fatalf("Nested structs must be referenced")
+	}
+}
+
+func TestValidator(t *testing.T) {
+	validator := NewValidator(testParams{})
+
+	testCases := []struct {
+		body string
+		err  string
+	}{
+		{`{"network": "evmos", "tx_bytes": "CgE="}`, ""},
+		{`{"network": "evmos", "tx_bytes": "CgE=", "coins": [{"denom": "aevmos", "amount": "1"}], "unknown": 1}`, ""},
+		{`{"Network": "evmos", "TX_BYTES": "CgE="}`, ""},
+		{`{"tx_bytes": "CgE="}`, "network is required"},
+		{`{"Network": "", "tx_bytes": "CgE="}`, "Network cannot be empty"},
+		{`{"network": "", "tx_bytes": "CgE="}`, "network cannot be empty"},
+		{`{"network": "evmos", "tx_bytes": [1, 2]}`, "tx_bytes must be of type string, got array"},
+		{`{"network": "evmos", "tx_bytes": "not base64"}`, "tx_bytes must be base64 encoded"},
+		{`{"network": "evmos", "tx_bytes": "CgE=", "gas": 1.5}`, "gas must be of type integer, got number"},
+		{`{"network": "evmos", "tx_bytes": "CgE=", "coins": [{"denom": 1}]}`, "coins[0].denom must be of type string, got number"},
+		{`[]`, "request body must be of type object, got array"},
+		{`{`, "invalid JSON"},
+	}
+
+	for _, tc := range testCases {
+		err := validator.Validate([]byte(tc.body))
+		if tc.err == "" {
+			if err != nil {
+				t.Fatalf("Expected %s to be valid, got %s", tc.body, err.Error())
+			}
+			continue
+		}
+		if err == nil || !strings.HasPrefix(err.Error(), tc.err) {
+			t.Fatalf("Expected %s to fail with %q, got %v", tc.body, tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package openapi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// Validator validates JSON documents against the schema of a Go type.
+// It uses the same schema that is published in the OpenAPI document.
+type Validator struct {
+	schema     *Schema
+	components map[string]*Schema
+}
+
+// NewValidator creates a validator for the JSON encoding of the type of v.
+func NewValidator(v interface{}) *Validator {
+	g := newGenerator()
+	return &Validator{
+		schema:     g.schemaOf(v),
+		components: g.components,
+	}
+}
+
+// NewSchemaValidator creates a validator for a schema written by hand, e.g. for
+// the bodies whose JSON encoding differs from the one of their Go type.
+func NewSchemaValidator(schema *Schema) *Validator {
+	return &Validator{schema: schema, components: map[string]*Schema{}}
+}
+
+// Validate returns an error describing the first value of body that does not match the schema.
+func (v *Validator) Validate(body []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return v.validate(v.schema, value, "")
+}
+
+func (v *Validator) validate(s *Schema, value interface{}, path string) error {
+	if s.Ref != "" {
+		component, ok := v.components[strings.TrimPrefix(s.Ref, componentsPrefix)]
+		if !ok {
+			return fmt.Errorf("unknown schema %s", s.Ref)
+		}
+		s = component
+	}
+	if s.Type == "" {
+		return nil
+	}
+	if value == nil {
+		if s.Nullable {
+			return nil
+		}
+		return fieldError(path, "cannot be null")
+	}
+
+	switch s.Type {
+	case "object":
+		return v.validateObject(s, value, path)
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return typeError(path, s.Type, value)
+		}
+		if s.MinItems != nil && len(items) < *s.MinItems {
+			return fieldError(path, "cannot be empty")
+		}
+		for i, item := range items {
+			if err := v.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return typeError(path, s.Type, value)
+		}
+		if s.MinLength != nil && len(str) < *s.MinLength {
+			return fieldError(path, "cannot be empty")
+		}
+		if s.Format == "byte" {
+			if _, err := base64.StdEncoding.DecodeString(str); err != nil {
+				return fieldError(path, "must be base64 encoded")
+			}
+		}
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return typeError(path, s.Type, value)
+		}
+		if _, ok := new(big.Int).SetString(number.String(), 10); !ok {
+			return typeError(path, s.Type, value)
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			return typeError(path, s.Type, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return typeError(path, s.Type, value)
+		}
+	}
+	return nil
+}
+
+func (v *Validator) validateObject(s *Schema, value interface{}, path string) error {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return typeError(path, s.Type, value)
+	}
+	for _, name := range s.Required {
+		if !hasField(object, name) {
+			return fieldError(joinPath(path, name), "is required")
+		}
+	}
+	// Sorted so the same body always reports the same error
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fieldValue := object[name]
+		fieldSchema := findProperty(s.Properties, name)
+		if fieldSchema == nil {
+			fieldSchema = s.AdditionalProperties
+		}
+		if fieldSchema == nil {
+			// Unknown fields are ignored by encoding/json
+			continue
+		}
+		if err := v.validate(fieldSchema, fieldValue, joinPath(path, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasField reports whether the object has the field, matching the keys
+// case-insensitively as encoding/json does.
+func hasField(object map[string]interface{}, name string) bool {
+	if _, ok := object[name]; ok {
+		return true
+	}
+	for key := range object {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// findProperty returns the schema of the property the key is decoded into,
+// preferring an exact match and then a case-insensitive one as encoding/json
+// does.
+func findProperty(properties map[string]*Schema, key string) *Schema {
+	if schema, ok := properties[key]; ok {
+		return schema
+	}
+	for name, schema := range properties {
+		if strings.EqualFold(name, key) {
+			return schema
+		}
+	}
+	return nil
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func fieldError(path string, msg string) error {
+	if path == "" {
+		return fmt.Errorf("request body %s", msg)
+	}
+	return fmt.Errorf("%s %s", path, msg)
+}
+
+func typeError(path string, expected string, value interface{}) error {
+	return fieldError(path, fmt.Sprintf("must be of type %s, got %s", expected, jsonType(value)))
+}
+
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+// Package requestctx provides the context bounding the upstream requests sent
+// on behalf of a client request.
+package requestctx
+
+import (
+	"context"
+	"time"
+
+	"github.com/tharsis/dashboard-backend/internal/v1/constants"
+	"github.com/tharsis/dashboard-backend/internal/v2/logging"
+	"github.com/valyala/fasthttp"
+)
+
+// UserValue is the fasthttp user value holding the request context.
+const UserValue = "requestContext"
+
+// EnvironmentValue is the fasthttp user value holding the environment of the
+// request, e.g. testnet, see Environment.
+const EnvironmentValue = "environment"
+
+type environmentKey struct{}
+
+// Attach stores in the request a context carrying its request ID and logger,
+// canceled once timeout elapses or the returned function is called.
+// A zero timeout never elapses.
+func Attach(ctx *fasthttp.RequestCtx, timeout time.Duration) context.CancelFunc {
+	var c context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		c, cancel = context.WithTimeout(Detach(ctx), timeout)
+	} else {
+		c, cancel = context.WithCancel(Detach(ctx))
+	}
+	ctx.SetUserValue(UserValue, c)
+	return cancel
+}
+
+// From returns the context to use for the upstream requests sent on behalf of ctx.
+// The fasthttp request contexts are never canceled while the request is served,
+// the context attached to the request is used instead.
+func From(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	if c, ok := ctx.Value(UserValue).(context.Context); ok {
+		return c
+	}
+	if _, ok := ctx.(*fasthttp.RequestCtx); ok {
+		// The fasthttp context can not be used as a parent outside of a server
+		return Detach(ctx)
+	}
+	return ctx
+}
+
+// WithEnvironment returns a context whose upstream requests are sent to the
+// nodes of the environment, a configuration type of the registry.
+func WithEnvironment(ctx context.Context, environment string) context.Context {
+	return context.WithValue(ctx, environmentKey{}, environment)
+}
+
+// Environment returns the environment the requests sent on behalf of ctx are
+// routed to, mainnet when none was selected.
+func Environment(ctx context.Context) string {
+	if ctx == nil {
+		return constants.Mainnet
+	}
+	if environment, ok := ctx.Value(environmentKey{}).(string); ok && environment != "" {
+		return environment
+	}
+	if environment, ok := ctx.Value(EnvironmentValue).(string); ok && environment != "" {
+		return environment
+	}
+	return constants.Mainnet
+}
+
+// Detach returns a context carrying the request scope of ctx, its request ID,
+// logger and environment, but none of its other values nor its cancellation.
+// It is used for the work outliving the request, e.g. shared with other requests.
+func Detach(ctx context.Context) context.Context {
+	c := logging.NewContext(context.Background(), logging.RequestID(ctx), logging.FromContext(ctx))
+	return WithEnvironment(c, Environment(ctx))
+}
@@ -0,0 +1,115 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+// Package telemetry exposes the Prometheus metrics of the API: latency per
+// route, outcome of every upstream node request and Redis cache usage.
+package telemetry
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/fasthttp/router"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+)
+
+const namespace = "dashboard_backend"
+
+// Outcomes of a request made to an upstream node.
+const (
+	OutcomeSuccess         = "success"
+	OutcomeError           = "error"
+	OutcomeNotFound        = "not_found"
+	OutcomeBadRequest      = "bad_request"
+	OutcomeServerError     = "server_error"
+	OutcomeBadStatus       = "bad_status"
+	OutcomeEmptyBody       = "empty_body"
+	OutcomeMissingEndpoint = "missing_endpoint"
+)
+
+// Results of a cache lookup.
+const (
+	CacheHit      = "hit"
+	CacheMiss     = "miss"
+	CacheFallback = "fallback"
+	CacheStale    = "stale"
+)
+
+// unmatchedRoute is used as route label for requests that did not match any route
+const unmatchedRoute = "unmatched"
+
+var (
+	routeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of the API requests by method, route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	upstreamRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upstream_requests_total",
+		Help:      "Requests made to upstream nodes by chain, endpoint type, endpoint index and outcome.",
+	}, []string{"chain", "endpoint_type", "index", "outcome"})
+
+	upstreamDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "upstream_request_duration_seconds",
+		Help:      "Latency of the requests made to upstream nodes by chain, endpoint type and endpoint index.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"chain", "endpoint_type", "index"})
+
+	cacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_lookups_total",
+		Help:      "Redis cache lookups by cache and result (hit, miss or fallback).",
+	}, []string{"cache", "result"})
+)
+
+func init() {
+	prometheus.MustRegister(routeDuration, upstreamRequests, upstreamDuration, cacheLookups)
+}
+
+// Handler returns the fasthttp handler that serves the metrics
+// in the Prometheus exposition format.
+func Handler() fasthttp.RequestHandler {
+	return fasthttpadaptor.NewFastHTTPHandler(promhttp.Handler())
+}
+
+// InstrumentRoutes wraps the router handler and records the latency of every request
+// labeled with the route pattern it matched, e.g. /v2/vesting/{address}.
+// The router must have SaveMatchedRoutePath enabled.
+func InstrumentRoutes(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		start := time.Now()
+		next(ctx)
+
+		route, ok := ctx.UserValue(router.MatchedRoutePathParam).(string)
+		if !ok {
+			route = unmatchedRoute
+		}
+		routeDuration.WithLabelValues(
+			string(ctx.Method()),
+			route,
+			strconv.Itoa(ctx.Response.StatusCode()),
+		).Observe(time.Since(start).Seconds())
+	}
+}
+
+// RecordUpstreamRequest records the outcome and latency of a request made to
+// the node stored at the given index for the chain and endpoint type.
+func RecordUpstreamRequest(chain, endpointType string, index int, outcome string, duration time.Duration) {
+	idx := strconv.Itoa(index)
+	upstreamRequests.WithLabelValues(chain, endpointType, idx, outcome).Inc()
+	if outcome != OutcomeMissingEndpoint {
+		upstreamDuration.WithLabelValues(chain, endpointType, idx).Observe(duration.Seconds())
+	}
+}
+
+// RecordCacheLookup records the result of a lookup in the given cache.
+func RecordCacheLookup(cache, result string) {
+	cacheLookups.WithLabelValues(cache, result).Inc()
+}
@@ -0,0 +1,63 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/backend/blob/main/LICENSE)
+
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/fasthttp/router"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/valyala/fasthttp"
+)
+
+func TestInstrumentRoutes(t *testing.T) {
+	r := router.New()
+	r.SaveMatchedRoutePath = true
+	r.GET("/v2/vesting/{address}", func(ctx *fasthttp.RequestCtx) {})
+	handler := InstrumentRoutes(r.Handler)
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/v2/vesting/evmos1abc")
+	handler(ctx)
+
+	ctx = &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/not-a-route")
+	handler(ctx)
+
+	if n := testutil.CollectAndCount(routeDuration); n != 2 {
+		t.Fatalf("Expected 2 route series, got %d", n)
+	}
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(routeDuration)
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Error gathering metrics: %s", err)
+	}
+	routes := map[string]bool{}
+	for _, m := range families[0].GetMetric() {
+		for _, l := range m.GetLabel() {
+			if l.GetName() == "route" {
+				routes[l.GetValue()] = true
+			}
+		}
+	}
+	if !routes["/v2/vesting/{address}"] || !routes[unmatchedRoute] {
+		t.Fatalf("Route patterns were not used as labels: %v", routes)
+	}
+}
+
+func TestRecordUpstreamRequest(t *testing.T) {
+	RecordUpstreamRequest("EVMOS", "rest", 1, OutcomeSuccess, 0)
+	RecordUpstreamRequest("EVMOS", "rest", 1, OutcomeSuccess, 0)
+	RecordUpstreamRequest("EVMOS", "rest", 2, OutcomeMissingEndpoint, 0)
+
+	if v := testutil.ToFloat64(upstreamRequests.WithLabelValues("EVMOS", "rest", "1", OutcomeSuccess)); v != 2 {
+		t.Fatalf("Expected 2 successful requests, got %v", v)
+	}
+	if n := testutil.CollectAndCount(upstreamDuration); n != 1 {
+		t.Fatalf("Missing endpoints must not record latency, got %d series", n)
+	}
+}